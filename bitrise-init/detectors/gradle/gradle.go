@@ -0,0 +1,556 @@
+package gradle
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/bitrise-io/bitrise-init/detectors/direntry"
+	"github.com/bitrise-io/bitrise-init/metrics"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+/*
+Relevant Gradle project files:
+
+Gradle wrapper scripts (gradlew and gradlew.bat):
+	The presence of the gradlew and gradlew.bat files in the root directory of a project is a clear indicator that Gradle is used.
+
+Settings File (settings.gradle[.kts]): The settings file is the entry point of every Gradle project.
+	The primary purpose of the settings file is to add subprojects to your build.
+	Gradle supports single and multi-project builds.
+	- For single-project builds, the settings file is optional.
+	- For multi-project builds, the settings file is mandatory and declares all subprojects.
+
+Gradle wrapper properties (gradle/wrapper/gradle-wrapper.properties):
+	The distributionUrl property sets the Gradle distribution (version and type) the wrapper scripts download and run.
+
+Gradle wrapper jar (gradle/wrapper/gradle-wrapper.jar):
+	The wrapper scripts run the jar, which downloads the distribution. Gradle publishes the checksum of the jar of every release.
+*/
+
+/*
+Java toolchain (build.gradle[.kts]): The toolchain selects the JDK compiling and testing the project, independently of the JDK running Gradle.
+
+	java { toolchain { languageVersion = JavaLanguageVersion.of(21) } }
+	kotlin { jvmToolchain(21) }
+*/
+var javaToolchainPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`languageVersion(?:\.set\(|\s*=\s*)\s*JavaLanguageVersion\.of\(\s*["']?(\d+)["']?\s*\)`),
+	regexp.MustCompile(`jvmToolchain\(\s*(\d+)\s*\)`),
+}
+
+type SubProject struct {
+	Name                 string
+	BuildScriptFileEntry direntry.DirEntry
+}
+
+func (proj SubProject) DetectAnyDependenciesInBuildScript(dependencies []string) (bool, error) {
+	return detectAnyDependencies(proj.BuildScriptFileEntry.AbsPath, dependencies)
+}
+
+type Project struct {
+	RootDirEntry            direntry.DirEntry
+	GradlewFileEntry        direntry.DirEntry
+	ConfigDirEntry          *direntry.DirEntry
+	VersionCatalogFileEntry *direntry.DirEntry
+	SettingsGradleFileEntry *direntry.DirEntry
+	// WrapperPropertiesFileEntry is the gradle/wrapper/gradle-wrapper.properties file
+	WrapperPropertiesFileEntry *direntry.DirEntry
+	WrapperJarFileEntry        *direntry.DirEntry
+
+	IncludedProjects          []SubProject
+	AllBuildScriptFileEntries []direntry.DirEntry
+
+	// WrapperIssues are the problems of the Gradle wrapper files, which fail the builds
+	WrapperIssues []string
+}
+
+func ScanProject(projectRootDirEntry direntry.DirEntry) (*Project, error) {
+	projectRoot, err := detectGradleProjectRoot(projectRootDirEntry)
+	if err != nil {
+		return nil, err
+	}
+	if projectRoot == nil {
+		return nil, nil
+	}
+	projects, err := detectIncludedProjects(*projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	project := Project{
+		RootDirEntry:            projectRoot.rootDirEntry,
+		GradlewFileEntry:        projectRoot.gradlewFileEntry,
+		ConfigDirEntry:          projectRoot.configDirEntry,
+		VersionCatalogFileEntry: projectRoot.versionCatalogFileEntry,
+		SettingsGradleFileEntry: projectRoot.settingsGradleFileEntry,
+
+		WrapperPropertiesFileEntry: projectRoot.wrapperPropertiesFileEntry,
+		WrapperJarFileEntry:        projectRoot.wrapperJarFileEntry,
+
+		IncludedProjects:          projects.includedProjects,
+		AllBuildScriptFileEntries: projects.allBuildScriptEntries,
+	}
+
+	project.WrapperIssues = project.checkWrapper()
+
+	return &project, nil
+}
+
+func (proj Project) DetectAnyDependencies(dependencies []string) (bool, error) {
+	detected, err := proj.detectAnyDependenciesInVersionCatalogFile(dependencies)
+	if err != nil {
+		return false, err
+	}
+	if detected {
+		return true, nil
+	}
+
+	detected, err = proj.detectAnyDependenciesInIncludedProjectBuildScripts(dependencies)
+	if err != nil {
+		return false, err
+	}
+	if detected {
+		return true, nil
+	}
+
+	return proj.detectAnyDependenciesInBuildScripts(dependencies)
+}
+
+func (proj Project) FindSubProjectsWithAnyDependencies(dependencies []string) ([]SubProject, error) {
+	var subProjects []SubProject
+	for _, includedProject := range proj.IncludedProjects {
+		detected, err := includedProject.DetectAnyDependenciesInBuildScript(dependencies)
+		if err != nil {
+			return nil, err
+		}
+		if detected {
+			subProjects = append(subProjects, includedProject)
+		}
+	}
+	return subProjects, nil
+}
+
+// FindFilesWithAnyDependencies returns the version catalog and build script files, which reference any of the dependencies.
+func (proj Project) FindFilesWithAnyDependencies(dependencies []string) ([]direntry.DirEntry, error) {
+	var candidates []direntry.DirEntry
+	if proj.VersionCatalogFileEntry != nil {
+		candidates = append(candidates, *proj.VersionCatalogFileEntry)
+	}
+	candidates = append(candidates, proj.AllBuildScriptFileEntries...)
+
+	var files []direntry.DirEntry
+	for _, candidate := range candidates {
+		detected, err := detectAnyDependencies(candidate.AbsPath, dependencies)
+		if err != nil {
+			return nil, err
+		}
+		if detected {
+			files = append(files, candidate)
+		}
+	}
+	return files, nil
+}
+
+func (proj Project) GetPluginAliasFromVersionCatalog(pluginID string) (string, error) {
+	if proj.VersionCatalogFileEntry == nil {
+		return "", nil
+	}
+
+	file, err := os.Open(proj.VersionCatalogFileEntry.AbsPath)
+	if err != nil {
+		return "", err
+	}
+	metrics.FileRead()
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.TWarnf("Unable to close file %s: %s", proj.VersionCatalogFileEntry.AbsPath, err)
+		}
+	}()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+
+	/*
+		[plugins]
+		androidApplication = { id = "com.android.application", version.ref = "agp" } # referenced in build.gradle: alias(libs.plugins.androidApplication)
+		androidLibrary = { id = "com.android.library", version.ref = "agp" }
+	*/
+	var versionCatalog struct {
+		Plugins map[string]struct {
+			ID         string `toml:"id"`
+			VersionRef string `toml:"version.ref"`
+		} `toml:"plugins"`
+	}
+	if _, err := toml.Decode(string(content), &versionCatalog); err != nil {
+		return "", fmt.Errorf("failed to decode version catalog file: %w", err)
+	}
+
+	var pluginAlias string
+	for alias, plugin := range versionCatalog.Plugins {
+		if plugin.ID == pluginID {
+			pluginAlias = alias
+			break
+		}
+	}
+
+	return pluginAlias, nil
+}
+
+// DetectPluginVersion returns the version of the plugin, and the file declaring it. The version is looked up in the version catalog,
+// in the plugins blocks of the settings and build scripts, and in the buildscript classpath dependencies by the plugin artifact
+// (like com.android.tools.build:gradle). Dynamic versions are not resolved.
+func (proj Project) DetectPluginVersion(pluginID, artifact string) (string, *direntry.DirEntry, error) {
+	if proj.VersionCatalogFileEntry != nil {
+		version, err := proj.pluginVersionFromVersionCatalog(pluginID, artifact)
+		if err != nil {
+			return "", nil, err
+		}
+		if version != "" {
+			return version, proj.VersionCatalogFileEntry, nil
+		}
+	}
+
+	/*
+		plugins { id("com.android.application") version "8.2.0" apply false }
+		buildscript { dependencies { classpath("com.android.tools.build:gradle:8.2.0") } }
+	*/
+	patterns := []*regexp.Regexp{
+		regexp.MustCompile(`\bid\s*\(?\s*["']` + regexp.QuoteMeta(pluginID) + `["']\s*\)?\s*version\s*\(?\s*["']([\w.+-]+)["']`),
+		regexp.MustCompile(`["']` + regexp.QuoteMeta(artifact) + `:([\w.+-]+)["']`),
+	}
+
+	var candidates []direntry.DirEntry
+	if proj.SettingsGradleFileEntry != nil {
+		candidates = append(candidates, *proj.SettingsGradleFileEntry)
+	}
+	candidates = append(candidates, proj.AllBuildScriptFileEntries...)
+	for _, candidate := range candidates {
+		content, err := metrics.ReadStringFromFile(candidate.AbsPath)
+		if err != nil {
+			return "", nil, err
+		}
+		for _, pattern := range patterns {
+			if match := pattern.FindStringSubmatch(content); match != nil {
+				return match[1], &candidate, nil
+			}
+		}
+	}
+	return "", nil, nil
+}
+
+func (proj Project) pluginVersionFromVersionCatalog(pluginID, artifact string) (string, error) {
+	content, err := metrics.ReadStringFromFile(proj.VersionCatalogFileEntry.AbsPath)
+	if err != nil {
+		return "", err
+	}
+
+	/*
+		[versions]
+		agp = "8.2.0"
+		[plugins]
+		android-application = { id = "com.android.application", version.ref = "agp" }
+		[libraries]
+		android-gradle-plugin = { module = "com.android.tools.build:gradle", version.ref = "agp" }
+	*/
+	var versionCatalog struct {
+		Versions  map[string]interface{} `toml:"versions"`
+		Plugins   map[string]interface{} `toml:"plugins"`
+		Libraries map[string]interface{} `toml:"libraries"`
+	}
+	if _, err := toml.Decode(content, &versionCatalog); err != nil {
+		return "", fmt.Errorf("failed to decode version catalog file: %w", err)
+	}
+
+	for _, plugin := range versionCatalog.Plugins {
+		switch plugin := plugin.(type) {
+		case string:
+			// android-application = "com.android.application:8.2.0"
+			if version, found := strings.CutPrefix(plugin, pluginID+":"); found {
+				return version, nil
+			}
+		case map[string]interface{}:
+			if plugin["id"] == pluginID {
+				return catalogVersion(versionCatalog.Versions, plugin["version"]), nil
+			}
+		}
+	}
+
+	for _, library := range versionCatalog.Libraries {
+		switch library := library.(type) {
+		case string:
+			// android-gradle-plugin = "com.android.tools.build:gradle:8.2.0"
+			if version, found := strings.CutPrefix(library, artifact+":"); found {
+				return version, nil
+			}
+		case map[string]interface{}:
+			if library["module"] == artifact || fmt.Sprintf("%v:%v", library["group"], library["name"]) == artifact {
+				return catalogVersion(versionCatalog.Versions, library["version"]), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// catalogVersion resolves a version of the version catalog: a version string, a reference to the versions table (version.ref = "agp")
+// or a rich version (version = { strictly = "8.2.0" }).
+func catalogVersion(versions map[string]interface{}, version interface{}) string {
+	switch version := version.(type) {
+	case string:
+		return version
+	case map[string]interface{}:
+		if ref, ok := version["ref"].(string); ok {
+			if referenced, ok := versions[ref].(string); ok {
+				return referenced
+			}
+			version, _ = versions[ref].(map[string]interface{})
+		}
+		for _, key := range []string{"strictly", "require", "prefer"} {
+			if v, ok := version[key].(string); ok {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// DetectJavaToolchainVersion returns the Java language version of the toolchain, and the build script configuring it.
+// Build scripts closer to the project root take precedence.
+func (proj Project) DetectJavaToolchainVersion() (string, *direntry.DirEntry, error) {
+	for _, buildScriptFileEntry := range proj.AllBuildScriptFileEntries {
+		content, err := metrics.ReadStringFromFile(buildScriptFileEntry.AbsPath)
+		if err != nil {
+			return "", nil, err
+		}
+		for _, pattern := range javaToolchainPatterns {
+			if match := pattern.FindStringSubmatch(content); match != nil {
+				return match[1], &buildScriptFileEntry, nil
+			}
+		}
+	}
+	return "", nil, nil
+}
+
+func (proj Project) detectAnyDependenciesInVersionCatalogFile(dependencies []string) (bool, error) {
+	if proj.VersionCatalogFileEntry == nil {
+		return false, nil
+	}
+	return detectAnyDependencies(proj.VersionCatalogFileEntry.AbsPath, dependencies)
+}
+
+func (proj Project) detectAnyDependenciesInIncludedProjectBuildScripts(dependencies []string) (bool, error) {
+	for _, includedProject := range proj.IncludedProjects {
+		detected, err := detectAnyDependencies(includedProject.BuildScriptFileEntry.AbsPath, dependencies)
+		if err != nil {
+			return false, err
+		}
+		if detected {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (proj Project) detectAnyDependenciesInBuildScripts(dependencies []string) (bool, error) {
+	for _, BuildScriptFileEntry := range proj.AllBuildScriptFileEntries {
+		detected, err := detectAnyDependencies(BuildScriptFileEntry.AbsPath, dependencies)
+		if err != nil {
+			return false, err
+		}
+		if detected {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func detectAnyDependencies(pth string, dependencies []string) (bool, error) {
+	content, err := metrics.ReadStringFromFile(pth)
+	if err != nil {
+		return false, err
+	}
+
+	for _, dependency := range dependencies {
+		if strings.Contains(content, dependency) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+type gradleProjectRootEntry struct {
+	rootDirEntry            direntry.DirEntry
+	gradlewFileEntry        direntry.DirEntry
+	configDirEntry          *direntry.DirEntry
+	versionCatalogFileEntry *direntry.DirEntry
+	settingsGradleFileEntry *direntry.DirEntry
+
+	wrapperPropertiesFileEntry *direntry.DirEntry
+	wrapperJarFileEntry        *direntry.DirEntry
+}
+
+func detectGradleProjectRoot(searchDir direntry.DirEntry) (*gradleProjectRootEntry, error) {
+	gradlewFileEntry := searchDir.FindImmediateChildByName("gradlew", false)
+	if gradlewFileEntry == nil {
+		return nil, nil
+	}
+
+	projectRootDirEntry := gradlewFileEntry.Parent()
+	if projectRootDirEntry == nil {
+		return nil, fmt.Errorf("unable to detect gradle project root")
+	}
+
+	projectRoot := gradleProjectRootEntry{
+		rootDirEntry:     *projectRootDirEntry,
+		gradlewFileEntry: *gradlewFileEntry,
+	}
+
+	configDirEntry := projectRootDirEntry.FindImmediateChildByName("gradle", true)
+	if configDirEntry != nil {
+		projectRoot.configDirEntry = configDirEntry
+
+		versionCatalogFileEntry := configDirEntry.FindImmediateChildByName("libs.versions.toml", false)
+		if versionCatalogFileEntry != nil {
+			projectRoot.versionCatalogFileEntry = versionCatalogFileEntry
+		}
+
+		wrapperDirEntry := configDirEntry.FindImmediateChildByName("wrapper", true)
+		if wrapperDirEntry != nil {
+			projectRoot.wrapperPropertiesFileEntry = wrapperDirEntry.FindImmediateChildByName("gradle-wrapper.properties", false)
+			projectRoot.wrapperJarFileEntry = wrapperDirEntry.FindImmediateChildByName("gradle-wrapper.jar", false)
+		}
+	}
+
+	settingsFileEntry := projectRootDirEntry.FindImmediateChildByName("settings.gradle", false)
+	if settingsFileEntry == nil {
+		settingsFileEntry = projectRootDirEntry.FindImmediateChildByName("settings.gradle.kts", false)
+	}
+	if settingsFileEntry != nil {
+		projectRoot.settingsGradleFileEntry = settingsFileEntry
+	}
+
+	return &projectRoot, nil
+}
+
+type includedProjects struct {
+	allBuildScriptEntries []direntry.DirEntry
+	includedProjects      []SubProject
+}
+
+func detectIncludedProjects(projectRootEntry gradleProjectRootEntry) (*includedProjects, error) {
+	projects := includedProjects{}
+	projects.allBuildScriptEntries = projectRootEntry.rootDirEntry.FindAllEntriesByName("build.gradle", false)
+	projects.allBuildScriptEntries = append(projects.allBuildScriptEntries, projectRootEntry.rootDirEntry.FindAllEntriesByName("build.gradle.kts", false)...)
+	sort.Slice(projects.allBuildScriptEntries, func(i, j int) bool {
+		if len(projects.allBuildScriptEntries[i].AbsPath) == len(projects.allBuildScriptEntries[j].AbsPath) {
+			return projects.allBuildScriptEntries[i].AbsPath < projects.allBuildScriptEntries[j].AbsPath
+		}
+		return len(projects.allBuildScriptEntries[i].AbsPath) < len(projects.allBuildScriptEntries[j].AbsPath)
+	})
+
+	if projectRootEntry.settingsGradleFileEntry != nil {
+		var subprojects []SubProject
+
+		includes, err := detectProjectIncludes(*projectRootEntry.settingsGradleFileEntry)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, include := range includes {
+			var components []string
+
+			trimmedInclude := strings.TrimPrefix(include, ":")
+			includeComponents := strings.Split(trimmedInclude, ":")
+			for _, includeComponent := range includeComponents {
+				if includeComponent == "" {
+					continue
+				}
+				includeComponent = strings.TrimSpace(includeComponent)
+				components = append(components, includeComponent)
+			}
+
+			projectBuildScript := projectRootEntry.rootDirEntry.FindEntryByPathComponents(false, append(components, "build.gradle")...)
+			if projectBuildScript != nil {
+				subprojects = append(subprojects, SubProject{
+					Name:                 include,
+					BuildScriptFileEntry: *projectBuildScript,
+				})
+				continue
+			}
+
+			projectBuildScript = projectRootEntry.rootDirEntry.FindEntryByPathComponents(false, append(components, "build.gradle.kts")...)
+			if projectBuildScript != nil {
+				subprojects = append(subprojects, SubProject{
+					Name:                 include,
+					BuildScriptFileEntry: *projectBuildScript,
+				})
+			} else {
+				log.TWarnf("Unable to find build script for %s", include)
+			}
+		}
+
+		projects.includedProjects = subprojects
+	}
+
+	return &projects, nil
+}
+
+func detectProjectIncludes(settingGradleFile direntry.DirEntry) ([]string, error) {
+	file, err := os.Open(settingGradleFile.AbsPath)
+	if err != nil {
+		return nil, err
+	}
+	metrics.FileRead()
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.TWarnf("Unable to close file %s: %s", settingGradleFile.AbsPath, err)
+		}
+	}()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return detectProjectIncludesInContent(string(content)), nil
+}
+
+func detectProjectIncludesInContent(settingGradleFileContent string) []string {
+	var projects []string
+	lines := strings.Split(settingGradleFileContent, "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "include(") && !strings.HasPrefix(line, "include ") {
+			continue
+		}
+
+		includedModules := strings.TrimPrefix(line, "include")
+		includedModules = strings.Trim(includedModules, "()")
+		includedModulesSplit := strings.Split(includedModules, ",")
+
+		for _, includedModule := range includedModulesSplit {
+			includedModule = strings.TrimSpace(includedModule)
+			includedModule = strings.Trim(includedModule, `"'`)
+			if !strings.HasPrefix(includedModule, ":") {
+				includedModule = ":" + includedModule
+			}
+			projects = append(projects, includedModule)
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		if len(projects[i]) == len(projects[j]) {
+			return projects[i] < projects[j]
+		}
+		return len(projects[i]) < len(projects[j])
+	})
+
+	return projects
+}
@@ -0,0 +1,146 @@
+package gradle
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/metrics"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+/*
+Gradle wrapper properties:
+
+	distributionUrl=https\://services.gradle.org/distributions/gradle-8.4-bin.zip
+*/
+var distributionURLPattern = regexp.MustCompile(`(?m)^\s*distributionUrl\s*[=:]\s*\S*gradle-(\d[\w.+-]*?)-(bin|all)\.zip\s*$`)
+
+// wrapperChecksumsData lists the official SHA-256 checksums of the wrapper jars by Gradle version, embedded to verify the jars offline.
+//
+//go:embed wrapper_checksums.txt
+var wrapperChecksumsData string
+
+var wrapperChecksums = parseWrapperChecksums(wrapperChecksumsData)
+
+// WrapperDistribution is the Gradle distribution downloaded by the wrapper, like version 8.4 and type bin for gradle-8.4-bin.zip.
+type WrapperDistribution struct {
+	Version string
+	Type    string
+}
+
+// DetectWrapperDistribution returns the Gradle distribution of the wrapper properties, nil if the project has no wrapper properties
+// or the distribution URL is not an official distribution.
+func (proj Project) DetectWrapperDistribution() (*WrapperDistribution, error) {
+	if proj.WrapperPropertiesFileEntry == nil {
+		return nil, nil
+	}
+
+	content, err := metrics.ReadStringFromFile(proj.WrapperPropertiesFileEntry.AbsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	match := distributionURLPattern.FindStringSubmatch(content)
+	if match == nil {
+		return nil, nil
+	}
+	return &WrapperDistribution{Version: match[1], Type: match[2]}, nil
+}
+
+// checkWrapper returns the issues of the wrapper files: the wrapper script is not executable, the wrapper jar is missing
+// or it doesn't match any official wrapper jar. Like Gradle's wrapper validation, any official jar is accepted,
+// as the jar is not regenerated when only the distribution URL is updated.
+// The checksum is not verified if the distribution's Gradle version is missing from the embedded checksums,
+// as the jar can be newer than the embedded list.
+func (proj Project) checkWrapper() []string {
+	var issues []string
+
+	info, err := os.Stat(proj.GradlewFileEntry.AbsPath)
+	if err != nil {
+		log.TWarnf("Failed to check the Gradle wrapper script: %s", err)
+	} else if info.Mode()&0111 == 0 {
+		issues = append(issues, fmt.Sprintf("Gradle wrapper script (%s) is not executable", proj.GradlewFileEntry.RelPath))
+	}
+
+	if proj.WrapperJarFileEntry == nil {
+		jarPath := strings.TrimSuffix(proj.GradlewFileEntry.RelPath, "gradlew") + "gradle/wrapper/gradle-wrapper.jar"
+		issues = append(issues, fmt.Sprintf("Gradle wrapper jar (%s) not found", jarPath))
+		return issues
+	}
+
+	distribution, err := proj.DetectWrapperDistribution()
+	if err != nil {
+		log.TWarnf("Failed to detect the Gradle wrapper distribution: %s", err)
+		return issues
+	}
+	if distribution == nil {
+		return issues
+	}
+
+	if _, ok := wrapperChecksums[distribution.Version]; !ok {
+		log.TPrintf("No official wrapper jar checksum known for Gradle %s", distribution.Version)
+		return issues
+	}
+
+	checksum, err := fileChecksum(proj.WrapperJarFileEntry.AbsPath)
+	if err != nil {
+		log.TWarnf("Failed to calculate the checksum of the Gradle wrapper jar: %s", err)
+		return issues
+	}
+	if !isOfficialWrapperChecksum(checksum) {
+		issues = append(issues, fmt.Sprintf("Gradle wrapper jar (%s) doesn't match any official Gradle wrapper jar checksum", proj.WrapperJarFileEntry.RelPath))
+	}
+
+	return issues
+}
+
+// parseWrapperChecksums parses the lines of Gradle version and checksum pairs, like 8.4 <sha256>.
+func parseWrapperChecksums(data string) map[string]string {
+	checksums := map[string]string{}
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		checksums[fields[0]] = strings.ToLower(fields[1])
+	}
+	return checksums
+}
+
+func isOfficialWrapperChecksum(checksum string) bool {
+	for _, officialChecksum := range wrapperChecksums {
+		if checksum == officialChecksum {
+			return true
+		}
+	}
+	return false
+}
+
+func fileChecksum(pth string) (string, error) {
+	file, err := os.Open(pth)
+	if err != nil {
+		return "", err
+	}
+	metrics.FileRead()
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.TWarnf("Unable to close file %s: %s", pth, err)
+		}
+	}()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,179 @@
+package gradle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/bitrise-init/detectors/direntry"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	officialWrapperJar      = "official gradle-wrapper.jar content"
+	olderOfficialWrapperJar = "official gradle-wrapper.jar content of an older Gradle"
+	wrapperProperties       = `distributionBase=GRADLE_USER_HOME
+distributionPath=wrapper/dists
+distributionUrl=https\://services.gradle.org/distributions/gradle-8.4-bin.zip
+zipStoreBase=GRADLE_USER_HOME
+zipStorePath=wrapper/dists
+`
+)
+
+func TestProject_checkWrapper(t *testing.T) {
+	officialChecksum := sha256.Sum256([]byte(officialWrapperJar))
+	olderOfficialChecksum := sha256.Sum256([]byte(olderOfficialWrapperJar))
+	originalChecksums := wrapperChecksums
+	wrapperChecksums = map[string]string{
+		"8.4": hex.EncodeToString(officialChecksum[:]),
+		"8.3": hex.EncodeToString(olderOfficialChecksum[:]),
+	}
+	t.Cleanup(func() { wrapperChecksums = originalChecksums })
+
+	tests := []struct {
+		name       string
+		files      map[string]string
+		executable bool
+		want       []string
+	}{
+		{
+			name: "known-good jar",
+			files: map[string]string{
+				"gradle/wrapper/gradle-wrapper.jar":        officialWrapperJar,
+				"gradle/wrapper/gradle-wrapper.properties": wrapperProperties,
+			},
+			executable: true,
+		},
+		{
+			name: "official jar of an older Gradle version",
+			files: map[string]string{
+				"gradle/wrapper/gradle-wrapper.jar":        olderOfficialWrapperJar,
+				"gradle/wrapper/gradle-wrapper.properties": wrapperProperties,
+			},
+			executable: true,
+		},
+		{
+			name: "tampered jar",
+			files: map[string]string{
+				"gradle/wrapper/gradle-wrapper.jar":        officialWrapperJar + " with a payload",
+				"gradle/wrapper/gradle-wrapper.properties": wrapperProperties,
+			},
+			executable: true,
+			want:       []string{"Gradle wrapper jar (./gradle/wrapper/gradle-wrapper.jar) doesn't match any official Gradle wrapper jar checksum"},
+		},
+		{
+			name: "unknown Gradle version",
+			files: map[string]string{
+				"gradle/wrapper/gradle-wrapper.jar":        "any content",
+				"gradle/wrapper/gradle-wrapper.properties": "distributionUrl=https\\://services.gradle.org/distributions/gradle-8.5-all.zip\n",
+			},
+			executable: true,
+		},
+		{
+			name: "missing jar",
+			files: map[string]string{
+				"gradle/wrapper/gradle-wrapper.properties": wrapperProperties,
+			},
+			executable: true,
+			want:       []string{"Gradle wrapper jar (./gradle/wrapper/gradle-wrapper.jar) not found"},
+		},
+		{
+			name: "wrapper script not executable",
+			files: map[string]string{
+				"gradle/wrapper/gradle-wrapper.jar":        officialWrapperJar,
+				"gradle/wrapper/gradle-wrapper.properties": wrapperProperties,
+			},
+			want: []string{"Gradle wrapper script (./gradlew) is not executable"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+			mode := os.FileMode(0644)
+			if tt.executable {
+				mode = 0755
+			}
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "gradlew"), []byte("#!/bin/sh"), mode))
+
+			rootEntry, err := direntry.WalkDir(dir, 4)
+			require.NoError(t, err)
+			project, err := ScanProject(*rootEntry)
+			require.NoError(t, err)
+			require.NotNil(t, project)
+
+			require.Equal(t, tt.want, project.WrapperIssues)
+		})
+	}
+}
+
+func TestProject_DetectWrapperDistribution(t *testing.T) {
+	tests := []struct {
+		name              string
+		wrapperProperties string
+		want              *WrapperDistribution
+	}{
+		{
+			name:              "bin distribution",
+			wrapperProperties: wrapperProperties,
+			want:              &WrapperDistribution{Version: "8.4", Type: "bin"},
+		},
+		{
+			name:              "all distribution of a release candidate",
+			wrapperProperties: "distributionUrl=https\\://services.gradle.org/distributions/gradle-8.5-rc-1-all.zip\n",
+			want:              &WrapperDistribution{Version: "8.5-rc-1", Type: "all"},
+		},
+		{
+			name:              "custom distribution",
+			wrapperProperties: "distributionUrl=https\\://example.com/distributions/custom.zip\n",
+			want:              nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, map[string]string{"gradle/wrapper/gradle-wrapper.properties": tt.wrapperProperties})
+			rootEntry, err := direntry.WalkDir(dir, 4)
+			require.NoError(t, err)
+			propertiesEntry := rootEntry.FindEntryByPathComponents(false, "gradle", "wrapper", "gradle-wrapper.properties")
+			require.NotNil(t, propertiesEntry)
+
+			got, err := Project{WrapperPropertiesFileEntry: propertiesEntry}.DetectWrapperDistribution()
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseWrapperChecksums(t *testing.T) {
+	data := `# comment
+8.4 ABCDEF0123456789
+
+line with more than two fields
+`
+	want := map[string]string{"8.4": "abcdef0123456789"}
+	require.Equal(t, want, parseWrapperChecksums(data))
+}
+
+func Test_embeddedWrapperChecksums(t *testing.T) {
+	checksums := parseWrapperChecksums(wrapperChecksumsData)
+	if len(checksums) == 0 {
+		t.Skip("wrapper_checksums.txt has no entries, populate it with the command in its header")
+	}
+
+	require.Contains(t, checksums, "8.4")
+	for version, checksum := range checksums {
+		require.Regexp(t, `^\d+\.\d+`, version)
+		require.Regexp(t, `^[0-9a-f]{64}$`, checksum, version)
+	}
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		pth := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+		require.NoError(t, os.WriteFile(pth, []byte(content), 0644))
+	}
+}
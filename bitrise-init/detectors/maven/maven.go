@@ -0,0 +1,256 @@
+package maven
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/detectors/direntry"
+	"github.com/bitrise-io/bitrise-init/metrics"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+/*
+Relevant Maven project files:
+
+Project Object Model (pom.xml):
+	The POM in the root directory describes the project, a multi-module project lists its modules in the <modules> section.
+	Modules are directories with their own POM, and can be aggregators of further modules.
+
+Maven wrapper script (mvnw):
+	The presence of the mvnw file next to the root POM shows that the project is built with the wrapper's Maven version.
+*/
+
+// javaVersionProperties are the POM properties configuring the Java version of the compiler, in the order of precedence.
+// Spring Boot's parent POM configures the compiler with java.version.
+var javaVersionProperties = []string{"maven.compiler.release", "java.version", "maven.compiler.target", "maven.compiler.source"}
+
+type Module struct {
+	ProjectObjectModelFileEntry direntry.DirEntry
+}
+
+type Project struct {
+	RootDirEntry                direntry.DirEntry
+	ProjectObjectModelFileEntry direntry.DirEntry
+	MavenWrapperFileEntry       direntry.DirEntry
+
+	Modules []Module
+}
+
+func ScanProject(projectRootDirEntry direntry.DirEntry) (*Project, error) {
+	project, err := detectMavenProjectRoot(projectRootDirEntry)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, nil
+	}
+
+	pom, err := readProjectObjectModel(project.ProjectObjectModelFileEntry.AbsPath)
+	if err != nil {
+		return nil, err
+	}
+	project.Modules = detectModules(project.RootDirEntry, pom)
+
+	return project, nil
+}
+
+// AllProjectObjectModelFileEntries returns the root POM and the POMs of the modules.
+func (proj Project) AllProjectObjectModelFileEntries() []direntry.DirEntry {
+	entries := []direntry.DirEntry{proj.ProjectObjectModelFileEntry}
+	for _, module := range proj.Modules {
+		entries = append(entries, module.ProjectObjectModelFileEntry)
+	}
+	return entries
+}
+
+func (proj Project) DetectAnyDependencies(dependencies []string) (bool, error) {
+	files, err := proj.FindFilesWithAnyDependencies(dependencies)
+	if err != nil {
+		return false, err
+	}
+	return len(files) > 0, nil
+}
+
+// FindFilesWithAnyDependencies returns the POM files, which reference any of the dependencies.
+func (proj Project) FindFilesWithAnyDependencies(dependencies []string) ([]direntry.DirEntry, error) {
+	var files []direntry.DirEntry
+	for _, entry := range proj.AllProjectObjectModelFileEntries() {
+		content, err := metrics.ReadStringFromFile(entry.AbsPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, dependency := range dependencies {
+			if strings.Contains(content, dependency) {
+				files = append(files, entry)
+				break
+			}
+		}
+	}
+	return files, nil
+}
+
+// DetectJavaVersion returns the Java major version the project is compiled for (like 17 or 8), and the POM configuring it.
+// The root POM takes precedence over the module POMs.
+func (proj Project) DetectJavaVersion() (string, *direntry.DirEntry, error) {
+	for _, entry := range proj.AllProjectObjectModelFileEntries() {
+		pom, err := readProjectObjectModel(entry.AbsPath)
+		if err != nil {
+			return "", nil, err
+		}
+		if version := pom.javaVersion(); version != "" {
+			return version, &entry, nil
+		}
+	}
+	return "", nil, nil
+}
+
+func detectMavenProjectRoot(searchDir direntry.DirEntry) (*Project, error) {
+	projectObjectModelEntry := searchDir.FindImmediateChildByName("pom.xml", false)
+	if projectObjectModelEntry == nil {
+		return nil, nil
+	}
+
+	projectRootDirEntry := projectObjectModelEntry.Parent()
+	if projectRootDirEntry == nil {
+		return nil, fmt.Errorf("unable to detect project root")
+	}
+
+	mavenWrapperEntry := projectRootDirEntry.FindImmediateChildByName("mvnw", false)
+	if mavenWrapperEntry == nil {
+		return nil, nil
+	}
+
+	return &Project{
+		RootDirEntry:                *projectRootDirEntry,
+		ProjectObjectModelFileEntry: *projectObjectModelEntry,
+		MavenWrapperFileEntry:       *mavenWrapperEntry,
+	}, nil
+}
+
+// detectModules returns the modules of the POM and their nested modules, depth-first in declaration order.
+// Modules with a missing or unparsable POM are skipped with a warning, together with their nested modules.
+func detectModules(dirEntry direntry.DirEntry, pom projectObjectModel) []Module {
+	var modules []Module
+	for _, modulePath := range pom.Modules {
+		components := pathComponents(modulePath)
+		if len(components) == 0 {
+			continue
+		}
+
+		// A module can point to its POM file, if it is not named pom.xml
+		var moduleEntry *direntry.DirEntry
+		if strings.HasSuffix(modulePath, ".xml") {
+			moduleEntry = dirEntry.FindEntryByPathComponents(false, components...)
+		} else {
+			moduleEntry = dirEntry.FindEntryByPathComponents(false, append(components, "pom.xml")...)
+		}
+		if moduleEntry == nil {
+			log.TWarnf("Unable to find POM file of module %s", modulePath)
+			continue
+		}
+
+		moduleDirEntry := moduleEntry.Parent()
+		if moduleDirEntry == nil {
+			log.TWarnf("Unable to detect module dir of %s", modulePath)
+			continue
+		}
+
+		modulePOM, err := readProjectObjectModel(moduleEntry.AbsPath)
+		if err != nil {
+			log.TWarnf("Skipping module %s: %s", modulePath, err)
+			continue
+		}
+
+		modules = append(modules, Module{ProjectObjectModelFileEntry: *moduleEntry})
+		modules = append(modules, detectModules(*moduleDirEntry, modulePOM)...)
+	}
+
+	return modules
+}
+
+func pathComponents(pth string) []string {
+	var components []string
+	for _, component := range strings.Split(pth, "/") {
+		if component == "" || component == "." {
+			continue
+		}
+		components = append(components, component)
+	}
+	return components
+}
+
+type property struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type plugin struct {
+	ArtifactID    string `xml:"artifactId"`
+	Configuration struct {
+		Release string `xml:"release"`
+		Target  string `xml:"target"`
+	} `xml:"configuration"`
+}
+
+type projectObjectModel struct {
+	Modules    []string `xml:"modules>module"`
+	Properties struct {
+		Entries []property `xml:",any"`
+	} `xml:"properties"`
+	Plugins []plugin `xml:"build>plugins>plugin"`
+}
+
+func (pom projectObjectModel) property(name string) string {
+	for _, property := range pom.Properties.Entries {
+		if property.XMLName.Local == name {
+			return strings.TrimSpace(property.Value)
+		}
+	}
+	return ""
+}
+
+// javaVersion returns the Java version of the compiler plugin configuration or the compiler properties, resolving the property references.
+func (pom projectObjectModel) javaVersion() string {
+	var candidates []string
+	for _, plugin := range pom.Plugins {
+		if plugin.ArtifactID == "maven-compiler-plugin" {
+			candidates = append(candidates, strings.TrimSpace(plugin.Configuration.Release), strings.TrimSpace(plugin.Configuration.Target))
+		}
+	}
+	for _, name := range javaVersionProperties {
+		candidates = append(candidates, pom.property(name))
+	}
+
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, "${") && strings.HasSuffix(candidate, "}") {
+			candidate = pom.property(strings.TrimSuffix(strings.TrimPrefix(candidate, "${"), "}"))
+		}
+		if version := majorJavaVersion(candidate); version != "" {
+			return version
+		}
+	}
+	return ""
+}
+
+// majorJavaVersion returns the major version of a Java version, like 8 for 1.8 and 17 for 17.
+func majorJavaVersion(version string) string {
+	version = strings.TrimPrefix(version, "1.")
+	if version == "" || strings.Trim(version, "0123456789") != "" {
+		return ""
+	}
+	return version
+}
+
+func readProjectObjectModel(pth string) (projectObjectModel, error) {
+	content, err := metrics.ReadStringFromFile(pth)
+	if err != nil {
+		return projectObjectModel{}, err
+	}
+
+	var pom projectObjectModel
+	if err := xml.Unmarshal([]byte(content), &pom); err != nil {
+		return projectObjectModel{}, fmt.Errorf("failed to parse %s: %w", pth, err)
+	}
+	return pom, nil
+}
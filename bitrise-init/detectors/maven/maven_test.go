@@ -0,0 +1,138 @@
+package maven
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/bitrise-init/detectors/direntry"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanProject(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		wantProject bool
+		wantModules []string
+		wantVersion string
+	}{
+		{
+			name:  "no wrapper",
+			files: map[string]string{"pom.xml": `<project></project>`},
+		},
+		{
+			name: "single module project",
+			files: map[string]string{
+				"pom.xml": `<project><properties><java.version>17</java.version></properties></project>`,
+				"mvnw":    "",
+			},
+			wantProject: true,
+			wantVersion: "17",
+		},
+		{
+			name: "nested modules",
+			files: map[string]string{
+				"pom.xml":           `<project><modules><module>core</module><module>web/</module></modules></project>`,
+				"mvnw":              "",
+				"core/pom.xml":      `<project><modules><module>api</module></modules></project>`,
+				"core/api/pom.xml":  `<project><properties><maven.compiler.release>21</maven.compiler.release></properties></project>`,
+				"web/pom.xml":       `<project></project>`,
+				"unrelated/pom.xml": `<project></project>`,
+			},
+			wantProject: true,
+			wantModules: []string{"core/pom.xml", "core/api/pom.xml", "web/pom.xml"},
+			wantVersion: "21",
+		},
+		{
+			name: "module pointing to its POM file",
+			files: map[string]string{
+				"pom.xml":         `<project><modules><module>app/app-pom.xml</module></modules></project>`,
+				"mvnw":            "",
+				"app/app-pom.xml": `<project><properties><maven.compiler.source>1.8</maven.compiler.source></properties></project>`,
+			},
+			wantProject: true,
+			wantModules: []string{"app/app-pom.xml"},
+			wantVersion: "8",
+		},
+		{
+			name: "missing and unparsable module POMs are skipped",
+			files: map[string]string{
+				"pom.xml":               `<project><modules><module>missing</module><module>broken</module><module>app</module></modules></project>`,
+				"mvnw":                  "",
+				"broken/pom.xml":        `<project><modules><module>nested</module>`,
+				"broken/nested/pom.xml": `<project></project>`,
+				"app/pom.xml":           `<project></project>`,
+			},
+			wantProject: true,
+			wantModules: []string{"app/pom.xml"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+			rootEntry, err := direntry.WalkDir(dir, 6)
+			require.NoError(t, err)
+
+			project, err := ScanProject(*rootEntry)
+			require.NoError(t, err)
+			if !tt.wantProject {
+				require.Nil(t, project)
+				return
+			}
+			require.NotNil(t, project)
+
+			var modules []string
+			for _, module := range project.Modules {
+				relPath, err := filepath.Rel(dir, module.ProjectObjectModelFileEntry.AbsPath)
+				require.NoError(t, err)
+				modules = append(modules, filepath.ToSlash(relPath))
+			}
+			require.Equal(t, tt.wantModules, modules)
+
+			version, _, err := project.DetectJavaVersion()
+			require.NoError(t, err)
+			require.Equal(t, tt.wantVersion, version)
+		})
+	}
+}
+
+func TestScanProject_UnparsableRootPOM(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"pom.xml": `<project><modules>`,
+		"mvnw":    "",
+	})
+	rootEntry, err := direntry.WalkDir(dir, 2)
+	require.NoError(t, err)
+
+	_, err = ScanProject(*rootEntry)
+	require.Error(t, err)
+}
+
+func Test_majorJavaVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "17", want: "17"},
+		{version: "1.8", want: "8"},
+		{version: "", want: ""},
+		{version: "${java.version}", want: ""},
+		{version: "17.0.2", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			require.Equal(t, tt.want, majorJavaVersion(tt.version))
+		})
+	}
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		pth := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+		require.NoError(t, os.WriteFile(pth, []byte(content), 0644))
+	}
+}
@@ -0,0 +1,47 @@
+module github.com/bitrise-io/bitrise-init
+
+go 1.25.7
+
+require (
+	github.com/BurntSushi/toml v1.5.0
+	github.com/beevik/etree v1.2.0
+	github.com/bitrise-io/bitrise/v2 v2.39.1
+	github.com/bitrise-io/envman/v2 v2.5.6
+	github.com/bitrise-io/go-flutter v0.3.0
+	github.com/bitrise-io/go-steputils v1.0.6
+	github.com/bitrise-io/go-utils v1.0.15
+	github.com/bitrise-io/go-utils/v2 v2.0.0-alpha.33
+	github.com/bitrise-io/go-xcode v1.0.18
+	github.com/bitrise-io/goinp v0.0.0-20240103152431-054ed78518ef
+	github.com/bitrise-io/stepman v0.19.0
+	github.com/google/go-cmp v0.7.0
+	github.com/stretchr/testify v1.10.0
+	go.opentelemetry.io/otel v1.35.0
+	go.opentelemetry.io/otel/trace v1.35.0
+	gopkg.in/yaml.v2 v2.4.0
+)
+
+require (
+	github.com/Masterminds/semver/v3 v3.2.1 // indirect
+	github.com/bitrise-io/go-plist v0.0.0-20210301100253-4b1a112ccd10 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/emirpasic/gods v1.18.1 // indirect
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/hashicorp/go-version v1.8.0 // indirect
+	github.com/heimdalr/dag v1.4.0 // indirect
+	github.com/kr/pretty v0.3.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/rogpeppe/go-internal v1.13.1 // indirect
+	github.com/ryanuber/go-glob v1.0.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/otel/metric v1.35.0 // indirect
+	golang.org/x/crypto v0.46.0 // indirect
+	golang.org/x/exp v0.0.0-20240909161429-701f63a606c0 // indirect
+	golang.org/x/sys v0.39.0 // indirect
+	golang.org/x/term v0.38.0 // indirect
+	golang.org/x/text v0.32.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+	howett.net/plist v1.0.0 // indirect
+)
@@ -0,0 +1,78 @@
+// Package metrics collects the resources used by a scanner: the files it reads and the external commands it runs.
+// Scanners run one after the other, so a single collection is active at a time.
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+)
+
+// Command is an external command run by a scanner.
+type Command struct {
+	Command  string
+	Duration time.Duration
+}
+
+// Usage is the resource usage collected between Start and Stop.
+type Usage struct {
+	FilesRead int
+	Commands  []Command
+}
+
+var (
+	mu      sync.Mutex
+	current *Usage
+)
+
+// Start starts a new collection, the previous one is discarded.
+func Start() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	current = &Usage{}
+}
+
+// Stop stops the collection and returns the collected usage.
+func Stop() Usage {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if current == nil {
+		return Usage{}
+	}
+	usage := *current
+	current = nil
+	return usage
+}
+
+// FileRead records a file read.
+func FileRead() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if current != nil {
+		current.FilesRead++
+	}
+}
+
+// ReadStringFromFile reads the file like fileutil.ReadStringFromFile and records the read if it succeeded.
+func ReadStringFromFile(pth string) (string, error) {
+	content, err := fileutil.ReadStringFromFile(pth)
+	if err != nil {
+		return "", err
+	}
+	FileRead()
+	return content, nil
+}
+
+// CommandRun records an external command, which was started at startTime and has just finished.
+func CommandRun(command string, startTime time.Time) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if current != nil {
+		current.Commands = append(current.Commands, Command{Command: command, Duration: time.Since(startTime)})
+	}
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadStringFromFile(t *testing.T) {
+	dir := t.TempDir()
+	existingPth := filepath.Join(dir, "file.txt")
+	require.NoError(t, os.WriteFile(existingPth, []byte("content"), 0600))
+
+	tests := []struct {
+		name          string
+		pth           string
+		want          string
+		wantErr       bool
+		wantFilesRead int
+	}{
+		{
+			name:          "Existing file is counted",
+			pth:           existingPth,
+			want:          "content",
+			wantFilesRead: 1,
+		},
+		{
+			name:          "Failed read is not counted",
+			pth:           filepath.Join(dir, "missing.txt"),
+			wantErr:       true,
+			wantFilesRead: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Start()
+			got, err := ReadStringFromFile(tt.pth)
+			usage := Stop()
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantFilesRead, usage.FilesRead)
+		})
+	}
+}
+
+func TestStop_withoutStart(t *testing.T) {
+	FileRead()
+	CommandRun("echo", time.Now())
+	require.Equal(t, Usage{}, Stop())
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"github.com/bitrise-io/go-steputils/step"
+)
+
+type BitriseConfigMap map[string]string
+
+type Warnings []string
+
+type Errors []string
+
+// Icon is potential app icon.
+// The name is unique (sha256 hash of relative path converted to string plus the original extension appended).
+type Icon struct {
+	Filename string
+	Path     string
+}
+
+type Icons []Icon
+
+// Evidence is a file and the marker found in it, which made a scanner detect its platform or add an option.
+// The file path is relative to the scanned directory.
+type Evidence struct {
+	File   string `json:"file" yaml:"file"`
+	Marker string `json:"marker" yaml:"marker"`
+}
+
+type Evidences []Evidence
+
+// Add appends a file and the marker found in it.
+func (evidences *Evidences) Add(file, marker string) {
+	*evidences = append(*evidences, Evidence{File: file, Marker: marker})
+}
+
+// ProjectRoot is a project root directory claimed by a scanner.
+// OptionValue is the value of the scanner's top-level option belonging to the project root,
+// it is empty if the scanner's options can not be split by project roots.
+// NativeDirs are the native iOS, macOS and Android project dirs referenced by a cross-platform project,
+// native projects overlap with the cross-platform project only in these dirs. It is nil for the other projects.
+type ProjectRoot struct {
+	Dir         string
+	OptionValue string
+	NativeDirs  []string
+}
+
+// SuppressedCandidate is a project root dropped from a scanner's output, because it overlaps with a project of another scanner.
+type SuppressedCandidate struct {
+	ProjectRoot  string `json:"project_root,omitempty" yaml:"project_root,omitempty"`
+	SuppressedBy string `json:"suppressed_by" yaml:"suppressed_by"`
+	Reason       string `json:"reason" yaml:"reason"`
+}
+
+type SuppressedCandidates []SuppressedCandidate
+
+// DetectedTool is a tool found in the scanned directory, which is not supported by the scanners.
+// The recommendations explain how to set up the tool manually.
+type DetectedTool struct {
+	Name            string              `json:"name" yaml:"name"`
+	Recommendations step.Recommendation `json:"recommendations,omitempty" yaml:"recommendations,omitempty"`
+}
+
+type DetectedTools []DetectedTool
+
+// ScannerMetrics is the time a scanner spent in its phases and the resources it used.
+type ScannerMetrics struct {
+	DetectPlatformMillis int64            `json:"detect_platform_ms" yaml:"detect_platform_ms"`
+	OptionsMillis        int64            `json:"options_ms,omitempty" yaml:"options_ms,omitempty"`
+	ConfigsMillis        int64            `json:"configs_ms,omitempty" yaml:"configs_ms,omitempty"`
+	FilesRead            int              `json:"files_read" yaml:"files_read"`
+	Commands             []CommandMetrics `json:"commands,omitempty" yaml:"commands,omitempty"`
+}
+
+// CommandMetrics is an external command run by a scanner.
+type CommandMetrics struct {
+	Command        string `json:"command" yaml:"command"`
+	DurationMillis int64  `json:"duration_ms" yaml:"duration_ms"`
+}
+
+type ErrorWithRecommendations struct {
+	Error           string
+	Recommendations step.Recommendation
+}
+
+type ErrorsWithRecommendations []ErrorWithRecommendations
+
+type ScanResultModel struct {
+	ScannerToOptionRoot                  map[string]OptionNode                `json:"options,omitempty" yaml:"options,omitempty"`
+	ScannerToBitriseConfigMap            map[string]BitriseConfigMap          `json:"configs,omitempty" yaml:"configs,omitempty"`
+	ScannerToWarnings                    map[string]Warnings                  `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+	ScannerToErrors                      map[string]Errors                    `json:"errors,omitempty" yaml:"errors,omitempty"`
+	ScannerToErrorsWithRecommendations   map[string]ErrorsWithRecommendations `json:"errors_with_recommendations,omitempty" yaml:"errors_with_recommendations,omitempty"`
+	ScannerToWarningsWithRecommendations map[string]ErrorsWithRecommendations `json:"warnings_with_recommendations,omitempty" yaml:"warnings_with_recommendations,omitempty"`
+	ScannerToEvidences                   map[string]Evidences                 `json:"evidence,omitempty" yaml:"evidence,omitempty"`
+	ScannerToSuppressedCandidates        map[string]SuppressedCandidates      `json:"suppressed,omitempty" yaml:"suppressed,omitempty"`
+	ScannerToMetrics                     map[string]ScannerMetrics            `json:"metrics,omitempty" yaml:"metrics,omitempty"`
+	DetectedTools                        DetectedTools                        `json:"detected_tools,omitempty" yaml:"detected_tools,omitempty"`
+	Icons                                []Icon                               `json:"-" yaml:"-"`
+}
+
+type SSHKeyActivation int
+
+const (
+	SSHKeyActivationNone = iota
+	SSHKeyActivationMandatory
+	SSHKeyActivationConditional
+)
+
+func (result *ScanResultModel) AddErrorWithRecommendation(platform string, recommendation ErrorWithRecommendations) {
+	if result.ScannerToErrorsWithRecommendations == nil {
+		result.ScannerToErrorsWithRecommendations = map[string]ErrorsWithRecommendations{}
+	}
+	if result.ScannerToErrorsWithRecommendations[platform] == nil {
+		result.ScannerToErrorsWithRecommendations[platform] = ErrorsWithRecommendations{}
+	}
+	result.ScannerToErrorsWithRecommendations[platform] = append(result.ScannerToErrorsWithRecommendations[platform], recommendation)
+}
@@ -0,0 +1,457 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bitrise-io/bitrise-init/analytics"
+	"github.com/bitrise-io/bitrise-init/errormapper"
+	"github.com/bitrise-io/bitrise-init/metrics"
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/scanners"
+	"github.com/bitrise-io/go-steputils/step"
+	"github.com/bitrise-io/go-utils/colorstring"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-io/go-utils/sliceutil"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const otherProjectType = "other"
+
+var tracer = otel.Tracer("github.com/bitrise-io/bitrise-init/scanner")
+
+type status int
+
+const (
+	// in case DetectPlatform() returned error, or false
+	notDetected status = iota
+	// in case DetectPlatform() returned true, but Options() or Config() returned an error
+	detectedWithErrors
+	// in case DetectPlatform() returned true, Options() and Config() returned no error
+	detected
+)
+
+func (s status) String() string {
+	switch s {
+	case notDetected:
+		return "not_detected"
+	case detectedWithErrors:
+		return "detected_with_errors"
+	case detected:
+		return "detected"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	optionsFailedTag        = "options_failed"
+	configsFailedTag        = "configs_failed"
+	detectPlatformFailedTag = "detect_platform_failed"
+	noPlatformDetectedTag   = "no_platform_detected"
+)
+
+type scannerOutput struct {
+	status status
+
+	// can always be set
+	// warnings returned by DetectPlatform(), Options()
+	warnings                   models.Warnings
+	warningsWithRecommendation []models.ErrorWithRecommendations
+
+	// set if scanResultStatus is scanResultDetectedWithErrors
+	// errors returned by Config()
+	errors                   models.Errors
+	errorsWithRecommendation []models.ErrorWithRecommendations
+
+	// set if scanResultStatus is scanResultDetected
+	options          models.OptionNode
+	configs          models.BitriseConfigMap
+	icons            models.Icons
+	excludedScanners []string
+
+	// set if scanResultStatus is not notDetected
+	evidences models.Evidences
+
+	// can always be set
+	metrics models.ScannerMetrics
+}
+
+func (o *scannerOutput) AddErrors(tag string, errs ...string) {
+	for _, err := range errs {
+		recommendation := mapRecommendation(tag, err)
+		if recommendation != nil {
+			o.errorsWithRecommendation = append(o.errorsWithRecommendation, models.ErrorWithRecommendations{
+				Error:           err,
+				Recommendations: recommendation,
+			})
+			continue
+		}
+
+		o.errors = append(o.errors, err)
+	}
+}
+
+func (o *scannerOutput) AddWarnings(tag string, errs ...string) {
+	for _, err := range errs {
+		recommendation := mapRecommendation(tag, err)
+		if recommendation != nil {
+			o.warningsWithRecommendation = append(o.warningsWithRecommendation, models.ErrorWithRecommendations{
+				Error:           err,
+				Recommendations: recommendation,
+			})
+			continue
+		}
+
+		o.warnings = append(o.warnings, err)
+	}
+}
+
+// Config ...
+func Config(searchDir string, hasSSHKey bool) models.ScanResultModel {
+	return ConfigWithContext(context.Background(), searchDir, hasSSHKey, scanners.Options{})
+}
+
+// ConfigWithContext runs the scanners like Config, the scanner phases are traced as children of the span in ctx.
+// The project scanners are configured by the options.
+func ConfigWithContext(ctx context.Context, searchDir string, hasSSHKey bool, options scanners.Options) models.ScanResultModel {
+	return config(ctx, searchDir, hasSSHKey, scanners.ProjectScannersWithOptions(options))
+}
+
+// config runs the project scanners of projectScanners, followed by the automation tool scanners.
+func config(ctx context.Context, searchDir string, hasSSHKey bool, projectScanners []scanners.ScannerInterface) models.ScanResultModel {
+	result := models.ScanResultModel{}
+
+	//
+	// Setup
+	currentDir, err := os.Getwd()
+	if err != nil {
+		errorMsg := fmt.Sprintf("Failed to expand current directory path: %s", err)
+		result.AddErrorWithRecommendation("general", models.ErrorWithRecommendations{
+			Error: errorMsg,
+			Recommendations: step.Recommendation{
+				errormapper.DetailedErrorRecKey: newDetectPlatformFailedGenericDetail(errorMsg),
+			},
+		})
+		return result
+	}
+
+	if searchDir == "" {
+		searchDir = currentDir
+	} else {
+		absScerach, err := pathutil.AbsPath(searchDir)
+		if err != nil {
+			errorMsg := fmt.Sprintf("Failed to expand path (%s): %s", searchDir, err)
+			result.AddErrorWithRecommendation("general", models.ErrorWithRecommendations{
+				Error: errorMsg,
+				Recommendations: step.Recommendation{
+					errormapper.DetailedErrorRecKey: newDetectPlatformFailedGenericDetail(errorMsg),
+				},
+			})
+			return result
+		}
+		searchDir = absScerach
+	}
+
+	if searchDir != currentDir {
+		if err := os.Chdir(searchDir); err != nil {
+			errorMsg := fmt.Sprintf("Failed to change dir, to (%s): %s", searchDir, err)
+			result.AddErrorWithRecommendation("general", models.ErrorWithRecommendations{
+				Error: errorMsg,
+				Recommendations: step.Recommendation{
+					errormapper.DetailedErrorRecKey: newDetectPlatformFailedGenericDetail(errorMsg),
+				},
+			})
+			return result
+		}
+		defer func() {
+			if err := os.Chdir(currentDir); err != nil {
+				log.TWarnf("Failed to change dir, to (%s), error: %s", searchDir, err)
+			}
+		}()
+	}
+	// ---
+
+	//
+	// Scan
+	log.TInfof(colorstring.Blue("Running scanners:"))
+	fmt.Println()
+
+	// Collect scanner outputs, by scanner name
+	projectScannerToOutputs := runScanners(ctx, projectScanners, searchDir, hasSSHKey)
+
+	scannerToMetrics := map[string]models.ScannerMetrics{}
+	for scanner, scannerOutput := range projectScannerToOutputs {
+		scannerToMetrics[scanner] = scannerOutput.metrics
+	}
+
+	log.TInfof(colorstring.Blue("Resolving overlapping projects:"))
+	_, overlapSpan := tracer.Start(ctx, "resolve overlaps")
+	scannerToSuppressedCandidates := resolveOverlaps(projectScanners, projectScannerToOutputs, searchDir)
+	overlapSpan.End()
+	fmt.Println()
+
+	detectedProjectTypes := getDetectedScannerNames(projectScannerToOutputs)
+	log.Printf("Detected project types: %s", detectedProjectTypes)
+	fmt.Println()
+
+	// Project types are needed by tool scanners, to create decision tree on which project type
+	// to actually use in bitrise.yml
+	if len(detectedProjectTypes) == 0 {
+		detectedProjectTypes = []string{otherProjectType}
+	}
+
+	automationToolScanners := scanners.AutomationToolScanners()
+
+	for _, toolScanner := range automationToolScanners {
+		toolScanner.(scanners.AutomationToolScanner).SetDetectedProjectTypes(detectedProjectTypes)
+	}
+
+	scannerToOutput := runScanners(ctx, automationToolScanners, searchDir, hasSSHKey)
+	detectedAutomationToolScanners := getDetectedScannerNames(scannerToOutput)
+	log.Printf("Detected automation tools: %s", detectedAutomationToolScanners)
+	fmt.Println()
+
+	for scanner, scannerOutput := range scannerToOutput {
+		scannerToMetrics[scanner] = scannerOutput.metrics
+	}
+
+	// Merge project and tool scanner outputs
+	for scanner, scannerOutput := range projectScannerToOutputs {
+		scannerToOutput[scanner] = scannerOutput
+	}
+
+	scannerToWarnings := map[string]models.Warnings{}
+	scannerToWarningsWithRecommendation := map[string]models.ErrorsWithRecommendations{}
+
+	scannerToErrors := map[string]models.Errors{}
+	scannerToErrorsWithRecommendations := map[string]models.ErrorsWithRecommendations{}
+
+	scannerToOptions := map[string]models.OptionNode{}
+	scannerToConfigMap := map[string]models.BitriseConfigMap{}
+	scannerToEvidences := map[string]models.Evidences{}
+	icons := models.Icons{}
+	var scannerNames []string
+	for scanner := range scannerToOutput {
+		scannerNames = append(scannerNames, scanner)
+	}
+	sortScannerNames(scannerNames)
+
+	for _, scanner := range scannerNames {
+		scannerOutput := scannerToOutput[scanner]
+		// Currently the tests except an empty warning list if no warnings
+		// are created in the not detect case.
+		if scannerOutput.status == notDetected && (len(scannerOutput.warnings) > 0 || len(scannerOutput.warningsWithRecommendation) > 0) ||
+			scannerOutput.status != notDetected {
+			scannerToWarnings[scanner] = scannerOutput.warnings
+			scannerToWarningsWithRecommendation[scanner] = scannerOutput.warningsWithRecommendation
+		}
+		if (len(scannerOutput.errors) > 0 || len(scannerOutput.errorsWithRecommendation) > 0) &&
+			(scannerOutput.status == detected || scannerOutput.status == detectedWithErrors) {
+			scannerToErrors[scanner] = scannerOutput.errors
+			scannerToErrorsWithRecommendations[scanner] = scannerOutput.errorsWithRecommendation
+		}
+		if len(scannerOutput.configs) > 0 && scannerOutput.status == detected {
+			scannerOutput.options.RankValues()
+			scannerToOptions[scanner] = scannerOutput.options
+			scannerToConfigMap[scanner] = scannerOutput.configs
+		}
+		if len(scannerOutput.evidences) > 0 {
+			scannerToEvidences[scanner] = scannerOutput.evidences
+		}
+		icons = append(icons, scannerOutput.icons...)
+	}
+	return models.ScanResultModel{
+		ScannerToOptionRoot:                  scannerToOptions,
+		ScannerToBitriseConfigMap:            scannerToConfigMap,
+		ScannerToWarnings:                    scannerToWarnings,
+		ScannerToErrors:                      scannerToErrors,
+		ScannerToErrorsWithRecommendations:   scannerToErrorsWithRecommendations,
+		ScannerToWarningsWithRecommendations: scannerToWarningsWithRecommendation,
+		ScannerToEvidences:                   scannerToEvidences,
+		ScannerToSuppressedCandidates:        scannerToSuppressedCandidates,
+		ScannerToMetrics:                     scannerToMetrics,
+		Icons:                                icons,
+	}
+}
+
+// runScanners runs the scanners in order. The scanners excluded by a detected scanner, which can not tell its project roots, are skipped:
+// the overlap resolution would drop them as a whole. The rest of the excluded scanners run, to keep their projects unrelated to the excluding scanner's.
+func runScanners(ctx context.Context, scannerList []scanners.ScannerInterface, searchDir string, hasSSHKey bool) map[string]scannerOutput {
+	scannerOutputs := map[string]scannerOutput{}
+	var excludedScannerNames []string
+	for _, scanner := range scannerList {
+		log.TInfof("Scanner: %s", colorstring.Blue(scanner.Name()))
+		if sliceutil.IsStringInSlice(scanner.Name(), excludedScannerNames) {
+			log.TWarnf("scanner is marked as excluded, skipping...")
+			fmt.Println()
+			continue
+		}
+
+		log.TPrintf("+------------------------------------------------------------------------------+")
+		log.TPrintf("|                                                                              |")
+		metrics.Start()
+		scannerCtx, span := tracer.Start(ctx, "scanner "+scanner.Name(), trace.WithAttributes(attribute.String("scanner.name", scanner.Name())))
+		scannerOutput := runScanner(scannerCtx, scanner, searchDir, hasSSHKey)
+		if scannerOutput.status != notDetected {
+			scannerOutput.evidences = collectEvidences(scanner, searchDir)
+		}
+		usage := metrics.Stop()
+		scannerOutput.metrics.FilesRead = usage.FilesRead
+		for _, command := range usage.Commands {
+			scannerOutput.metrics.Commands = append(scannerOutput.metrics.Commands, models.CommandMetrics{
+				Command:        command.Command,
+				DurationMillis: command.Duration.Milliseconds(),
+			})
+		}
+		span.SetAttributes(
+			attribute.String("scanner.status", scannerOutput.status.String()),
+			attribute.Int("scanner.files_read", usage.FilesRead),
+			attribute.Int("scanner.commands_run", len(usage.Commands)),
+		)
+		span.End()
+		log.TPrintf("Files read: %d, external commands run: %d", usage.FilesRead, len(usage.Commands))
+		log.TPrintf("|                                                                              |")
+		log.TPrintf("+------------------------------------------------------------------------------+")
+		fmt.Println()
+
+		scannerOutputs[scanner.Name()] = scannerOutput
+		if scannerOutput.status == detected {
+			if _, ok := projectRoots(scanner, searchDir); !ok {
+				excludedScannerNames = append(excludedScannerNames, scannerOutput.excludedScanners...)
+			}
+		}
+	}
+	return scannerOutputs
+}
+
+// Collect output of a specific scanner
+func runScanner(ctx context.Context, detector scanners.ScannerInterface, searchDir string, hasSSHKey bool) scannerOutput {
+	output := scannerOutput{}
+
+	startTime := time.Now()
+	_, span := tracer.Start(ctx, "detect platform")
+	isDetect, err := detector.DetectPlatform(searchDir)
+	span.SetAttributes(attribute.Bool("scanner.detected", isDetect))
+	endSpan(span, err)
+	output.metrics.DetectPlatformMillis = time.Since(startTime).Milliseconds()
+	if err != nil {
+		data := detectorErrorData(detector.Name(), err)
+		analytics.LogError(detectPlatformFailedTag, data, "%s detector DetectPlatform failed", detector.Name())
+
+		log.TErrorf("Scanner failed, error: %s", err)
+
+		output.status = notDetected
+		output.AddWarnings(detectPlatformFailedTag, err.Error())
+		return output
+	} else if !isDetect {
+		output.status = notDetected
+		return output
+	}
+
+	startTime = time.Now()
+	_, span = tracer.Start(ctx, "options")
+	options, projectWarnings, icons, err := detector.Options()
+	span.SetAttributes(attribute.Int("scanner.warnings", len(projectWarnings)), attribute.Int("scanner.icons", len(icons)))
+	endSpan(span, err)
+	output.metrics.OptionsMillis = time.Since(startTime).Milliseconds()
+	output.AddWarnings(optionsFailedTag, []string(projectWarnings)...)
+	for _, warning := range projectWarnings {
+		data := detectorErrorData(detector.Name(), errors.New(warning))
+		analytics.LogWarn(optionsFailedTag, data, "%s detector Options warning", detector.Name())
+	}
+
+	if err != nil {
+		data := detectorErrorData(detector.Name(), err)
+		analytics.LogError(optionsFailedTag, data, "%s detector Options failed", detector.Name())
+
+		log.TErrorf("Analyzer failed, error: %s", err)
+
+		// Error returned as a warning
+		output.status = detectedWithErrors
+		output.AddWarnings(optionsFailedTag, err.Error())
+		return output
+	}
+
+	// Generate configs
+	var sshKeyActivation models.SSHKeyActivation
+	if hasSSHKey {
+		sshKeyActivation = models.SSHKeyActivationMandatory
+	} else {
+		sshKeyActivation = models.SSHKeyActivationNone
+	}
+	startTime = time.Now()
+	_, span = tracer.Start(ctx, "configs")
+	configs, err := detector.Configs(sshKeyActivation)
+	span.SetAttributes(attribute.Int("scanner.configs", len(configs)))
+	endSpan(span, err)
+	output.metrics.ConfigsMillis = time.Since(startTime).Milliseconds()
+	if err != nil {
+		data := detectorErrorData(detector.Name(), err)
+		analytics.LogError(configsFailedTag, data, "%s detector Configs failed", detector.Name())
+
+		log.TErrorf("Failed to generate config, error: %s", err)
+
+		output.status = detectedWithErrors
+		output.AddErrors(configsFailedTag, err.Error())
+		return output
+	}
+
+	scannerExcludedScanners := detector.ExcludedScannerNames()
+	if len(scannerExcludedScanners) > 0 {
+		log.TWarnf("Scanner will exclude scanners: %v", scannerExcludedScanners)
+	}
+
+	output.status = detected
+	output.options = options
+	output.configs = configs
+	output.icons = icons
+	output.excludedScanners = scannerExcludedScanners
+	return output
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// collectEvidences returns the evidences of a scanner, with file paths relative to the searchDir
+func collectEvidences(detector scanners.ScannerInterface, searchDir string) models.Evidences {
+	provider, ok := detector.(scanners.EvidenceProvider)
+	if !ok {
+		return nil
+	}
+
+	var evidences models.Evidences
+	for _, evidence := range provider.Evidence() {
+		if filepath.IsAbs(evidence.File) {
+			if relPth, err := filepath.Rel(searchDir, evidence.File); err == nil {
+				evidence.File = relPth
+			}
+		}
+		evidence.File = filepath.ToSlash(filepath.Clean(evidence.File))
+		evidences = append(evidences, evidence)
+	}
+	return evidences
+}
+
+func getDetectedScannerNames(scannerOutputs map[string]scannerOutput) (names []string) {
+	for scanner, scannerOutput := range scannerOutputs {
+		if scannerOutput.status == detected {
+			names = append(names, scanner)
+		}
+	}
+	sortScannerNames(names)
+	return
+}
@@ -0,0 +1,122 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bitrise-io/bitrise-init/errormapper"
+	"github.com/bitrise-io/bitrise-init/scanners"
+
+	"github.com/bitrise-io/bitrise-init/models"
+)
+
+var GradlewNotFoundRecommendation = errormapper.NewDetailedErrorRecommendation(errormapper.DetailedError{
+	Title:       "We couldn't find your Gradle Wrapper. Please make sure there is a gradlew file in your project's root directory.",
+	Description: `The Gradle Wrapper ensures that the right Gradle version is installed and used for the build. You can find out more about <a target="_blank" href="https://docs.gradle.org/current/userguide/gradle_wrapper.html">the Gradle Wrapper in the Gradle docs</a>.`,
+})
+
+var GenericRecommendation = errormapper.NewDetailedErrorRecommendation(newGenericDetail("unexpected end of JSON input"))
+
+func Test_scannerOutput_AddErrors(t *testing.T) {
+	type args struct {
+		tag  string
+		errs []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want scannerOutput
+	}{
+		{
+			name: "Mapped error",
+			args: args{tag: detectPlatformFailedTag, errs: []string{"No Gradle Wrapper (gradlew) found."}},
+			want: scannerOutput{errorsWithRecommendation: []models.ErrorWithRecommendations{{Error: "No Gradle Wrapper (gradlew) found.", Recommendations: GradlewNotFoundRecommendation}}},
+		},
+		{
+			name: "Not mapped error",
+			args: args{tag: configsFailedTag, errs: []string{"unexpected end of JSON input"}},
+			want: scannerOutput{errorsWithRecommendation: []models.ErrorWithRecommendations{{Error: "unexpected end of JSON input", Recommendations: GenericRecommendation}}},
+		},
+		{
+			name: "Multiple errors",
+			args: args{tag: configsFailedTag, errs: []string{"unexpected end of JSON input", "unexpected EOF"}},
+			want: scannerOutput{errorsWithRecommendation: []models.ErrorWithRecommendations{
+				{Error: "unexpected end of JSON input", Recommendations: GenericRecommendation},
+				{Error: "unexpected EOF", Recommendations: errormapper.NewDetailedErrorRecommendation(newGenericDetail("unexpected EOF"))},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := scannerOutput{}
+			if o.AddErrors(tt.args.tag, tt.args.errs...); !reflect.DeepEqual(o, tt.want) {
+				t.Errorf("mapRecommendation() = %v, want %v", o, tt.want)
+			}
+		})
+	}
+}
+
+func Test_scannerOutput_AddWarnings(t *testing.T) {
+	o := scannerOutput{}
+	o.AddWarnings(configsFailedTag, "unexpected end of JSON input", "unexpected EOF")
+
+	want := scannerOutput{warningsWithRecommendation: []models.ErrorWithRecommendations{
+		{Error: "unexpected end of JSON input", Recommendations: GenericRecommendation},
+		{Error: "unexpected EOF", Recommendations: errormapper.NewDetailedErrorRecommendation(newGenericDetail("unexpected EOF"))},
+	}}
+	if !reflect.DeepEqual(o, want) {
+		t.Errorf("AddWarnings() = %v, want %v", o, want)
+	}
+}
+
+type plainScanner struct {
+	scanners.ScannerInterface
+}
+
+type evidenceScanner struct {
+	scanners.ScannerInterface
+	evidences models.Evidences
+}
+
+func (s evidenceScanner) Evidence() models.Evidences {
+	return s.evidences
+}
+
+func Test_collectEvidences(t *testing.T) {
+	tests := []struct {
+		name     string
+		detector scanners.ScannerInterface
+		want     models.Evidences
+	}{
+		{
+			name:     "Scanner without evidence",
+			detector: plainScanner{},
+			want:     nil,
+		},
+		{
+			name: "Relative paths are cleaned",
+			detector: evidenceScanner{evidences: models.Evidences{
+				{File: "./android/app/build.gradle", Marker: "com.android.application plugin"},
+			}},
+			want: models.Evidences{{File: "android/app/build.gradle", Marker: "com.android.application plugin"}},
+		},
+		{
+			name: "Absolute paths are relative to the search dir",
+			detector: evidenceScanner{evidences: models.Evidences{
+				{File: "/scan/dir/package.json", Marker: "react-native dependency"},
+				{File: "/scan/dir", Marker: "root"},
+			}},
+			want: models.Evidences{
+				{File: "package.json", Marker: "react-native dependency"},
+				{File: ".", Marker: "root"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collectEvidences(tt.detector, "/scan/dir"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("collectEvidences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
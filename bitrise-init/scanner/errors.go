@@ -0,0 +1,249 @@
+package scanner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/errormapper"
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/scanners"
+	"github.com/bitrise-io/go-steputils/step"
+)
+
+func newPatternErrorMatcher(defaultBuilder errormapper.DefaultDetailedErrorBuilder, patternToBuilder map[string]errormapper.DetailedErrorBuilder) *errormapper.PatternErrorMatcher {
+	m := errormapper.PatternErrorMatcher{
+		PatternToBuilder: patternToBuilder,
+		DefaultBuilder:   defaultBuilder,
+	}
+
+	return &m
+}
+
+func mapRecommendation(tag, err string) step.Recommendation {
+	var matcher *errormapper.PatternErrorMatcher
+	switch tag {
+	case detectPlatformFailedTag:
+		matcher = newDetectPlatformFailedMatcher()
+	case optionsFailedTag:
+		matcher = newOptionsFailedMatcher()
+	}
+
+	if matcher == nil {
+		matcher = newGenericMatcher()
+	}
+
+	return matcher.Run(err)
+}
+
+func newGenericMatcher() *errormapper.PatternErrorMatcher {
+	return newPatternErrorMatcher(
+		newGenericDetail,
+		nil,
+	)
+}
+
+func newGenericDetail(errorMsg string) errormapper.DetailedError {
+	return errormapper.DetailedError{
+		Title:       errorMsg,
+		Description: "For more information, please see the log.",
+	}
+}
+
+func newNoPlatformDetectedGenericDetail() errormapper.DetailedError {
+	return errormapper.DetailedError{
+		Title:       "We couldn't recognize your platform.",
+		Description: fmt.Sprintf("Our auto-configurator supports %s projects. If you're adding something else, skip this step and configure your Workflow manually.", strings.Join(availableScanners(), ", ")),
+	}
+}
+
+// newNoPlatformDetectedWithToolsDetail is used instead of the generic no platform detected detail,
+// if a tool not supported by the auto-configurator was found.
+func newNoPlatformDetectedWithToolsDetail(tools models.DetectedTools) errormapper.DetailedError {
+	var toolNames []string
+	for _, tool := range tools {
+		toolNames = append(toolNames, tool.Name)
+	}
+
+	description := fmt.Sprintf("Our auto-configurator supports %s projects, but your project uses %s.", strings.Join(availableScanners(), ", "), strings.Join(toolNames, ", "))
+	if len(tools) == 1 {
+		description += "\n" + newDetectedToolDetail(tools[0].Name).Description
+	} else {
+		description += " Skip this step and configure your Workflow manually, see the detected tools for more information."
+	}
+
+	return errormapper.DetailedError{
+		Title:       fmt.Sprintf("We detected %s in your project.", strings.Join(toolNames, ", ")),
+		Description: description,
+	}
+}
+
+// newDetectedToolDetail explains how to set up a tool detected by an UnknownToolDetector.
+func newDetectedToolDetail(toolName string) errormapper.DetailedError {
+	title := fmt.Sprintf("We detected %s in your project.", toolName)
+	switch toolName {
+	case "Tuist":
+		return errormapper.DetailedError{
+			Title:       title,
+			Description: "Tuist generates the Xcode project our auto-configurator looks for. Run `tuist install` and `tuist generate` before scanning, or commit the generated Xcode project. In your Workflow, add a Script Step running the same commands before the Xcode Steps.",
+		}
+	case "Xcodegen":
+		return errormapper.DetailedError{
+			Title:       title,
+			Description: "XcodeGen generates the Xcode project our auto-configurator looks for. Run `xcodegen generate` before scanning, or commit the generated Xcode project. In your Workflow, add a Script Step running the same command before the Xcode Steps.",
+		}
+	case "Buck":
+		return errormapper.DetailedError{
+			Title:       title,
+			Description: "Our auto-configurator doesn't support Buck projects. Skip this step and configure your Workflow manually: add a Script Step which installs Buck and runs `buck build` and `buck test` with your targets.",
+		}
+	case "Kotlin Multiplatform":
+		return errormapper.DetailedError{
+			Title:       title,
+			Description: "Make sure the Gradle Wrapper (gradlew) is committed to your repository, or skip this step and configure your Workflow manually: add a Script Step which runs `./gradlew build` and the Gradle tasks of your targets.",
+		}
+	default:
+		return errormapper.DetailedError{
+			Title:       title,
+			Description: "Our auto-configurator doesn't support this tool. Skip this step and configure your Workflow manually.",
+		}
+	}
+}
+
+func availableScanners() (scannerNames []string) {
+	for _, scanner := range scanners.ProjectScanners() {
+		scannerNames = append(scannerNames, scanner.Name())
+	}
+	for _, scanner := range scanners.AutomationToolScanners() {
+		scannerNames = append(scannerNames, scanner.Name())
+	}
+	return
+}
+
+// detectPlatformFailedTag
+func newDetectPlatformFailedMatcher() *errormapper.PatternErrorMatcher {
+	return newPatternErrorMatcher(
+		newDetectPlatformFailedGenericDetail,
+		map[string]errormapper.DetailedErrorBuilder{
+			`No Gradle Wrapper \(gradlew\) found\.`: newGradlewNotFoundDetail,
+		},
+	)
+}
+
+func newDetectPlatformFailedGenericDetail(errorMsg string) errormapper.DetailedError {
+	return errormapper.DetailedError{
+		Title:       "We couldn't parse your project files.",
+		Description: fmt.Sprintf("You can fix the problem and try again, or skip auto-configuration and set up your project manually. Our auto-configurator returned the following error:\n%s", errorMsg),
+	}
+}
+
+// optionsFailedTag
+func newOptionsFailedMatcher() *errormapper.PatternErrorMatcher {
+	return newPatternErrorMatcher(
+		newOptionsFailedGenericDetail,
+		map[string]errormapper.DetailedErrorBuilder{
+			`app\.json file \((.+)\) missing or empty (.+) entry\nThe app\.json file needs to contain:`:                             newAppJSONIssueDetail,
+			`app\.json file \((.+)\) missing or empty (.+) entry\nIf the project uses Expo Kit the app.json file needs to contain:`: newExpoAppJSONIssueDetail,
+			`Cordova config.xml not found.`:                      newIonicCapacitorNotSupportedIssueDetail,
+			`^(.+) requires JDK (\d+), but (.+) sets JDK (\d+)$`: newDeclaredJDKTooOldDetail,
+			`^Android Gradle plugin (\S+) requires Gradle (\S+) or newer, but the Gradle wrapper uses Gradle (\S+)$`: newGradleWrapperTooOldDetail,
+			`^Gradle (\S+) does not support running on JDK (\d+)$`:                                                   newGradleUnsupportedJDKDetail,
+			`^Gradle wrapper script \((.+)\) is not executable$`:                                                     newGradlewNotExecutableDetail,
+			`^Gradle wrapper jar \((.+)\) not found$`:                                                                newGradleWrapperJarNotFoundDetail,
+			`^Gradle wrapper jar \((.+)\) doesn't match the official checksum of Gradle (\S+)$`:                      newGradleWrapperJarChecksumMismatchDetail,
+		},
+	)
+}
+
+var newOptionsFailedGenericDetail = newDetectPlatformFailedGenericDetail
+
+func newGradlewNotFoundDetail(_ string, _ ...string) errormapper.DetailedError {
+	return errormapper.DetailedError{
+		Title:       "We couldn't find your Gradle Wrapper. Please make sure there is a gradlew file in your project's root directory.",
+		Description: `The Gradle Wrapper ensures that the right Gradle version is installed and used for the build. You can find out more about <a target="_blank" href="https://docs.gradle.org/current/userguide/gradle_wrapper.html">the Gradle Wrapper in the Gradle docs</a>.`,
+	}
+}
+
+func newAppJSONIssueDetail(_ string, params ...string) errormapper.DetailedError {
+	appJSONPath := params[0]
+	entryName := params[1]
+	return errormapper.DetailedError{
+		Title: fmt.Sprintf("Your app.json file (%s) doesn't have a %s field.", appJSONPath, entryName),
+		Description: `The app.json file needs to contain the following entries:
+- name
+- displayName`,
+	}
+}
+
+func newExpoAppJSONIssueDetail(_ string, params ...string) errormapper.DetailedError {
+	appJSONPath := params[0]
+	entryName := params[1]
+	return errormapper.DetailedError{
+		Title: fmt.Sprintf("Your app.json file (%s) doesn't have a %s field.", appJSONPath, entryName),
+		Description: `If your project uses Expo Kit, the app.json file needs to contain the following entries:
+- expo/name
+- expo/ios/bundleIdentifier
+- expo/android/package`,
+	}
+}
+
+func newIonicCapacitorNotSupportedIssueDetail(_ string, _ ...string) errormapper.DetailedError {
+	return errormapper.DetailedError{
+		Title:       "We couldn't find your cordova.xml file.",
+		Description: `Our auto-configurator only supports Ionic projects with Cordova at the moment. If you're trying to add a project with Ionic Capacitor, or something else, some Steps in your automatically generated Workflow might fail. To fix this, replace the failing Steps with script Steps in the Workflow editor later.`,
+	}
+}
+
+func newDeclaredJDKTooOldDetail(_ string, params ...string) errormapper.DetailedError {
+	requiredBy := errormapper.GetParamAt(0, params)
+	requiredJDK := errormapper.GetParamAt(1, params)
+	declaredBy := errormapper.GetParamAt(2, params)
+	declaredJDK := errormapper.GetParamAt(3, params)
+	return errormapper.DetailedError{
+		Title:       fmt.Sprintf("Your project sets JDK %s, but %s requires JDK %s.", declaredJDK, requiredBy, requiredJDK),
+		Description: fmt.Sprintf("The generated Workflows set up JDK %s with the Set Java version Step. Update the JDK version in %s to %s, so that your local builds use the same JDK.", requiredJDK, declaredBy, requiredJDK),
+	}
+}
+
+func newGradleWrapperTooOldDetail(_ string, params ...string) errormapper.DetailedError {
+	agpVersion := errormapper.GetParamAt(0, params)
+	minimumGradleVersion := errormapper.GetParamAt(1, params)
+	gradleVersion := errormapper.GetParamAt(2, params)
+	return errormapper.DetailedError{
+		Title:       fmt.Sprintf("Your Gradle wrapper uses Gradle %s, but Android Gradle plugin %s requires Gradle %s or newer.", gradleVersion, agpVersion, minimumGradleVersion),
+		Description: fmt.Sprintf("Update the Gradle wrapper by running `./gradlew wrapper --gradle-version %s` and commit the changed files of the gradle/wrapper directory. You can find out more about <a target=\"_blank\" href=\"https://developer.android.com/build/releases/gradle-plugin#updating-gradle\">the compatible Gradle versions in the Android docs</a>.", minimumGradleVersion),
+	}
+}
+
+func newGradleUnsupportedJDKDetail(_ string, params ...string) errormapper.DetailedError {
+	gradleVersion := errormapper.GetParamAt(0, params)
+	jdkVersion := errormapper.GetParamAt(1, params)
+	return errormapper.DetailedError{
+		Title:       fmt.Sprintf("Gradle %s can't run on JDK %s, which your project requires.", gradleVersion, jdkVersion),
+		Description: `Update the Gradle wrapper to a Gradle version supporting the JDK by running ` + "`./gradlew wrapper --gradle-version <version>`" + `, and commit the changed files of the gradle/wrapper directory. You can find out more about <a target="_blank" href="https://docs.gradle.org/current/userguide/compatibility.html">the supported JDK versions in the Gradle docs</a>.`,
+	}
+}
+
+func newGradlewNotExecutableDetail(_ string, params ...string) errormapper.DetailedError {
+	gradlewPath := errormapper.GetParamAt(0, params)
+	return errormapper.DetailedError{
+		Title:       fmt.Sprintf("Your Gradle Wrapper (%s) is not executable.", gradlewPath),
+		Description: fmt.Sprintf("The builds will fail with a permission denied error when running the Gradle Wrapper. Set the executable bit by running `chmod +x %s` and `git update-index --chmod=+x %s`, then commit and push the change.", gradlewPath, gradlewPath),
+	}
+}
+
+func newGradleWrapperJarNotFoundDetail(_ string, params ...string) errormapper.DetailedError {
+	jarPath := errormapper.GetParamAt(0, params)
+	return errormapper.DetailedError{
+		Title:       fmt.Sprintf("We couldn't find your Gradle Wrapper jar (%s).", jarPath),
+		Description: "The Gradle Wrapper script runs the jar to download Gradle. Generate the wrapper files by running `gradle wrapper`, and commit the gradle/wrapper/gradle-wrapper.jar file. Make sure your .gitignore file doesn't exclude it.",
+	}
+}
+
+func newGradleWrapperJarChecksumMismatchDetail(_ string, params ...string) errormapper.DetailedError {
+	jarPath := errormapper.GetParamAt(0, params)
+	gradleVersion := errormapper.GetParamAt(1, params)
+	return errormapper.DetailedError{
+		Title:       fmt.Sprintf("Your Gradle Wrapper jar (%s) doesn't match the official jar of Gradle %s.", jarPath, gradleVersion),
+		Description: fmt.Sprintf(`The jar might belong to another Gradle version, or it might have been modified. Regenerate it by running `+"`./gradlew wrapper --gradle-version %s`"+`, and commit the changed files of the gradle/wrapper directory. You can find out more about <a target="_blank" href="https://docs.gradle.org/current/userguide/gradle_wrapper.html#wrapper_checksum_verification">verifying the Gradle Wrapper jar in the Gradle docs</a>.`, gradleVersion),
+	}
+}
@@ -0,0 +1,222 @@
+package scanner
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/scanners"
+	"github.com/bitrise-io/bitrise-init/scanners/android"
+	"github.com/bitrise-io/bitrise-init/scanners/ios"
+	"github.com/bitrise-io/bitrise-init/scanners/java"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/sliceutil"
+)
+
+// nativeScannerNames are the scanners of the native projects, which overlap with a cross-platform project only in its native project dirs.
+var nativeScannerNames = []string{
+	string(ios.XcodeProjectTypeIOS),
+	string(ios.XcodeProjectTypeMacOS),
+	android.ScannerName,
+	java.ProjectType,
+}
+
+// resolveOverlaps runs after all the project scanners and applies the scanners' ExcludedScannerNames.
+// A project root of an excluded scanner is dropped if it is the same as, or nested in a project root of the excluding scanner,
+// the rest of the excluded scanner's options are kept.
+// A native project root is dropped only if it is one of the native dirs of a cross-platform project root, or nested in it.
+// If any of the scanners can not tell its project roots, the excluded scanner is dropped as a whole, keeping its warnings and errors.
+// Scanners are processed in the order of scannerList, a scanner dropped as a whole does not exclude other scanners.
+// Returns the suppressed candidates by scanner name.
+func resolveOverlaps(scannerList []scanners.ScannerInterface, scannerToOutput map[string]scannerOutput, searchDir string) map[string]models.SuppressedCandidates {
+	scannerToSuppressed := map[string]models.SuppressedCandidates{}
+	var excludingScanners []scanners.ScannerInterface
+
+	for _, scanner := range scannerList {
+		output, ok := scannerToOutput[scanner.Name()]
+		if !ok || output.status == notDetected {
+			continue
+		}
+
+		keep := true
+		for _, excludingScanner := range excludingScanners {
+			if !sliceutil.IsStringInSlice(scanner.Name(), scannerToOutput[excludingScanner.Name()].excludedScanners) {
+				continue
+			}
+
+			var suppressed models.SuppressedCandidates
+			suppressed, keep = suppressOverlappingRoots(excludingScanner, scanner, &output, searchDir)
+			scannerToSuppressed[scanner.Name()] = append(scannerToSuppressed[scanner.Name()], suppressed...)
+			for _, candidate := range suppressed {
+				log.TWarnf("%s scanner candidate suppressed: %s", scanner.Name(), candidate.Reason)
+			}
+			if !keep {
+				break
+			}
+		}
+
+		if !keep {
+			scannerToOutput[scanner.Name()] = droppedOutput(output)
+			continue
+		}
+
+		scannerToOutput[scanner.Name()] = output
+		if output.status == detected && len(output.excludedScanners) > 0 {
+			excludingScanners = append(excludingScanners, scanner)
+		}
+	}
+
+	return scannerToSuppressed
+}
+
+// suppressOverlappingRoots drops the project roots of the excluded scanner, which overlap with the excluding scanner's project roots.
+// Returns the suppressed candidates and whether the excluded scanner's output should be kept.
+func suppressOverlappingRoots(excludingScanner, excludedScanner scanners.ScannerInterface, output *scannerOutput, searchDir string) (models.SuppressedCandidates, bool) {
+	excludingRoots, excludingOK := projectRoots(excludingScanner, searchDir)
+	excludedRoots, excludedOK := projectRoots(excludedScanner, searchDir)
+	if !excludingOK || !excludedOK {
+		return models.SuppressedCandidates{{
+			SuppressedBy: excludingScanner.Name(),
+			Reason:       fmt.Sprintf("%s scanner excludes the %s scanner", excludingScanner.Name(), excludedScanner.Name()),
+		}}, false
+	}
+
+	native := sliceutil.IsStringInSlice(excludedScanner.Name(), nativeScannerNames)
+	var suppressed models.SuppressedCandidates
+	keep := true
+	for _, root := range excludedRoots {
+		excludingRoot, overlaps := findEnclosingRoot(root.Dir, excludingRoots, native)
+		if !overlaps {
+			continue
+		}
+
+		suppressed = append(suppressed, models.SuppressedCandidate{
+			ProjectRoot:  root.Dir,
+			SuppressedBy: excludingScanner.Name(),
+			Reason:       fmt.Sprintf("%s project at %s is part of the %s project at %s", excludedScanner.Name(), root.Dir, excludingScanner.Name(), excludingRoot.Dir),
+		})
+
+		if root.OptionValue == "" || output.status != detected || output.options.ChildOptionMap[root.OptionValue] == nil {
+			keep = false
+			continue
+		}
+		delete(output.options.ChildOptionMap, root.OptionValue)
+		output.evidences = removeEvidencesIn(output.evidences, root.Dir)
+	}
+
+	if !keep || (output.status == detected && len(output.options.ChildOptionMap) == 0) {
+		return suppressed, false
+	}
+	if len(suppressed) > 0 {
+		removeUnusedConfigs(output)
+	}
+
+	return suppressed, true
+}
+
+// projectRoots returns the scanner's project roots with directories relative to the searchDir.
+func projectRoots(scanner scanners.ScannerInterface, searchDir string) ([]models.ProjectRoot, bool) {
+	provider, ok := scanner.(scanners.ProjectRootProvider)
+	if !ok {
+		return nil, false
+	}
+
+	var roots []models.ProjectRoot
+	for _, root := range provider.ProjectRoots() {
+		if filepath.IsAbs(root.Dir) {
+			relDir, err := filepath.Rel(searchDir, root.Dir)
+			if err != nil {
+				return nil, false
+			}
+			root.Dir = relDir
+		}
+		root.Dir = filepath.ToSlash(filepath.Clean(root.Dir))
+
+		if root.NativeDirs != nil {
+			nativeDirs := make([]string, 0, len(root.NativeDirs))
+			for _, dir := range root.NativeDirs {
+				if filepath.IsAbs(dir) {
+					relDir, err := filepath.Rel(searchDir, dir)
+					if err != nil {
+						return nil, false
+					}
+					dir = relDir
+				}
+				nativeDirs = append(nativeDirs, filepath.ToSlash(filepath.Clean(dir)))
+			}
+			root.NativeDirs = nativeDirs
+		}
+
+		roots = append(roots, root)
+	}
+	return roots, len(roots) > 0
+}
+
+// findEnclosingRoot returns the root the dir belongs to.
+// The dir of a native project belongs to a cross-platform root only if it is one of the root's native dirs, or nested in it.
+func findEnclosingRoot(dir string, roots []models.ProjectRoot, native bool) (models.ProjectRoot, bool) {
+	for _, root := range roots {
+		if native && root.NativeDirs != nil {
+			for _, nativeDir := range root.NativeDirs {
+				// A native dir of "." (like the Gradle root of a Kotlin Multiplatform project) does not enclose the nested projects
+				if dir == nativeDir || (nativeDir != "." && isInDir(dir, nativeDir)) {
+					return root, true
+				}
+			}
+			continue
+		}
+		if isInDir(dir, root.Dir) {
+			return root, true
+		}
+	}
+	return models.ProjectRoot{}, false
+}
+
+func isInDir(pth, dir string) bool {
+	return dir == "." || pth == dir || strings.HasPrefix(pth, dir+"/")
+}
+
+func removeEvidencesIn(evidences models.Evidences, dir string) models.Evidences {
+	var kept models.Evidences
+	for _, evidence := range evidences {
+		if !isInDir(evidence.File, dir) {
+			kept = append(kept, evidence)
+		}
+	}
+	return kept
+}
+
+// droppedOutput returns the output of a scanner dropped as a whole: its options, configs and evidences are removed,
+// the errors are kept as warnings of the not detected scanner.
+func droppedOutput(output scannerOutput) scannerOutput {
+	return scannerOutput{
+		status:                     notDetected,
+		warnings:                   append(output.warnings, output.errors...),
+		warningsWithRecommendation: append(output.warningsWithRecommendation, output.errorsWithRecommendation...),
+		metrics:                    output.metrics,
+	}
+}
+
+func removeUnusedConfigs(output *scannerOutput) {
+	usedConfigs := map[string]bool{}
+	var walk func(option *models.OptionNode)
+	walk = func(option *models.OptionNode) {
+		if option == nil {
+			return
+		}
+		if option.IsConfigOption() {
+			usedConfigs[option.Config] = true
+		}
+		for _, child := range option.ChildOptionMap {
+			walk(child)
+		}
+	}
+	walk(&output.options)
+
+	for name := range output.configs {
+		if !usedConfigs[name] {
+			delete(output.configs, name)
+		}
+	}
+}
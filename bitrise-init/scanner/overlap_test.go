@@ -0,0 +1,240 @@
+package scanner
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/scanners"
+)
+
+type rootScanner struct {
+	scanners.ScannerInterface
+	name  string
+	roots []models.ProjectRoot
+}
+
+func (s rootScanner) Name() string {
+	return s.name
+}
+
+func (s rootScanner) ProjectRoots() []models.ProjectRoot {
+	return s.roots
+}
+
+type namedScanner struct {
+	scanners.ScannerInterface
+	name string
+}
+
+func (s namedScanner) Name() string {
+	return s.name
+}
+
+// detectedOutput returns a detected scanner output with a top-level option and a config per option value.
+func detectedOutput(excludedScanners []string, optionValues ...string) scannerOutput {
+	option := models.NewOption("Project", "", "PROJECT", models.TypeSelector)
+	configs := models.BitriseConfigMap{}
+	for _, value := range optionValues {
+		option.AddConfig(value, models.NewConfigOption(value+"-config", nil))
+		configs[value+"-config"] = "config"
+	}
+	return scannerOutput{
+		status:           detected,
+		options:          *option,
+		configs:          configs,
+		excludedScanners: excludedScanners,
+		warnings:         models.Warnings{"warning of " + optionValues[0]},
+	}
+}
+
+func Test_resolveOverlaps(t *testing.T) {
+	nativeScanners := []string{"ios", "android"}
+
+	tests := []struct {
+		name            string
+		scannerList     []scanners.ScannerInterface
+		outputs         map[string]scannerOutput
+		wantOptions     map[string][]string
+		wantWarnings    map[string]models.Warnings
+		wantSuppressed  map[string][]string
+		wantNotDetected []string
+	}{
+		{
+			name: "React Native project suppresses only its own native projects",
+			scannerList: []scanners.ScannerInterface{
+				rootScanner{name: "react-native", roots: []models.ProjectRoot{{Dir: ".", OptionValue: ".", NativeDirs: []string{"ios", "android"}}}},
+				rootScanner{name: "ios", roots: []models.ProjectRoot{
+					{Dir: "ios", OptionValue: "ios/App.xcworkspace"},
+					{Dir: "native/ios", OptionValue: "native/ios/Native.xcodeproj"},
+				}},
+				rootScanner{name: "android", roots: []models.ProjectRoot{{Dir: "android", OptionValue: "android"}}},
+			},
+			outputs: map[string]scannerOutput{
+				"react-native": detectedOutput(nativeScanners, "."),
+				"ios":          detectedOutput(nil, "ios/App.xcworkspace", "native/ios/Native.xcodeproj"),
+				"android":      detectedOutput(nil, "android"),
+			},
+			wantOptions: map[string][]string{
+				"react-native": {"."},
+				"ios":          {"native/ios/Native.xcodeproj"},
+			},
+			wantWarnings: map[string]models.Warnings{
+				"react-native": {"warning of ."},
+				"ios":          {"warning of ios/App.xcworkspace"},
+				"android":      {"warning of android"},
+			},
+			wantSuppressed: map[string][]string{
+				"ios":     {"ios"},
+				"android": {"android"},
+			},
+			wantNotDetected: []string{"android"},
+		},
+		{
+			name: "Cross-platform project without native projects keeps the native projects",
+			scannerList: []scanners.ScannerInterface{
+				rootScanner{name: "react-native", roots: []models.ProjectRoot{{Dir: ".", OptionValue: ".", NativeDirs: []string{}}}},
+				rootScanner{name: "ios", roots: []models.ProjectRoot{{Dir: "ios", OptionValue: "ios/App.xcworkspace"}}},
+			},
+			outputs: map[string]scannerOutput{
+				"react-native": detectedOutput(nativeScanners, "."),
+				"ios":          detectedOutput(nil, "ios/App.xcworkspace"),
+			},
+			wantOptions: map[string][]string{
+				"react-native": {"."},
+				"ios":          {"ios/App.xcworkspace"},
+			},
+			wantWarnings: map[string]models.Warnings{
+				"react-native": {"warning of ."},
+				"ios":          {"warning of ios/App.xcworkspace"},
+			},
+		},
+		{
+			name: "Native dir at the search dir root does not enclose the nested projects",
+			scannerList: []scanners.ScannerInterface{
+				rootScanner{name: "kotlin-multiplatform", roots: []models.ProjectRoot{{Dir: ".", OptionValue: ".", NativeDirs: []string{"."}}}},
+				rootScanner{name: "android", roots: []models.ProjectRoot{
+					{Dir: ".", OptionValue: "."},
+					{Dir: "other", OptionValue: "other"},
+				}},
+			},
+			outputs: map[string]scannerOutput{
+				"kotlin-multiplatform": detectedOutput([]string{"android"}, "."),
+				"android":              detectedOutput(nil, ".", "other"),
+			},
+			wantOptions: map[string][]string{
+				"kotlin-multiplatform": {"."},
+				"android":              {"other"},
+			},
+			wantWarnings: map[string]models.Warnings{
+				"kotlin-multiplatform": {"warning of ."},
+				"android":              {"warning of ."},
+			},
+			wantSuppressed: map[string][]string{
+				"android": {"."},
+			},
+		},
+		{
+			name: "Non-native projects are suppressed in the whole project root",
+			scannerList: []scanners.ScannerInterface{
+				rootScanner{name: "react-native", roots: []models.ProjectRoot{{Dir: ".", OptionValue: ".", NativeDirs: []string{"ios"}}}},
+				rootScanner{name: "nodejs", roots: []models.ProjectRoot{{Dir: ".", OptionValue: "."}}},
+			},
+			outputs: map[string]scannerOutput{
+				"react-native": detectedOutput([]string{"nodejs"}, "."),
+				"nodejs":       detectedOutput(nil, "."),
+			},
+			wantOptions: map[string][]string{
+				"react-native": {"."},
+			},
+			wantWarnings: map[string]models.Warnings{
+				"react-native": {"warning of ."},
+				"nodejs":       {"warning of ."},
+			},
+			wantSuppressed: map[string][]string{
+				"nodejs": {"."},
+			},
+			wantNotDetected: []string{"nodejs"},
+		},
+		{
+			name: "Excluded scanner is dropped as a whole, keeping its warnings and errors, if the excluding scanner has no project roots",
+			scannerList: []scanners.ScannerInterface{
+				namedScanner{name: "cordova"},
+				rootScanner{name: "android", roots: []models.ProjectRoot{{Dir: "android", OptionValue: "android"}}},
+			},
+			outputs: map[string]scannerOutput{
+				"cordova": detectedOutput([]string{"android"}, "."),
+				"android": func() scannerOutput {
+					output := detectedOutput(nil, "android")
+					output.errors = models.Errors{"error of android"}
+					return output
+				}(),
+			},
+			wantOptions: map[string][]string{
+				"cordova": {"."},
+			},
+			wantWarnings: map[string]models.Warnings{
+				"cordova": {"warning of ."},
+				"android": {"warning of android", "error of android"},
+			},
+			wantSuppressed: map[string][]string{
+				"android": {""},
+			},
+			wantNotDetected: []string{"android"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suppressed := resolveOverlaps(tt.scannerList, tt.outputs, "/scan/dir")
+
+			gotOptions := map[string][]string{}
+			gotWarnings := map[string]models.Warnings{}
+			var gotNotDetected []string
+			for name, output := range tt.outputs {
+				gotWarnings[name] = output.warnings
+				if output.status == notDetected {
+					gotNotDetected = append(gotNotDetected, name)
+					if len(output.options.ChildOptionMap) > 0 || len(output.configs) > 0 {
+						t.Errorf("%s: dropped scanner kept its options or configs", name)
+					}
+					continue
+				}
+				for value := range output.options.ChildOptionMap {
+					gotOptions[name] = append(gotOptions[name], value)
+					if _, ok := output.configs[value+"-config"]; !ok {
+						t.Errorf("%s: config of option %s removed", name, value)
+					}
+				}
+				sort.Strings(gotOptions[name])
+				if len(output.configs) != len(output.options.ChildOptionMap) {
+					t.Errorf("%s: unused configs kept: %v", name, output.configs)
+				}
+			}
+
+			gotSuppressed := map[string][]string{}
+			for name, candidates := range suppressed {
+				for _, candidate := range candidates {
+					gotSuppressed[name] = append(gotSuppressed[name], candidate.ProjectRoot)
+				}
+			}
+
+			if !reflect.DeepEqual(gotOptions, tt.wantOptions) {
+				t.Errorf("options = %v, want %v", gotOptions, tt.wantOptions)
+			}
+			if !reflect.DeepEqual(gotWarnings, tt.wantWarnings) {
+				t.Errorf("warnings = %v, want %v", gotWarnings, tt.wantWarnings)
+			}
+			if tt.wantSuppressed == nil {
+				tt.wantSuppressed = map[string][]string{}
+			}
+			if !reflect.DeepEqual(gotSuppressed, tt.wantSuppressed) {
+				t.Errorf("suppressed = %v, want %v", gotSuppressed, tt.wantSuppressed)
+			}
+			sort.Strings(gotNotDetected)
+			if !reflect.DeepEqual(gotNotDetected, tt.wantNotDetected) {
+				t.Errorf("not detected = %v, want %v", gotNotDetected, tt.wantNotDetected)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/bitrise-io/bitrise-init/analytics"
+	"github.com/bitrise-io/bitrise-init/errormapper"
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/output"
+	"github.com/bitrise-io/bitrise-init/scanners"
+	"github.com/bitrise-io/bitrise-init/scanners/ios"
+	"github.com/bitrise-io/go-steputils/step"
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/sliceutil"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// GenerateScanResult runs the scanner, returns the results and if any platform was detected.
+func GenerateScanResult(searchDir string, hasSSHKey bool) (models.ScanResultModel, bool) {
+	return GenerateScanResultWithContext(context.Background(), searchDir, hasSSHKey, scanners.Options{})
+}
+
+// GenerateScanResultWithContext runs the scanner like GenerateScanResult, tracing it as a child of the span in ctx.
+// The project scanners are configured by the options.
+func GenerateScanResultWithContext(ctx context.Context, searchDir string, hasSSHKey bool, options scanners.Options) (models.ScanResultModel, bool) {
+	ctx, span := tracer.Start(ctx, "scan")
+	defer span.End()
+
+	projectScanners := scanners.ProjectScannersWithOptions(options)
+	scanResult := config(ctx, searchDir, hasSSHKey, projectScanners)
+
+	scanResult.DetectedTools = detectUnknownTools(ctx, searchDir, generatedProjectTools(projectScanners))
+
+	var platforms []string
+	for platform := range scanResult.ScannerToOptionRoot {
+		platforms = append(platforms, platform)
+	}
+
+	if len(platforms) == 0 {
+		analytics.LogError(noPlatformDetectedTag, nil, "No known platform detected")
+
+		recommendations := step.Recommendation{
+			"NoPlatformDetected":            true,
+			errormapper.DetailedErrorRecKey: newNoPlatformDetectedGenericDetail(),
+		}
+		if len(scanResult.DetectedTools) > 0 {
+			var toolNames []string
+			for _, tool := range scanResult.DetectedTools {
+				toolNames = append(toolNames, tool.Name)
+			}
+			recommendations["DetectedTools"] = toolNames
+			recommendations[errormapper.DetailedErrorRecKey] = newNoPlatformDetectedWithToolsDetail(scanResult.DetectedTools)
+		}
+
+		scanResult.AddErrorWithRecommendation("general", models.ErrorWithRecommendations{
+			Error:           "No known platform detected",
+			Recommendations: recommendations,
+		})
+		return scanResult, false
+	}
+	return scanResult, true
+}
+
+// GenerateAndWriteResults runs the scanner and saves results to the given output dir.
+func GenerateAndWriteResults(searchDir string, outputDir string, format output.Format) (models.ScanResultModel, error) {
+	result, detected := GenerateScanResult(searchDir, true)
+
+	// Write output to files
+	log.TInfof("Saving outputs:")
+	outputPth, err := writeScanResult(result, outputDir, format)
+	if err != nil {
+		return result, fmt.Errorf("failed to write output, error: %w", err)
+	}
+	log.TPrintf("scan result: %s", outputPth)
+
+	if !detected {
+		printDirTree()
+		//nolint:staticcheck // Other components potentially rely on the error message
+		return result, fmt.Errorf("No known platform detected")
+	}
+	return result, nil
+}
+
+func printDirTree() {
+	cmd := command.New("which", "tree")
+	out, err := cmd.RunAndReturnTrimmedCombinedOutput()
+	if err != nil || out == "" {
+		log.TErrorf("tree not installed, can not list files")
+	} else {
+		fmt.Println()
+		cmd := command.NewWithStandardOuts("tree", ".", "-L", "3")
+		log.TPrintf("$ %s", cmd.PrintableCommandArgs())
+		if err := cmd.Run(); err != nil {
+			log.TErrorf("Failed to list files in current directory, error: %s", err)
+		}
+	}
+}
+
+func writeScanResult(scanResult models.ScanResultModel, outputDir string, format output.Format) (string, error) {
+	if len(scanResult.Icons) != 0 {
+		const iconDirName = "icons"
+		iconsOutputDir := filepath.Join(outputDir, iconDirName)
+		if err := os.MkdirAll(iconsOutputDir, 0755); err != nil {
+			return "", fmt.Errorf("failed to create icons directory")
+		}
+		if err := copyIconsToDir(scanResult.Icons, iconsOutputDir); err != nil {
+			return "", fmt.Errorf("failed to copy icons, error: %w", err)
+		}
+	}
+
+	return output.WriteToFile(scanResult, format, path.Join(outputDir, "result"))
+}
+
+// generatedProjectTools returns the names of the UnknownToolDetectors tools, which the iOS scanner generated Xcode projects with.
+func generatedProjectTools(projectScanners []scanners.ScannerInterface) []string {
+	var toolNames []string
+	for _, projectScanner := range projectScanners {
+		iosScanner, ok := projectScanner.(*ios.Scanner)
+		if !ok {
+			continue
+		}
+		for _, project := range iosScanner.DetectResult.Projects {
+			var toolName string
+			switch project.Generation.Generator {
+			case ios.ProjectGeneratorTuist:
+				toolName = "Tuist"
+			case ios.ProjectGeneratorXcodeGen:
+				toolName = "Xcodegen"
+			default:
+				continue
+			}
+			if !sliceutil.IsStringInSlice(toolName, toolNames) {
+				toolNames = append(toolNames, toolName)
+			}
+		}
+	}
+	return toolNames
+}
+
+// detectUnknownTools runs the UnknownToolDetectors and returns the detected tools, with a recommendation on how to set them up manually.
+// The supportedTools are not detected: the scanners already set up their projects.
+func detectUnknownTools(ctx context.Context, searchDir string, supportedTools []string) models.DetectedTools {
+	ctx, span := tracer.Start(ctx, "detect unknown tools")
+	defer span.End()
+
+	var detectedTools models.DetectedTools
+	for _, detector := range UnknownToolDetectors {
+		if sliceutil.IsStringInSlice(detector.ToolName(), supportedTools) {
+			log.Debugf("Skipping %s detection, its projects are generated by the scanners", detector.ToolName())
+			continue
+		}
+
+		_, detectorSpan := tracer.Start(ctx, "detect "+detector.ToolName())
+		result, err := detector.DetectToolIn(searchDir)
+		detectorSpan.SetAttributes(attribute.Bool("tool.detected", result.Detected))
+		endSpan(detectorSpan, err)
+		if err != nil {
+			log.Warnf("Failed to detect %s: %s", detector.ToolName(), err)
+		}
+		if result.Detected {
+			data := map[string]interface{}{
+				"project_tree": result.ProjectTree,
+			}
+			analytics.LogInfo("tool-detector", data, "Tool detected: %s", detector.ToolName())
+			log.Debugf("Tool detected: %s", detector.ToolName())
+
+			detectedTools = append(detectedTools, models.DetectedTool{
+				Name: detector.ToolName(),
+				Recommendations: step.Recommendation{
+					errormapper.DetailedErrorRecKey: newDetectedToolDetail(detector.ToolName()),
+				},
+			})
+		}
+	}
+	return detectedTools
+}
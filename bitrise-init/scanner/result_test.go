@@ -0,0 +1,77 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/bitrise-io/bitrise-init/scanners"
+	"github.com/bitrise-io/bitrise-init/scanners/ios"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_generatedProjectTools(t *testing.T) {
+	tests := []struct {
+		name     string
+		projects []ios.Project
+		want     []string
+	}{
+		{
+			name:     "Committed Xcode project",
+			projects: []ios.Project{{RelPath: "App.xcodeproj"}},
+		},
+		{
+			name: "Generated Xcode projects",
+			projects: []ios.Project{
+				{RelPath: "App.xcworkspace", Generation: ios.ProjectGeneration{Generator: ios.ProjectGeneratorTuist, Manifest: "Workspace.swift"}},
+				{RelPath: "Framework/Framework.xcodeproj", Generation: ios.ProjectGeneration{Generator: ios.ProjectGeneratorTuist, Manifest: "Framework/Project.swift"}},
+				{RelPath: "Other/Other.xcodeproj", Generation: ios.ProjectGeneration{Generator: ios.ProjectGeneratorXcodeGen, Manifest: "Other/project.yml"}},
+			},
+			want: []string{"Tuist", "Xcodegen"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iosScanner := ios.NewScanner()
+			iosScanner.DetectResult = ios.DetectResult{Projects: tt.projects}
+
+			got := generatedProjectTools([]scanners.ScannerInterface{namedScanner{name: "android"}, iosScanner})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("generatedProjectTools() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_detectUnknownTools(t *testing.T) {
+	searchDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(searchDir, "Project.swift"), nil, 0600))
+
+	tests := []struct {
+		name           string
+		supportedTools []string
+		want           []string
+	}{
+		{
+			name: "Tuist manifest is reported",
+			want: []string{"Tuist"},
+		},
+		{
+			name:           "Tuist manifest is not reported if the Xcode project was generated",
+			supportedTools: []string{"Tuist"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, tool := range detectUnknownTools(context.Background(), searchDir, tt.supportedTools) {
+				got = append(got, tool.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("detectUnknownTools() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
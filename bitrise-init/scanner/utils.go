@@ -0,0 +1,235 @@
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/scanners"
+	bitriseModels "github.com/bitrise-io/bitrise/v2/models"
+	envmanModels "github.com/bitrise-io/envman/v2/models"
+	"github.com/bitrise-io/goinp/goinp"
+)
+
+func getDefaultValue(opt models.OptionNode) string {
+	if opt.Type == models.TypeOptionalSelector {
+		return ""
+	}
+
+	if values := opt.RankedValues(); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// scannerOrder is the position of the scanners by name, in the order the scanners run.
+var scannerOrder = func() map[string]int {
+	order := map[string]int{}
+	for i, scanner := range append(scanners.ProjectScanners(), scanners.AutomationToolScanners()...) {
+		order[scanner.Name()] = i
+	}
+	return order
+}()
+
+// sortScannerNames orders the scanner names as the scanners run, the more specific project types first.
+func sortScannerNames(names []string) {
+	sort.SliceStable(names, func(i, j int) bool {
+		iOrder, iKnown := scannerOrder[names[i]]
+		jOrder, jKnown := scannerOrder[names[j]]
+		if iKnown != jKnown {
+			return iKnown
+		}
+		if iOrder != jOrder {
+			return iOrder < jOrder
+		}
+		return names[i] < names[j]
+	})
+}
+
+func getOptions(opt models.OptionNode) []string {
+	return opt.RankedValues()
+}
+
+func selectOption(options []string) (string, error) {
+	for i, option := range options {
+		fmt.Printf("[%d] : %s\n", i+1, option)
+	}
+	fmt.Printf("Type in the option's number, then hit Enter: ")
+
+	answer, err := goinp.AskForOptionalInput("", false)
+	if err != nil {
+		return "", err
+	}
+
+	optionNo, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse option number, pick a number from 1-%d", len(options))
+	}
+
+	if optionNo-1 < 0 || optionNo-1 >= len(options) {
+		return "", fmt.Errorf("invalid option number, pick a number from 1-%d", len(options))
+	}
+
+	return options[optionNo-1], nil
+}
+
+func askForOptionValue(option models.OptionNode) (string, string, error) {
+	const customValueOptionText = "<custom value>"
+
+	// this options is a last element in a tree, contains only config name
+	if option.Config != "" {
+		return "", option.Config, nil
+	}
+
+	optional := option.Type == models.TypeOptionalUserInput || option.Type == models.TypeOptionalSelector
+
+	switch option.Type {
+	case models.TypeSelector, models.TypeOptionalSelector:
+		fmt.Println("Select \"" + option.Title + "\" from the list:")
+
+		options := getOptions(option)
+		if optional {
+			options = append(options, customValueOptionText)
+		}
+
+		if len(options) == 1 {
+			return option.EnvKey, options[0], nil
+		}
+
+		selected, err := selectOption(options)
+		if err != nil {
+			return "", "", err
+		}
+
+		if option.Type == models.TypeSelector || selected != customValueOptionText {
+			return option.EnvKey, selected, nil
+		}
+
+		fallthrough
+	case models.TypeUserInput, models.TypeOptionalUserInput:
+		suffix := ": "
+		if optional {
+			suffix = " (optional): "
+		}
+		fmt.Print("Enter value for \"" + option.Title + "\"" + suffix)
+
+		answer, err := goinp.AskForOptionalInput(getDefaultValue(option), optional)
+		return option.EnvKey, strings.TrimSpace(answer), err
+	}
+
+	return "", "", fmt.Errorf("invalid input type")
+}
+
+// AskForOptions ...
+func AskForOptions(options models.OptionNode) (string, []envmanModels.EnvironmentItemModel, error) {
+	configPth := ""
+	appEnvs := []envmanModels.EnvironmentItemModel{}
+
+	var walkDepth func(models.OptionNode) error
+	walkDepth = func(opt models.OptionNode) error {
+		optionEnvKey, selectedValue, err := askForOptionValue(opt)
+		if err != nil {
+			return fmt.Errorf("failed to ask for value, error: %w", err)
+		}
+
+		if opt.Title == "" {
+			// last option selected, config got
+			configPth = selectedValue
+			return nil
+		} else if optionEnvKey != "" {
+			// env's value selected
+			appEnvs = append(appEnvs, envmanModels.EnvironmentItemModel{
+				optionEnvKey: selectedValue,
+			})
+		}
+
+		var nestedOptions *models.OptionNode
+		if len(opt.ChildOptionMap) == 1 {
+			// auto select the next option
+			nestedOptions = opt.ChildOptionMap[opt.RankedValues()[0]]
+		} else {
+			// go to the next option, based on the selected value
+			childOption, found := opt.ChildOptionMap[selectedValue]
+			if !found {
+				if opt.Type != models.TypeOptionalSelector {
+					return nil
+				}
+				// if user select custom value from the optional list then we need to select the most likely next option
+				childOption = opt.ChildOptionMap[opt.RankedValues()[0]]
+			}
+			nestedOptions = childOption
+		}
+
+		return walkDepth(*nestedOptions)
+	}
+
+	if err := walkDepth(options); err != nil {
+		return "", []envmanModels.EnvironmentItemModel{}, err
+	}
+
+	if configPth == "" {
+		return "", nil, errors.New("no config selected")
+	}
+
+	return configPth, appEnvs, nil
+}
+
+// AskForConfig ...
+func AskForConfig(scanResult models.ScanResultModel) (bitriseModels.BitriseDataModel, error) {
+
+	//
+	// Select platform
+	platforms := []string{}
+	for platform := range scanResult.ScannerToOptionRoot {
+		platforms = append(platforms, platform)
+	}
+	sortScannerNames(platforms)
+
+	platform := ""
+	if len(platforms) == 0 {
+		return bitriseModels.BitriseDataModel{}, errors.New("no platform detected")
+	} else if len(platforms) == 1 {
+		platform = platforms[0]
+	} else {
+		fmt.Println("Select platform:")
+		var err error
+		platform, err = selectOption(platforms)
+		if err != nil {
+			return bitriseModels.BitriseDataModel{}, err
+		}
+	}
+	// ---
+
+	//
+	// Select config
+	options, ok := scanResult.ScannerToOptionRoot[platform]
+	if !ok {
+		return bitriseModels.BitriseDataModel{}, fmt.Errorf("invalid platform selected: %s", platform)
+	}
+
+	configPth, appEnvs, err := AskForOptions(options)
+	if err != nil {
+		return bitriseModels.BitriseDataModel{}, err
+	}
+	// --
+
+	//
+	// Build config
+	configMap := scanResult.ScannerToBitriseConfigMap[platform]
+	configStr := configMap[configPth]
+
+	var config bitriseModels.BitriseDataModel
+	if err := yaml.Unmarshal([]byte(configStr), &config); err != nil {
+		return bitriseModels.BitriseDataModel{}, fmt.Errorf("failed to unmarshal config, error: %w", err)
+	}
+
+	config.App.Environments = append(config.App.Environments, appEnvs...)
+	// ---
+
+	return config, nil
+}
@@ -0,0 +1,34 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_sortScannerNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		want  []string
+	}{
+		{
+			name:  "Scanners in run order",
+			names: []string{"fastlane", "ios", "android", "react-native"},
+			want:  []string{"react-native", "ios", "android", "fastlane"},
+		},
+		{
+			name:  "Unknown scanners last, alphabetically",
+			names: []string{"zeta", "ios", "alpha"},
+			want:  []string{"ios", "alpha", "zeta"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names := append([]string{}, tt.names...)
+			sortScannerNames(names)
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("sortScannerNames() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
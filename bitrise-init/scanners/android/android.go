@@ -0,0 +1,772 @@
+package android
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/bitrise-io/bitrise-init/analytics"
+	"github.com/bitrise-io/bitrise-init/detectors/direntry"
+	"github.com/bitrise-io/bitrise-init/detectors/gradle"
+	"github.com/bitrise-io/bitrise-init/metrics"
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/scanners/java"
+	"github.com/bitrise-io/bitrise-init/steps"
+	bitriseModels "github.com/bitrise-io/bitrise/v2/models"
+	envmanModels "github.com/bitrise-io/envman/v2/models"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+/*
+Relevant Gradle dependencies:
+	plugins:
+		com.android.application
+			This plugin is used to configure and build Android application projects.
+*/
+
+const (
+	ScannerName                   = "android"
+	ConfigName                    = "android-config"
+	ConfigNameKotlinScript        = "android-config-kts"
+	DefaultConfigName             = "default-android-config"
+	DefaultConfigNameKotlinScript = "default-android-config-kts"
+
+	testsWorkflowID         = "run_tests"
+	testsWorkflowSummary    = "Run your Android unit tests and get the test report."
+	testWorkflowDescription = "The workflow will first clone your Git repository, cache your Gradle dependencies, install Android tools, run your Android unit tests and save the test report."
+
+	testPipelineID = "run_tests"
+
+	runInstrumentedTestsWorkflowID          = "run_instrumented_tests"
+	runInstrumentedTestsWorkflowSummary     = "Run your Android instrumented tests and get the test report."
+	runInstrumentedTestsWorkflowDescription = "The workflow will first clone your Git repository, cache your Gradle dependencies, install Android tools, run your Android instrumented tests and save the test report."
+	TestShardCountEnvKey                    = "TEST_SHARD_COUNT"
+	TestShardCountEnvValue                  = 2
+	ParallelTotalEnvKey                     = "BITRISE_IO_PARALLEL_TOTAL"
+	ParallelIndexEnvKey                     = "BITRISE_IO_PARALLEL_INDEX"
+
+	buildWorkflowID          = "build_apk"
+	buildWorkflowSummary     = "Run your Android unit tests and create an APK file to install your app on a device or share it with your team."
+	buildWorkflowDescription = "The workflow will first clone your Git repository, install Android tools, set the project's version code based on the build number, run Android lint and unit tests, build the project's APK file and save it."
+
+	ProjectLocationInputKey     = "project_location"
+	ProjectLocationInputEnvKey  = "PROJECT_LOCATION"
+	ProjectLocationInputTitle   = "The root directory of your Android project"
+	ProjectLocationInputSummary = "The root directory of your Android project where the gradlew or gradlew.bat wrapper is located. This is stored as an Environment Variable (PROJECT_LOCATION) and you can specify paths relative to this path in your Workflows. It can be changed any time."
+
+	ModuleBuildGradlePathInputKey = "build_gradle_path"
+
+	VariantInputKey     = "variant"
+	VariantInputEnvKey  = "VARIANT"
+	VariantInputTitle   = "Variant"
+	VariantInputSummary = "Your Android build variant. You can add variants at any time, as well as further configure your existing variants later."
+
+	ModuleInputKey     = "module"
+	ModuleInputEnvKey  = "MODULE"
+	ModuleInputTitle   = "Module"
+	ModuleInputSummary = "Modules provide a container for your Android project's source code, resource files, and app level settings, such as the module-level build file and Android manifest file. Each module can be independently built, tested, and debugged. You can add new modules to your Bitrise builds at any time."
+
+	BuildScriptInputTitle   = "Does your app use Kotlin build scripts?"
+	BuildScriptInputSummary = "The workflow configuration slightly differs based on what language (Groovy or Kotlin) you used in your build scripts."
+
+	GradlewPathInputKey = "gradlew_path"
+	NDKVersionInputKey  = "ndk_version"
+
+	CacheLevelInputKey = "cache_level"
+	CacheLevelNone     = "none"
+
+	APILevelInputKey = "api_level"
+
+	TestResultsSearchPatternInputKey = "search_pattern"
+	TestResultsBasePathInputKey      = "base_path"
+	TestResultsTestNameInputKey      = "test_name"
+	instrumentedTestResultsName      = "Instrumented tests"
+
+	gradleKotlinBuildFile = "build.gradle.kts"
+)
+
+type GradleModule struct {
+	ModulePath     string
+	BuildScriptPth string
+	UsesKotlinDSL  bool
+	Type           ModuleType
+	// HasInstrumentedTests is set if the module has an androidTest source set
+	HasInstrumentedTests bool
+	TargetSdk            int
+	MinSdk               int
+	CompileSdk           int
+	NdkVersion           string
+	BuildToolsVersion    string
+	// Variants are the statically parsed build variants, empty if they can only be determined by Gradle
+	Variants []Variant
+}
+
+// Scanner ...
+type Scanner struct {
+	Results []DetectResult
+
+	evidences models.Evidences
+}
+
+// NewScanner ...
+func NewScanner() *Scanner {
+	return &Scanner{}
+}
+
+// Name ...
+func (scanner *Scanner) Name() string {
+	return ScannerName
+}
+
+// ExcludedScannerNames ...
+func (scanner *Scanner) ExcludedScannerNames() []string {
+	return []string{java.ProjectType}
+}
+
+type DetectResult struct {
+	GradleProject gradle.Project
+	Modules       []GradleModule
+	Icons         models.Icons
+	Requirements  ToolRequirements
+}
+
+// DetectPlatform ...
+func (scanner *Scanner) DetectPlatform(searchDir string) (_ bool, err error) {
+	log.TInfof("Searching for Gradle project files...")
+
+	rootEntry, err := direntry.WalkDir(searchDir, 6)
+	if err != nil {
+		return false, err
+	}
+
+	gradleWrapperScripts := rootEntry.FindAllEntriesByName("gradlew", false)
+
+	log.TDonef("%d Gradle wrapper script(s) found", len(gradleWrapperScripts))
+	if len(gradleWrapperScripts) == 0 {
+		return false, nil
+	}
+
+	var results []DetectResult
+	for i, gradleWrapperScript := range gradleWrapperScripts {
+		if i > 0 {
+			log.TPrintf("")
+		}
+		log.TInfof("Scanning project with Gradle wrapper script: %s", gradleWrapperScript.AbsPath)
+
+		projectRootDir := gradleWrapperScript.Parent()
+		if projectRootDir == nil {
+			return false, fmt.Errorf("failed to get parent directory of %s", gradleWrapperScript.AbsPath)
+		}
+		gradleProject, err := gradle.ScanProject(*projectRootDir)
+		if err != nil {
+			return false, err
+		}
+		if gradleProject == nil {
+			log.TWarnf("No Gradle project found in %s", projectRootDir.AbsPath)
+			continue
+		}
+
+		printGradleProject(*gradleProject)
+
+		if len(gradleProject.AllBuildScriptFileEntries) == 0 {
+			analytics.LogInfo("android-no-build-scripts-found", nil, "no build script files found")
+			return false, fmt.Errorf("no Gradle build script file found")
+		}
+
+		log.TPrintf("Searching for Android dependencies...")
+		androidDependencies := []string{
+			"com.android.application",
+		}
+		androidDetected, err := gradleProject.DetectAnyDependencies(androidDependencies)
+		if err != nil {
+			return false, err
+		}
+
+		log.TDonef("Android dependencies found: %v", androidDetected)
+		if !androidDetected {
+			log.TDonef("No Android dependencies found, skipping this project")
+			continue
+		}
+
+		scanner.evidences.Add(gradleProject.GradlewFileEntry.RelPath, "Gradle wrapper script")
+		dependencyFiles, err := gradleProject.FindFilesWithAnyDependencies(androidDependencies)
+		if err != nil {
+			return false, err
+		}
+		for _, dependencyFile := range dependencyFiles {
+			scanner.evidences.Add(dependencyFile.RelPath, "com.android.application plugin")
+		}
+
+		result := DetectResult{
+			GradleProject: *gradleProject,
+		}
+
+		if gradleProject.SettingsGradleFileEntry != nil && len(gradleProject.IncludedProjects) == 0 {
+			log.TWarnf("No included projects found in settings.gradle file")
+			remoteLogNoIncludedProjectsFound(gradleProject.SettingsGradleFileEntry.AbsPath)
+		}
+
+		log.TPrintf("Scanning Gradle modules...")
+		var modules []GradleModule
+		if len(gradleProject.IncludedProjects) > 0 {
+			for _, includedProject := range gradleProject.IncludedProjects {
+				modulePath := modulePathFromBuildScriptPath(gradleProject.RootDirEntry.RelPath, includedProject.BuildScriptFileEntry.RelPath)
+				modules = append(modules, GradleModule{
+					ModulePath:     modulePath,
+					BuildScriptPth: includedProject.BuildScriptFileEntry.RelPath,
+					UsesKotlinDSL:  strings.HasSuffix(includedProject.BuildScriptFileEntry.RelPath, ".kts"),
+				})
+			}
+
+			log.TDonef("%d included module(s) found:", len(modules))
+			for _, module := range modules {
+				log.TPrintf("- %s", module.ModulePath)
+			}
+		} else {
+			for _, buildScript := range gradleProject.AllBuildScriptFileEntries {
+				modulePath := modulePathFromBuildScriptPath(gradleProject.RootDirEntry.RelPath, buildScript.RelPath)
+				if modulePath == "" {
+					// Skipp top-level build script file
+					continue
+				}
+				modules = append(modules, GradleModule{
+					ModulePath:     modulePath,
+					BuildScriptPth: buildScript.RelPath,
+					UsesKotlinDSL:  strings.HasSuffix(buildScript.RelPath, ".kts"),
+				})
+			}
+
+			log.TDonef("%d module(s) found:", len(modules))
+			for _, module := range modules {
+				log.TPrintf("- %s", module.ModulePath)
+			}
+		}
+		log.TPrintf("Classifying modules and parsing build variants...")
+		classifier := newModuleTypeClassifier(*gradleProject)
+		for i := range modules {
+			content, err := metrics.ReadStringFromFile(filepath.Join(searchDir, modules[i].BuildScriptPth))
+			if err != nil {
+				log.TWarnf("Failed to read build script %s: %s", modules[i].BuildScriptPth, err)
+				continue
+			}
+			modules[i].Type = classifier.classify(content)
+			modules[i].TargetSdk = parseSdkVersion(content, targetSdkPattern)
+			modules[i].MinSdk = parseSdkVersion(content, minSdkPattern)
+			modules[i].CompileSdk = parseSdkVersion(content, compileSdkPattern)
+			modules[i].NdkVersion = parseStringProperty(content, ndkVersionPattern)
+			modules[i].BuildToolsVersion = parseStringProperty(content, buildToolsVersionPattern)
+			modules[i].HasInstrumentedTests, err = hasInstrumentedTests(filepath.Dir(filepath.Join(searchDir, modules[i].BuildScriptPth)))
+			if err != nil {
+				log.TWarnf("Failed to check instrumented tests of %s: %s", modules[i].ModulePath, err)
+			}
+			modules[i].Variants = detectVariants(modules[i], content, searchDir)
+		}
+		result.Modules = modules
+
+		log.TPrintf("Detecting tool versions...")
+		result.Requirements = detectToolRequirements(*gradleProject, modules)
+		printToolRequirements(result.Requirements)
+
+		log.TPrintf("Searching for project icons...")
+		result.Icons, err = LookupIcons(result.GradleProject.RootDirEntry.AbsPath, searchDir)
+		if err != nil {
+			log.TWarnf("Failed to find icons: %v", err)
+			analytics.LogInfo("android-icon-lookup", analytics.DetectorErrorData("android", err), "Failed to lookup android icon")
+		}
+		log.TDonef("%d icon(s) found", len(result.Icons))
+
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		log.TDonef("No Android projects found")
+		return false, nil
+	}
+
+	scanner.Results = results
+
+	return len(results) > 0, nil
+}
+
+// Evidence ...
+func (scanner *Scanner) Evidence() models.Evidences {
+	evidences := append(models.Evidences{}, scanner.evidences...)
+	for _, result := range scanner.Results {
+		evidences = append(evidences, result.Requirements.evidences...)
+		for _, module := range result.Modules {
+			if module.Type == ModuleTypeUnknown {
+				evidences.Add(module.BuildScriptPth, fmt.Sprintf("Gradle module: %s", module.ModulePath))
+			} else {
+				evidences.Add(module.BuildScriptPth, fmt.Sprintf("Gradle module: %s (%s)", module.ModulePath, module.Type))
+			}
+			if module.HasInstrumentedTests {
+				evidences.Add(module.BuildScriptPth, "androidTest source set")
+			}
+		}
+	}
+	return evidences
+}
+
+// ProjectRoots ...
+func (scanner *Scanner) ProjectRoots() []models.ProjectRoot {
+	var roots []models.ProjectRoot
+	for _, result := range scanner.Results {
+		rootDir := result.GradleProject.RootDirEntry.RelPath
+		roots = append(roots, models.ProjectRoot{Dir: rootDir, OptionValue: rootDir})
+	}
+	return roots
+}
+
+// Options ...
+func (scanner *Scanner) Options() (models.OptionNode, models.Warnings, models.Icons, error) {
+	projectLocationOption := models.NewOption(ProjectLocationInputTitle, ProjectLocationInputSummary, ProjectLocationInputEnvKey, models.TypeSelector)
+	var allIcons models.Icons
+	var warnings models.Warnings
+
+	for _, result := range scanner.Results {
+		warnings = append(warnings, result.GradleProject.WrapperIssues...)
+		warnings = append(warnings, result.Requirements.Warnings...)
+
+		moduleOption := models.NewOption(ModuleInputTitle, ModuleInputSummary, ModuleInputEnvKey, models.TypeUserInput)
+		variantOption := models.NewOption(VariantInputTitle, VariantInputSummary, VariantInputEnvKey, models.TypeOptionalUserInput)
+
+		iconIDs := make([]string, len(result.Icons))
+		for i, icon := range result.Icons {
+			iconIDs[i] = icon.Filename
+		}
+		allIcons = append(allIcons, result.Icons...)
+
+		modules := buildableModules(result.Modules)
+		if len(modules) == 0 {
+			warning := fmt.Sprintf("No module applying the com.android.application plugin found in %s", result.GradleProject.RootDirEntry.RelPath)
+			log.TWarnf("%s", warning)
+			warnings = append(warnings, warning)
+			continue
+		}
+
+		for _, module := range modules {
+			configName := newConfigBuildingParams(result, module).name
+
+			projectLocationOption.AddOption(result.GradleProject.RootDirEntry.RelPath, moduleOption)
+
+			if len(module.Variants) == 0 {
+				moduleOption.AddOption(module.ModulePath, variantOption)
+				variantOption.AddConfig("", models.NewConfigOption(configName, iconIDs))
+				continue
+			}
+
+			moduleVariantOption := models.NewOption(VariantInputTitle, VariantInputSummary, VariantInputEnvKey, models.TypeSelector)
+			moduleOption.AddOption(module.ModulePath, moduleVariantOption)
+			for _, variant := range module.Variants {
+				variantIconIDs := iconIDs
+				if len(variant.Icons) > 0 {
+					variantIconIDs = make([]string, len(variant.Icons))
+					for i, icon := range variant.Icons {
+						variantIconIDs[i] = icon.Filename
+					}
+					allIcons = appendMissingIcons(allIcons, variant.Icons)
+				}
+				moduleVariantOption.AddConfig(variant.Name, models.NewConfigOption(configName, variantIconIDs))
+			}
+		}
+	}
+
+	if len(projectLocationOption.ChildOptionMap) == 0 {
+		return models.OptionNode{}, warnings, nil, fmt.Errorf("no Android application module found")
+	}
+
+	return *projectLocationOption, warnings, allIcons, nil
+}
+
+// detectVariants parses the build variants of the module and looks up the icons of their source sets.
+func detectVariants(module GradleModule, buildScriptContent, searchDir string) []Variant {
+	variants, err := parseVariants(buildScriptContent)
+	if err != nil {
+		log.TPrintf("- %s: variants can not be determined statically: %s", module.ModulePath, err)
+		return nil
+	}
+
+	names := make([]string, len(variants))
+	for i := range variants {
+		names[i] = variants[i].Name
+		variants[i].Icons, err = LookupVariantIcons(filepath.Dir(filepath.Join(searchDir, module.BuildScriptPth)), variants[i], searchDir)
+		if err != nil {
+			log.TWarnf("Failed to find icons of variant %s: %s", variants[i].Name, err)
+		}
+	}
+	log.TPrintf("- %s: %s", module.ModulePath, strings.Join(names, ", "))
+
+	return variants
+}
+
+func appendMissingIcons(icons models.Icons, newIcons models.Icons) models.Icons {
+	for _, newIcon := range newIcons {
+		found := false
+		for _, icon := range icons {
+			if icon.Filename == newIcon.Filename {
+				found = true
+				break
+			}
+		}
+		if !found {
+			icons = append(icons, newIcon)
+		}
+	}
+	return icons
+}
+
+// DefaultOptions ...
+func (scanner *Scanner) DefaultOptions() models.OptionNode {
+	projectLocationOption := models.NewOption(ProjectLocationInputTitle, ProjectLocationInputSummary, ProjectLocationInputEnvKey, models.TypeUserInput)
+	moduleOption := models.NewOption(ModuleInputTitle, ModuleInputSummary, ModuleInputEnvKey, models.TypeUserInput)
+	variantOption := models.NewOption(VariantInputTitle, VariantInputSummary, VariantInputEnvKey, models.TypeOptionalUserInput)
+
+	buildScriptOption := models.NewOption(BuildScriptInputTitle, BuildScriptInputSummary, "", models.TypeSelector)
+	regularConfigOption := models.NewConfigOption(DefaultConfigName, nil)
+	kotlinScriptConfigOption := models.NewConfigOption(DefaultConfigNameKotlinScript, nil)
+
+	projectLocationOption.AddOption(models.UserInputOptionDefaultValue, moduleOption)
+	moduleOption.AddOption(models.UserInputOptionDefaultValue, variantOption)
+	variantOption.AddOption(models.UserInputOptionDefaultValue, buildScriptOption)
+
+	buildScriptOption.AddConfig("yes", kotlinScriptConfigOption)
+	buildScriptOption.AddOption("no", regularConfigOption)
+
+	return *projectLocationOption
+}
+
+type configBuildingParams struct {
+	name                  string
+	useKotlinScript       bool
+	runsInstrumentedTests bool
+	// runsTestModules is set if the project has com.android.test modules, their tests run in the instrumented test workflow
+	runsTestModules  bool
+	emulatorAPILevel int
+	jdkVersion       int
+	ndkVersion       string
+}
+
+func newConfigBuildingParams(result DetectResult, module GradleModule) configBuildingParams {
+	params := configBuildingParams{
+		useKotlinScript:       module.UsesKotlinDSL,
+		runsInstrumentedTests: runsInstrumentedTests(result.Modules),
+		runsTestModules:       hasTestModules(result.Modules),
+		emulatorAPILevel:      instrumentedTestAPILevel(result.Modules),
+		jdkVersion:            result.Requirements.JDKVersion,
+		ndkVersion:            result.Requirements.NdkVersion,
+	}
+
+	params.name = ConfigName
+	if params.useKotlinScript {
+		params.name = ConfigNameKotlinScript
+	}
+	if params.runsInstrumentedTests {
+		params.name += "-instrumented"
+		if params.runsTestModules {
+			params.name += "-test-modules"
+		}
+		if params.emulatorAPILevel > 0 {
+			params.name += fmt.Sprintf("-api%d", params.emulatorAPILevel)
+		}
+	}
+	if params.jdkVersion > 0 {
+		params.name += fmt.Sprintf("-jdk%d", params.jdkVersion)
+	}
+	if params.ndkVersion != "" {
+		params.name += "-ndk" + params.ndkVersion
+	}
+	return params
+}
+
+// Configs ...
+func (scanner *Scanner) Configs(sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	var params []configBuildingParams
+	seen := map[string]bool{}
+	for _, result := range scanner.Results {
+		for _, module := range buildableModules(result.Modules) {
+			param := newConfigBuildingParams(result, module)
+			if seen[param.name] {
+				continue
+			}
+			seen[param.name] = true
+			params = append(params, param)
+		}
+	}
+	return scanner.generateConfigs(sshKeyActivation, params)
+}
+
+// DefaultConfigs ...
+func (scanner *Scanner) DefaultConfigs() (models.BitriseConfigMap, error) {
+	params := []configBuildingParams{
+		{name: DefaultConfigName, useKotlinScript: false, runsInstrumentedTests: true},
+		{name: DefaultConfigNameKotlinScript, useKotlinScript: true, runsInstrumentedTests: true},
+	}
+	return scanner.generateConfigs(models.SSHKeyActivationConditional, params)
+}
+
+func (scanner *Scanner) generateConfigs(sshKeyActivation models.SSHKeyActivation, params []configBuildingParams) (models.BitriseConfigMap, error) {
+	bitriseDataMap := models.BitriseConfigMap{}
+
+	for _, param := range params {
+		configBuilder := scanner.generateConfigBuilder(sshKeyActivation, param)
+
+		var appEnvs []envmanModels.EnvironmentItemModel
+		if param.runsInstrumentedTests {
+			appEnvs = append(appEnvs, envmanModels.EnvironmentItemModel{TestShardCountEnvKey: TestShardCountEnvValue})
+		}
+		config, err := configBuilder.Generate(ScannerName, appEnvs...)
+		if err != nil {
+			return models.BitriseConfigMap{}, err
+		}
+
+		data, err := yaml.Marshal(config)
+		if err != nil {
+			return models.BitriseConfigMap{}, err
+		}
+
+		bitriseDataMap[param.name] = string(data)
+	}
+
+	return bitriseDataMap, nil
+}
+
+func (scanner *Scanner) generateConfigBuilder(sshKeyActivation models.SSHKeyActivation, params configBuildingParams) models.ConfigBuilderModel {
+	configBuilder := models.NewDefaultConfigBuilder()
+
+	projectLocationEnv, gradlewPath, moduleEnv, variantEnv := "$"+ProjectLocationInputEnvKey, "$"+ProjectLocationInputEnvKey+"/gradlew", "$"+ModuleInputEnvKey, "$"+VariantInputEnvKey
+
+	installMissingAndroidToolsInputs := []envmanModels.EnvironmentItemModel{{GradlewPathInputKey: gradlewPath}}
+	if params.ndkVersion != "" {
+		installMissingAndroidToolsInputs = append(installMissingAndroidToolsInputs, envmanModels.EnvironmentItemModel{NDKVersionInputKey: params.ndkVersion})
+	}
+
+	//-- test
+	configBuilder.AppendStepListItemsTo(testsWorkflowID, steps.DefaultPrepareStepList(steps.PrepareListParams{
+		SSHKeyActivation: sshKeyActivation})...)
+	configBuilder.AppendStepListItemsTo(testsWorkflowID, steps.RestoreGradleCache())
+	if params.jdkVersion > 0 {
+		configBuilder.AppendStepListItemsTo(testsWorkflowID, steps.SetJavaVersionStepListItem(strconv.Itoa(params.jdkVersion)))
+	}
+	configBuilder.AppendStepListItemsTo(testsWorkflowID, steps.InstallMissingAndroidToolsStepListItem(installMissingAndroidToolsInputs...))
+	configBuilder.AppendStepListItemsTo(testsWorkflowID, steps.AndroidUnitTestStepListItem(
+		envmanModels.EnvironmentItemModel{
+			ProjectLocationInputKey: projectLocationEnv,
+		},
+		envmanModels.EnvironmentItemModel{
+			VariantInputKey: variantEnv,
+		},
+		envmanModels.EnvironmentItemModel{
+			CacheLevelInputKey: CacheLevelNone,
+		},
+	))
+	configBuilder.AppendStepListItemsTo(testsWorkflowID, steps.SaveGradleCache())
+	configBuilder.AppendStepListItemsTo(testsWorkflowID, steps.DefaultDeployStepList()...)
+	configBuilder.SetWorkflowSummaryTo(testsWorkflowID, testsWorkflowSummary)
+	configBuilder.SetWorkflowDescriptionTo(testsWorkflowID, testWorkflowDescription)
+
+	//-- instrumented test
+	if params.runsInstrumentedTests {
+		configBuilder.AppendStepListItemsTo(runInstrumentedTestsWorkflowID, steps.DefaultPrepareStepList(steps.PrepareListParams{
+			SSHKeyActivation: sshKeyActivation,
+		})...)
+		configBuilder.AppendStepListItemsTo(runInstrumentedTestsWorkflowID, steps.RestoreGradleCache())
+		if params.jdkVersion > 0 {
+			configBuilder.AppendStepListItemsTo(runInstrumentedTestsWorkflowID, steps.SetJavaVersionStepListItem(strconv.Itoa(params.jdkVersion)))
+		}
+		configBuilder.AppendStepListItemsTo(runInstrumentedTestsWorkflowID, steps.InstallMissingAndroidToolsStepListItem(installMissingAndroidToolsInputs...))
+		var avdManagerInputs []envmanModels.EnvironmentItemModel
+		if params.emulatorAPILevel > 0 {
+			avdManagerInputs = append(avdManagerInputs, envmanModels.EnvironmentItemModel{APILevelInputKey: strconv.Itoa(params.emulatorAPILevel)})
+		}
+		configBuilder.AppendStepListItemsTo(runInstrumentedTestsWorkflowID, steps.AvdManagerStepListItem(avdManagerInputs...))
+		configBuilder.AppendStepListItemsTo(runInstrumentedTestsWorkflowID, steps.WaitForAndroidEmulatorStepListItem())
+		// connectedCheck runs the tests of the com.android.test modules too, besides the androidTest source sets of the other modules
+		instrumentedTestTask := "connectedAndroidTest"
+		if params.runsTestModules {
+			instrumentedTestTask = "connectedCheck"
+		}
+		configBuilder.AppendStepListItemsTo(runInstrumentedTestsWorkflowID, steps.GradleRunnerStepListItem(
+			projectLocationEnv,
+			fmt.Sprintf("%s \\\n  -Pandroid.testInstrumentationRunnerArguments.numShards=$%s \\\n  -Pandroid.testInstrumentationRunnerArguments.shardIndex=$%s",
+				instrumentedTestTask,
+				ParallelTotalEnvKey,
+				ParallelIndexEnvKey,
+			),
+		))
+		configBuilder.AppendStepListItemsTo(runInstrumentedTestsWorkflowID, steps.CustomTestResultsExportStepListItem(
+			envmanModels.EnvironmentItemModel{TestResultsSearchPatternInputKey: instrumentedTestResultsDir},
+			envmanModels.EnvironmentItemModel{TestResultsBasePathInputKey: projectLocationEnv},
+			envmanModels.EnvironmentItemModel{TestResultsTestNameInputKey: instrumentedTestResultsName},
+		))
+		configBuilder.AppendStepListItemsTo(runInstrumentedTestsWorkflowID, steps.SaveGradleCache())
+		configBuilder.AppendStepListItemsTo(runInstrumentedTestsWorkflowID, steps.DefaultDeployStepList()...)
+		configBuilder.SetWorkflowSummaryTo(runInstrumentedTestsWorkflowID, runInstrumentedTestsWorkflowSummary)
+		configBuilder.SetWorkflowDescriptionTo(runInstrumentedTestsWorkflowID, runInstrumentedTestsWorkflowDescription)
+
+		configBuilder.SetGraphPipelineWorkflowTo(testPipelineID, runInstrumentedTestsWorkflowID, bitriseModels.GraphPipelineWorkflowModel{
+			Parallel: "$" + TestShardCountEnvKey,
+		})
+	}
+
+	//-- build
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.DefaultPrepareStepList(steps.PrepareListParams{
+		SSHKeyActivation: sshKeyActivation,
+	})...)
+	if params.jdkVersion > 0 {
+		configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.SetJavaVersionStepListItem(strconv.Itoa(params.jdkVersion)))
+	}
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.InstallMissingAndroidToolsStepListItem(installMissingAndroidToolsInputs...))
+
+	basePath := filepath.Join(projectLocationEnv, moduleEnv)
+	path := filepath.Join(basePath, "build.gradle")
+	if params.useKotlinScript {
+		path = filepath.Join(basePath, gradleKotlinBuildFile)
+	}
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.ChangeAndroidVersionCodeAndVersionNameStepListItem(
+		envmanModels.EnvironmentItemModel{ModuleBuildGradlePathInputKey: path},
+	))
+
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.AndroidLintStepListItem(
+		envmanModels.EnvironmentItemModel{
+			ProjectLocationInputKey: projectLocationEnv,
+		},
+		envmanModels.EnvironmentItemModel{
+			VariantInputKey: variantEnv,
+		},
+		envmanModels.EnvironmentItemModel{
+			CacheLevelInputKey: CacheLevelNone,
+		},
+	))
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.AndroidUnitTestStepListItem(
+		envmanModels.EnvironmentItemModel{
+			ProjectLocationInputKey: projectLocationEnv,
+		},
+		envmanModels.EnvironmentItemModel{
+			VariantInputKey: variantEnv,
+		},
+		envmanModels.EnvironmentItemModel{
+			CacheLevelInputKey: CacheLevelNone,
+		},
+	))
+
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.AndroidBuildStepListItem(
+		envmanModels.EnvironmentItemModel{
+			ProjectLocationInputKey: projectLocationEnv,
+		},
+		envmanModels.EnvironmentItemModel{
+			ModuleInputKey: moduleEnv,
+		},
+		envmanModels.EnvironmentItemModel{
+			VariantInputKey: variantEnv,
+		},
+		envmanModels.EnvironmentItemModel{
+			CacheLevelInputKey: CacheLevelNone,
+		},
+	))
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.SignAPKStepListItem())
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.DefaultDeployStepList()...)
+
+	configBuilder.SetWorkflowDescriptionTo(buildWorkflowID, buildWorkflowDescription)
+	configBuilder.SetWorkflowSummaryTo(buildWorkflowID, buildWorkflowSummary)
+
+	return *configBuilder
+}
+
+func printToolRequirements(requirements ToolRequirements) {
+	if requirements.AGPVersion != "" {
+		log.TPrintf("Android Gradle plugin: %s", requirements.AGPVersion)
+	}
+	if requirements.GradleVersion != "" {
+		log.TPrintf("Gradle wrapper: %s", requirements.GradleVersion)
+	}
+	if requirements.CompileSdk > 0 {
+		log.TPrintf("compileSdk: %d, targetSdk: %d", requirements.CompileSdk, requirements.TargetSdk)
+	}
+	if requirements.NdkVersion != "" {
+		log.TPrintf("NDK: %s", requirements.NdkVersion)
+	}
+	if requirements.BuildToolsVersion != "" {
+		log.TPrintf("Build tools: %s", requirements.BuildToolsVersion)
+	}
+	if requirements.JDKVersion > 0 {
+		log.TDonef("JDK: %d", requirements.JDKVersion)
+	}
+	for _, warning := range requirements.Warnings {
+		log.TWarnf("%s", warning)
+	}
+}
+
+func printGradleProject(gradleProject gradle.Project) {
+	log.TPrintf("Project root dir: %s", gradleProject.RootDirEntry.RelPath)
+	log.TPrintf("Gradle wrapper script: %s", gradleProject.GradlewFileEntry.RelPath)
+	if gradleProject.ConfigDirEntry != nil {
+		log.TPrintf("Gradle config dir: %s", gradleProject.ConfigDirEntry.RelPath)
+	}
+	if gradleProject.VersionCatalogFileEntry != nil {
+		log.TPrintf("Version catalog file: %s", gradleProject.VersionCatalogFileEntry.RelPath)
+	}
+	if gradleProject.SettingsGradleFileEntry != nil {
+		log.TPrintf("Gradle settings file: %s", gradleProject.SettingsGradleFileEntry.RelPath)
+	}
+	if gradleProject.WrapperPropertiesFileEntry != nil {
+		log.TPrintf("Gradle wrapper properties file: %s", gradleProject.WrapperPropertiesFileEntry.RelPath)
+	}
+	if len(gradleProject.IncludedProjects) > 0 {
+		log.TPrintf("Included projects:")
+		for _, includedProject := range gradleProject.IncludedProjects {
+			log.TPrintf("- %s: %s", includedProject.Name, includedProject.BuildScriptFileEntry.RelPath)
+		}
+	}
+}
+
+func modulePathFromBuildScriptPath(projectRootDir, buildScriptPth string) string {
+	relBuildScriptPath := strings.TrimPrefix(buildScriptPth, projectRootDir)
+	relBuildScriptPath = strings.TrimPrefix(relBuildScriptPath, "/")
+	pathComponents := strings.Split(relBuildScriptPath, "/")
+	if len(pathComponents) < 2 {
+		return ""
+	}
+
+	return strings.Join(pathComponents[:len(pathComponents)-1], "/")
+}
+
+func remoteLogNoIncludedProjectsFound(settingGradlePth string) {
+	file, err := os.Open(settingGradlePth)
+	if err != nil {
+		analytics.LogInfo("android-no-included-projects", map[string]interface{}{
+			"error": err.Error(),
+		}, "Failed to open settings.gradle file")
+		return
+	}
+	metrics.FileRead()
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.TWarnf("Unable to close file %s: %s", settingGradlePth, err)
+		}
+	}()
+
+	var includeLines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "include") {
+			includeLines = append(includeLines, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		analytics.LogInfo("android-no-included-projects", map[string]interface{}{
+			"error": err.Error(),
+		}, "Failed to read settings.gradle file")
+		return
+	}
+
+	analytics.LogInfo("android-no-included-projects", map[string]interface{}{
+		"include_lines": strings.Join(includeLines, "\n"),
+	}, "settings.gradle file exists, but no included projects found")
+}
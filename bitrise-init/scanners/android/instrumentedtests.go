@@ -0,0 +1,82 @@
+package android
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+)
+
+/*
+Instrumented tests run on a device or emulator, from the androidTest source set of the module (src/androidTest).
+The emulator's API level is based on the SDK versions of the module:
+	android {
+		defaultConfig {
+			minSdk = 24
+			targetSdk = 34
+		}
+	}
+*/
+
+const (
+	androidTestSourceSet = "androidTest"
+	// instrumentedTestResultsDir matches the results of the modules at any depth, like feature/login/build/outputs/...
+	instrumentedTestResultsDir = "**/build/outputs/androidTest-results/**/*.xml"
+)
+
+// sdkVersionPattern returns the pattern matching the SDK version property (like targetSdk = 34 or targetSdkVersion 34) with a literal value.
+func sdkVersionPattern(property string) *regexp.Regexp {
+	return regexp.MustCompile(`\b` + property + `(?:Version)?\b\s*(?:=|\()?\s*(\d+)\b`)
+}
+
+var (
+	targetSdkPattern = sdkVersionPattern("targetSdk")
+	minSdkPattern    = sdkVersionPattern("minSdk")
+)
+
+// parseSdkVersion returns the SDK version set by the property, 0 if it is not set by a literal.
+func parseSdkVersion(buildScriptContent string, pattern *regexp.Regexp) int {
+	match := pattern.FindStringSubmatch(stripComments(buildScriptContent))
+	if match == nil {
+		return 0
+	}
+	version, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
+	}
+	return version
+}
+
+// hasInstrumentedTests checks whether the module has an androidTest source set.
+// The instrumentation runner is not required in the build script, as convention plugins and the Android Gradle plugin's default can set it.
+func hasInstrumentedTests(moduleDir string) (bool, error) {
+	return pathutil.IsDirExists(filepath.Join(moduleDir, "src", androidTestSourceSet))
+}
+
+// runsInstrumentedTests checks whether any module has instrumented tests, com.android.test modules consist of instrumented tests only.
+func runsInstrumentedTests(modules []GradleModule) bool {
+	for _, module := range modules {
+		if module.HasInstrumentedTests || module.Type == ModuleTypeTest {
+			return true
+		}
+	}
+	return false
+}
+
+// instrumentedTestAPILevel returns the emulator API level for the instrumented tests: the highest targetSdk of the tested modules,
+// or their highest minSdk if no targetSdk is set. 0 means the emulator's default API level.
+func instrumentedTestAPILevel(modules []GradleModule) int {
+	var targetSdk, minSdk int
+	for _, module := range modules {
+		if !module.HasInstrumentedTests && module.Type != ModuleTypeTest {
+			continue
+		}
+		targetSdk = max(targetSdk, module.TargetSdk)
+		minSdk = max(minSdk, module.MinSdk)
+	}
+	if targetSdk > 0 {
+		return targetSdk
+	}
+	return minSdk
+}
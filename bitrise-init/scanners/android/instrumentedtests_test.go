@@ -0,0 +1,133 @@
+package android
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_hasInstrumentedTests(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  bool
+	}{
+		{
+			name: "androidTest source set",
+			files: map[string]string{
+				"app/build.gradle.kts":                            `plugins { id("nowinandroid.android.application") }`,
+				"app/src/androidTest/java/com/example/AppTest.kt": "",
+			},
+			want: true,
+		},
+		{
+			name: "unit tests only",
+			files: map[string]string{
+				"app/build.gradle.kts":                     `android { defaultConfig { testInstrumentationRunner = "androidx.test.runner.AndroidJUnitRunner" } }`,
+				"app/src/test/java/com/example/AppTest.kt": "",
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			got, err := hasInstrumentedTests(filepath.Join(dir, "app"))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseSdkVersion(t *testing.T) {
+	tests := []struct {
+		name               string
+		buildScriptContent string
+		pattern            string
+		want               int
+	}{
+		{
+			name:               "kotlin dsl",
+			buildScriptContent: `defaultConfig { targetSdk = 34 }`,
+			pattern:            "targetSdk",
+			want:               34,
+		},
+		{
+			name:               "groovy dsl with the legacy property",
+			buildScriptContent: `defaultConfig { minSdkVersion 21 }`,
+			pattern:            "minSdk",
+			want:               21,
+		},
+		{
+			name:               "method call",
+			buildScriptContent: `defaultConfig { targetSdkVersion(33) }`,
+			pattern:            "targetSdk",
+			want:               33,
+		},
+		{
+			name:               "version catalog reference",
+			buildScriptContent: `defaultConfig { targetSdk = libs.versions.targetSdk.get().toInt() }`,
+			pattern:            "targetSdk",
+			want:               0,
+		},
+		{
+			name:               "commented out",
+			buildScriptContent: `defaultConfig { // minSdk = 21 }`,
+			pattern:            "minSdk",
+			want:               0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, parseSdkVersion(tt.buildScriptContent, sdkVersionPattern(tt.pattern)))
+		})
+	}
+}
+
+func Test_instrumentedTestAPILevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		modules []GradleModule
+		want    int
+	}{
+		{
+			name: "highest targetSdk of the tested modules",
+			modules: []GradleModule{
+				{HasInstrumentedTests: true, TargetSdk: 33, MinSdk: 24},
+				{Type: ModuleTypeTest, TargetSdk: 34},
+				{TargetSdk: 35},
+			},
+			want: 34,
+		},
+		{
+			name: "minSdk without targetSdk",
+			modules: []GradleModule{
+				{HasInstrumentedTests: true, MinSdk: 26},
+			},
+			want: 26,
+		},
+		{
+			name:    "no tested modules",
+			modules: []GradleModule{{TargetSdk: 34}},
+			want:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, instrumentedTestAPILevel(tt.modules))
+			require.Equal(t, tt.want != 0, runsInstrumentedTests(tt.modules))
+		})
+	}
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		pth := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+		require.NoError(t, os.WriteFile(pth, []byte(content), 0644))
+	}
+}
@@ -0,0 +1,93 @@
+package android
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/detectors/gradle"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+// ModuleType is the kind of Android module, based on the Android Gradle plugin applied by its build script.
+type ModuleType string
+
+const (
+	ModuleTypeApplication    ModuleType = "application"
+	ModuleTypeLibrary        ModuleType = "library"
+	ModuleTypeDynamicFeature ModuleType = "dynamic-feature"
+	ModuleTypeTest           ModuleType = "test"
+	// ModuleTypeUnknown modules apply no Android plugin directly, like modules configured by convention plugins
+	ModuleTypeUnknown ModuleType = ""
+)
+
+var androidPlugins = []struct {
+	id         string
+	moduleType ModuleType
+}{
+	{id: "com.android.application", moduleType: ModuleTypeApplication},
+	{id: "com.android.library", moduleType: ModuleTypeLibrary},
+	{id: "com.android.dynamic-feature", moduleType: ModuleTypeDynamicFeature},
+	{id: "com.android.test", moduleType: ModuleTypeTest},
+}
+
+// pluginReferencePatterns returns the patterns matching the plugin in the build scripts:
+// plugins { id("com.android.application") }, apply plugin: 'com.android.application' or alias(libs.plugins.android.application).
+func pluginReferencePatterns(pluginID, versionCatalogAlias string) []*regexp.Regexp {
+	patterns := []*regexp.Regexp{regexp.MustCompile(`["']` + regexp.QuoteMeta(pluginID) + `["']`)}
+	if versionCatalogAlias != "" {
+		// Gradle maps the dashes and underscores of the catalog aliases to dots in the type-safe accessors
+		accessor := strings.NewReplacer("-", ".", "_", ".").Replace(versionCatalogAlias)
+		patterns = append(patterns, regexp.MustCompile(`\balias\s*\(\s*libs\.plugins\.`+regexp.QuoteMeta(accessor)+`\s*\)`))
+	}
+	return patterns
+}
+
+// moduleTypeClassifier classifies the modules of a Gradle project by the Android plugins.
+type moduleTypeClassifier struct {
+	patterns map[ModuleType][]*regexp.Regexp
+}
+
+func newModuleTypeClassifier(project gradle.Project) moduleTypeClassifier {
+	classifier := moduleTypeClassifier{patterns: map[ModuleType][]*regexp.Regexp{}}
+	for _, plugin := range androidPlugins {
+		alias, err := project.GetPluginAliasFromVersionCatalog(plugin.id)
+		if err != nil {
+			log.TWarnf("Failed to look up %s in the version catalog: %s", plugin.id, err)
+		}
+		classifier.patterns[plugin.moduleType] = pluginReferencePatterns(plugin.id, alias)
+	}
+	return classifier
+}
+
+func (c moduleTypeClassifier) classify(buildScriptContent string) ModuleType {
+	content := stripComments(buildScriptContent)
+	for _, plugin := range androidPlugins {
+		for _, pattern := range c.patterns[plugin.moduleType] {
+			if pattern.MatchString(content) {
+				return plugin.moduleType
+			}
+		}
+	}
+	return ModuleTypeUnknown
+}
+
+// buildableModules returns the modules applying the application plugin directly.
+// Modules of unknown type are not built, even if no application module is found.
+func buildableModules(modules []GradleModule) []GradleModule {
+	var applications []GradleModule
+	for _, module := range modules {
+		if module.Type == ModuleTypeApplication {
+			applications = append(applications, module)
+		}
+	}
+	return applications
+}
+
+func hasTestModules(modules []GradleModule) bool {
+	for _, module := range modules {
+		if module.Type == ModuleTypeTest {
+			return true
+		}
+	}
+	return false
+}
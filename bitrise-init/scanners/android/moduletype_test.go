@@ -0,0 +1,105 @@
+package android
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_moduleTypeClassifier_classify(t *testing.T) {
+	classifier := moduleTypeClassifier{patterns: map[ModuleType][]*regexp.Regexp{}}
+	for _, plugin := range androidPlugins {
+		alias := ""
+		if plugin.moduleType == ModuleTypeApplication {
+			alias = "android-application"
+		}
+		classifier.patterns[plugin.moduleType] = pluginReferencePatterns(plugin.id, alias)
+	}
+
+	tests := []struct {
+		name               string
+		buildScriptContent string
+		want               ModuleType
+	}{
+		{
+			name:               "plugins block",
+			buildScriptContent: `plugins { id("com.android.application") }`,
+			want:               ModuleTypeApplication,
+		},
+		{
+			name:               "apply plugin",
+			buildScriptContent: `apply plugin: 'com.android.library'`,
+			want:               ModuleTypeLibrary,
+		},
+		{
+			name:               "version catalog alias",
+			buildScriptContent: `plugins { alias(libs.plugins.android.application) }`,
+			want:               ModuleTypeApplication,
+		},
+		{
+			name:               "dynamic feature",
+			buildScriptContent: `plugins { id 'com.android.dynamic-feature' }`,
+			want:               ModuleTypeDynamicFeature,
+		},
+		{
+			name:               "test module",
+			buildScriptContent: `plugins { id("com.android.test") }`,
+			want:               ModuleTypeTest,
+		},
+		{
+			name: "commented out plugin",
+			buildScriptContent: `plugins {
+    // id("com.android.application")
+    id("com.android.library")
+}`,
+			want: ModuleTypeLibrary,
+		},
+		{
+			name:               "convention plugin",
+			buildScriptContent: `plugins { id("nowinandroid.android.application") }`,
+			want:               ModuleTypeUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, classifier.classify(tt.buildScriptContent))
+		})
+	}
+}
+
+func Test_buildableModules(t *testing.T) {
+	tests := []struct {
+		name    string
+		modules []GradleModule
+		want    []string
+	}{
+		{
+			name: "application modules",
+			modules: []GradleModule{
+				{ModulePath: "app", Type: ModuleTypeApplication},
+				{ModulePath: "core", Type: ModuleTypeLibrary},
+				{ModulePath: "wear", Type: ModuleTypeApplication},
+				{ModulePath: "build-logic", Type: ModuleTypeUnknown},
+			},
+			want: []string{"app", "wear"},
+		},
+		{
+			name: "no application module",
+			modules: []GradleModule{
+				{ModulePath: "app", Type: ModuleTypeUnknown},
+				{ModulePath: "core", Type: ModuleTypeLibrary},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, module := range buildableModules(tt.modules) {
+				got = append(got, module.ModulePath)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
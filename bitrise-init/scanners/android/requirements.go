@@ -0,0 +1,319 @@
+package android
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/detectors/direntry"
+	"github.com/bitrise-io/bitrise-init/detectors/gradle"
+	"github.com/bitrise-io/bitrise-init/metrics"
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+/*
+The JDK running Gradle is determined by the Android Gradle plugin (AGP), the Gradle wrapper and the Android SDK packages:
+	- AGP 8 requires JDK 17, AGP 7 requires JDK 11
+	- Gradle 9 requires JDK 17, and every Gradle version supports running on JDKs up to a maximum version
+	- compileSdk 30 and newer and build tools 31 and newer require JDK 11
+The Java toolchain of the build scripts only selects the JDK compiling the sources, it doesn't affect the JDK running Gradle.
+*/
+
+const (
+	androidGradlePluginID       = "com.android.application"
+	androidGradlePluginArtifact = "com.android.tools.build:gradle"
+)
+
+var (
+	compileSdkPattern        = sdkVersionPattern("compileSdk")
+	ndkVersionPattern        = stringPropertyPattern("ndkVersion")
+	buildToolsVersionPattern = stringPropertyPattern("buildToolsVersion")
+)
+
+// stringPropertyPattern returns the pattern matching the property set to a string literal, like ndkVersion = "26.1.10909125" or ndkVersion "26.1.10909125".
+func stringPropertyPattern(property string) *regexp.Regexp {
+	return regexp.MustCompile(`\b` + property + `\b\s*(?:=|\()?\s*["']([\w.+-]+)["']`)
+}
+
+// parseStringProperty returns the value of the property, empty if it is not set by a literal.
+func parseStringProperty(buildScriptContent string, pattern *regexp.Regexp) string {
+	match := pattern.FindStringSubmatch(stripComments(buildScriptContent))
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// agpMinimumGradleVersions are the minimum Gradle versions of the AGP releases, newest first.
+var agpMinimumGradleVersions = []struct {
+	agp    string
+	gradle string
+}{
+	{agp: "9.0", gradle: "9.0"},
+	{agp: "8.11", gradle: "8.13"},
+	{agp: "8.10", gradle: "8.11.1"},
+	{agp: "8.8", gradle: "8.10.2"},
+	{agp: "8.7", gradle: "8.9"},
+	{agp: "8.5", gradle: "8.7"},
+	{agp: "8.4", gradle: "8.6"},
+	{agp: "8.3", gradle: "8.4"},
+	{agp: "8.2", gradle: "8.2"},
+	{agp: "8.0", gradle: "8.0"},
+	{agp: "7.4", gradle: "7.5"},
+	{agp: "7.3", gradle: "7.4"},
+	{agp: "7.2", gradle: "7.3.3"},
+	{agp: "7.1", gradle: "7.2"},
+	{agp: "7.0", gradle: "7.0"},
+}
+
+// gradleMaximumJDKVersions are the newest JDKs the Gradle releases can run on, newest first.
+var gradleMaximumJDKVersions = []struct {
+	gradle string
+	jdk    int
+}{
+	{gradle: "9.1", jdk: 25},
+	{gradle: "8.14", jdk: 24},
+	{gradle: "8.10", jdk: 23},
+	{gradle: "8.8", jdk: 22},
+	{gradle: "8.5", jdk: 21},
+	{gradle: "8.3", jdk: 20},
+	{gradle: "7.6", jdk: 19},
+	{gradle: "7.5", jdk: 18},
+	{gradle: "7.3", jdk: 17},
+	{gradle: "7.0", jdk: 16},
+	{gradle: "6.7", jdk: 15},
+	{gradle: "6.3", jdk: 14},
+	{gradle: "6.0", jdk: 13},
+	{gradle: "5.4", jdk: 12},
+	{gradle: "5.0", jdk: 11},
+}
+
+// ToolRequirements are the Android SDK packages, Gradle and JDK versions the project is built with.
+type ToolRequirements struct {
+	AGPVersion        string
+	GradleVersion     string
+	CompileSdk        int
+	TargetSdk         int
+	NdkVersion        string
+	BuildToolsVersion string
+	// JDKVersion is the major version of the JDK running Gradle, 0 if it can not be determined
+	JDKVersion int
+	// Warnings are the incompatible version combinations
+	Warnings models.Warnings
+
+	evidences models.Evidences
+}
+
+// detectToolRequirements collects the versions of the project and derives the JDK running Gradle.
+func detectToolRequirements(project gradle.Project, modules []GradleModule) ToolRequirements {
+	var requirements ToolRequirements
+
+	agpVersion, agpFile, err := project.DetectPluginVersion(androidGradlePluginID, androidGradlePluginArtifact)
+	if err != nil {
+		log.TWarnf("Failed to detect the Android Gradle plugin version: %s", err)
+	} else if agpVersion != "" {
+		requirements.AGPVersion = agpVersion
+		requirements.evidences.Add(agpFile.RelPath, fmt.Sprintf("Android Gradle plugin %s", agpVersion))
+	}
+
+	distribution, err := project.DetectWrapperDistribution()
+	if err != nil {
+		log.TWarnf("Failed to detect the Gradle wrapper version: %s", err)
+	} else if distribution != nil {
+		requirements.GradleVersion = distribution.Version
+		requirements.evidences.Add(project.WrapperPropertiesFileEntry.RelPath, fmt.Sprintf("Gradle %s", distribution.Version))
+	}
+
+	for _, module := range modules {
+		requirements.CompileSdk = max(requirements.CompileSdk, module.CompileSdk)
+		requirements.TargetSdk = max(requirements.TargetSdk, module.TargetSdk)
+		if compareVersions(module.NdkVersion, requirements.NdkVersion) > 0 {
+			requirements.NdkVersion = module.NdkVersion
+		}
+		if compareVersions(module.BuildToolsVersion, requirements.BuildToolsVersion) > 0 {
+			requirements.BuildToolsVersion = module.BuildToolsVersion
+		}
+	}
+
+	requiredJDK, requiredBy := requirements.requiredJDKVersion()
+	maximumJDK := gradleMaximumJDKVersion(requirements.GradleVersion)
+	requirements.JDKVersion = requiredJDK
+
+	declaredJDK, declaredJDKFile := detectDeclaredJDKVersion(project.RootDirEntry)
+	if declaredJDK > 0 {
+		requirements.evidences.Add(declaredJDKFile.RelPath, fmt.Sprintf("JDK %d", declaredJDK))
+		switch {
+		case declaredJDK < requiredJDK:
+			requirements.Warnings = append(requirements.Warnings, fmt.Sprintf("%s requires JDK %d, but %s sets JDK %d",
+				requiredBy, requiredJDK, declaredJDKFile.RelPath, declaredJDK))
+		case maximumJDK == 0 || declaredJDK <= maximumJDK:
+			requirements.JDKVersion = declaredJDK
+		}
+	}
+
+	if minimumGradle := agpMinimumGradleVersion(requirements.AGPVersion); minimumGradle != "" && requirements.GradleVersion != "" &&
+		compareVersions(requirements.GradleVersion, minimumGradle) < 0 {
+		requirements.Warnings = append(requirements.Warnings, fmt.Sprintf("Android Gradle plugin %s requires Gradle %s or newer, but the Gradle wrapper uses Gradle %s",
+			requirements.AGPVersion, minimumGradle, requirements.GradleVersion))
+	}
+	if maximumJDK > 0 && requirements.JDKVersion > maximumJDK {
+		requirements.Warnings = append(requirements.Warnings, fmt.Sprintf("Gradle %s does not support running on JDK %d",
+			requirements.GradleVersion, requirements.JDKVersion))
+	}
+
+	return requirements
+}
+
+// requiredJDKVersion returns the minimum JDK version of AGP, Gradle and the Android SDK packages, and the tool requiring it.
+// It returns 0 if none of the versions is known.
+func (requirements ToolRequirements) requiredJDKVersion() (int, string) {
+	jdk, requiredBy := 0, ""
+	require := func(version int, tool string) {
+		if version > jdk {
+			jdk, requiredBy = version, tool
+		}
+	}
+
+	if requirements.AGPVersion != "" {
+		tool := fmt.Sprintf("Android Gradle plugin %s", requirements.AGPVersion)
+		switch major := majorVersion(requirements.AGPVersion); {
+		case major >= 8:
+			require(17, tool)
+		case major == 7:
+			require(11, tool)
+		default:
+			require(8, tool)
+		}
+	}
+	if majorVersion(requirements.GradleVersion) >= 9 {
+		require(17, fmt.Sprintf("Gradle %s", requirements.GradleVersion))
+	}
+	if requirements.CompileSdk >= 30 {
+		require(11, fmt.Sprintf("compileSdk %d", requirements.CompileSdk))
+	}
+	if majorVersion(requirements.BuildToolsVersion) >= 31 {
+		require(11, fmt.Sprintf("Build tools %s", requirements.BuildToolsVersion))
+	}
+	return jdk, requiredBy
+}
+
+func agpMinimumGradleVersion(agpVersion string) string {
+	if agpVersion == "" {
+		return ""
+	}
+	for _, release := range agpMinimumGradleVersions {
+		if compareVersions(agpVersion, release.agp) >= 0 {
+			return release.gradle
+		}
+	}
+	return ""
+}
+
+// gradleMaximumJDKVersion returns the newest JDK the Gradle version can run on, 0 if it is not known.
+func gradleMaximumJDKVersion(gradleVersion string) int {
+	if gradleVersion == "" {
+		return 0
+	}
+	for _, release := range gradleMaximumJDKVersions {
+		if compareVersions(gradleVersion, release.gradle) >= 0 {
+			return release.jdk
+		}
+	}
+	return 0
+}
+
+// jdkVersionPattern matches the version segment of a JDK identifier, like 17 in temurin-17.0.9+9 or 8 in 1.8.0_392.
+// The segment starts the value or follows a separator, so digits of the distribution name (like openjdk64) are not matched.
+var jdkVersionPattern = regexp.MustCompile(`(?:^|[-_@=\s])(?:1\.)?(\d+)(?:[._+-]|$)`)
+
+// oldestJDKVersion and newestJDKVersion are the range of the released JDK major versions, JDK 1.8 is referred to as 8.
+const (
+	oldestJDKVersion = 8
+	newestJDKVersion = 27
+)
+
+// detectDeclaredJDKVersion returns the JDK major version set by the version manager files in the project root:
+// .java-version (jenv) like 17, .tool-versions (asdf) like java temurin-17.0.9+9 and .sdkmanrc (SDKMAN!) like java=17.0.9-tem.
+func detectDeclaredJDKVersion(rootDirEntry direntry.DirEntry) (int, *direntry.DirEntry) {
+	for _, candidate := range []struct {
+		name   string
+		prefix string
+	}{
+		{name: ".java-version"},
+		{name: ".tool-versions", prefix: "java "},
+		{name: ".sdkmanrc", prefix: "java="},
+	} {
+		entry := rootDirEntry.FindImmediateChildByName(candidate.name, false)
+		if entry == nil {
+			continue
+		}
+		content, err := metrics.ReadStringFromFile(entry.AbsPath)
+		if err != nil {
+			log.TWarnf("Failed to read %s: %s", entry.RelPath, err)
+			continue
+		}
+		for _, line := range strings.Split(content, "\n") {
+			line = strings.TrimSpace(line)
+			value, found := strings.CutPrefix(line, candidate.prefix)
+			if !found || value == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			for _, match := range jdkVersionPattern.FindAllStringSubmatch(value, -1) {
+				version, err := strconv.Atoi(match[1])
+				if err == nil && version >= oldestJDKVersion && version <= newestJDKVersion {
+					return version, entry
+				}
+			}
+		}
+	}
+	return 0, nil
+}
+
+// majorVersion returns the major component of the version, 0 if it is not a number.
+func majorVersion(version string) int {
+	components := versionComponents(version)
+	if len(components) == 0 {
+		return 0
+	}
+	return components[0]
+}
+
+// versionComponents returns the leading numeric components of the version, like [8, 2, 0] for 8.2.0-alpha01.
+func versionComponents(version string) []int {
+	var components []int
+	for _, part := range strings.Split(version, ".") {
+		digits := part
+		if end := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' }); end != -1 {
+			digits = part[:end]
+		}
+		number, err := strconv.Atoi(digits)
+		if err != nil {
+			break
+		}
+		components = append(components, number)
+		if digits != part {
+			break
+		}
+	}
+	return components
+}
+
+// compareVersions compares the numeric components of the versions, the missing components count as 0.
+func compareVersions(a, b string) int {
+	componentsA, componentsB := versionComponents(a), versionComponents(b)
+	for i := 0; i < max(len(componentsA), len(componentsB)); i++ {
+		var componentA, componentB int
+		if i < len(componentsA) {
+			componentA = componentsA[i]
+		}
+		if i < len(componentsB) {
+			componentB = componentsB[i]
+		}
+		if componentA != componentB {
+			return componentA - componentB
+		}
+	}
+	return 0
+}
@@ -0,0 +1,133 @@
+package android
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/bitrise-init/detectors/direntry"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_detectDeclaredJDKVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		want     int
+		wantFile string
+	}{
+		{
+			name:     "jenv",
+			files:    map[string]string{".java-version": "17\n"},
+			want:     17,
+			wantFile: ".java-version",
+		},
+		{
+			name:     "jenv with legacy version",
+			files:    map[string]string{".java-version": "1.8.0_392"},
+			want:     8,
+			wantFile: ".java-version",
+		},
+		{
+			name:     "jenv with distribution name",
+			files:    map[string]string{".java-version": "openjdk64-17.0.2"},
+			want:     17,
+			wantFile: ".java-version",
+		},
+		{
+			name:     "asdf",
+			files:    map[string]string{".tool-versions": "nodejs 20.11.0\njava temurin-21.0.2+13.0.LTS\n"},
+			want:     21,
+			wantFile: ".tool-versions",
+		},
+		{
+			name:     "SDKMAN!",
+			files:    map[string]string{".sdkmanrc": "# Enable auto-env through the sdkman_auto_env config\njava=11.0.21-tem\ngradle=8.5\n"},
+			want:     11,
+			wantFile: ".sdkmanrc",
+		},
+		{
+			name:  "no java in the version manager file",
+			files: map[string]string{".tool-versions": "ruby 3.3.0\n"},
+		},
+		{
+			name:  "not a JDK major version",
+			files: map[string]string{".java-version": "system"},
+		},
+		{
+			name:  "unknown JDK major version",
+			files: map[string]string{".java-version": "64"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+			rootEntry, err := direntry.WalkDir(dir, 1)
+			require.NoError(t, err)
+
+			got, gotFile := detectDeclaredJDKVersion(*rootEntry)
+			require.Equal(t, tt.want, got)
+			if tt.wantFile == "" {
+				require.Nil(t, gotFile)
+			} else {
+				require.NotNil(t, gotFile)
+				require.Equal(t, tt.wantFile, gotFile.Name)
+			}
+		})
+	}
+}
+
+func Test_requiredJDKVersion(t *testing.T) {
+	tests := []struct {
+		name           string
+		requirements   ToolRequirements
+		want           int
+		wantRequiredBy string
+	}{
+		{
+			name:           "Android Gradle plugin 8",
+			requirements:   ToolRequirements{AGPVersion: "8.2.0", GradleVersion: "8.5", CompileSdk: 34},
+			want:           17,
+			wantRequiredBy: "Android Gradle plugin 8.2.0",
+		},
+		{
+			name:           "compileSdk with Android Gradle plugin 4",
+			requirements:   ToolRequirements{AGPVersion: "4.2.2", CompileSdk: 30},
+			want:           11,
+			wantRequiredBy: "compileSdk 30",
+		},
+		{
+			name:           "Gradle 9",
+			requirements:   ToolRequirements{GradleVersion: "9.0.0"},
+			want:           17,
+			wantRequiredBy: "Gradle 9.0.0",
+		},
+		{
+			name: "unknown versions",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, requiredBy := tt.requirements.requiredJDKVersion()
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantRequiredBy, requiredBy)
+		})
+	}
+}
+
+func Test_gradleMaximumJDKVersion(t *testing.T) {
+	tests := []struct {
+		gradleVersion string
+		want          int
+	}{
+		{gradleVersion: "9.1.0", want: 25},
+		{gradleVersion: "8.5", want: 21},
+		{gradleVersion: "8.4", want: 20},
+		{gradleVersion: "4.10.3", want: 0},
+		{gradleVersion: "", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.gradleVersion, func(t *testing.T) {
+			require.Equal(t, tt.want, gradleMaximumJDKVersion(tt.gradleVersion))
+		})
+	}
+}
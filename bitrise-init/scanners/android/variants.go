@@ -0,0 +1,383 @@
+package android
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/models"
+)
+
+/*
+Build variants are the combinations of the product flavors (one from each flavor dimension, in the order of the dimensions) and the build types:
+	android {
+		flavorDimensions "tier", "env"
+		productFlavors {
+			free { dimension "tier" }
+			paid { dimension "tier" }
+			prod { dimension "env" }
+		}
+		buildTypes {
+			release { ... }
+		}
+	}
+gives freeProdDebug, freeProdRelease, paidProdDebug and paidProdRelease. The debug and release build types always exist.
+Kotlin build scripts declare the flavors and build types with create("free") { dimension = "tier" } or getByName("release") { ... }.
+*/
+
+// Variant is a build variant of an Android application module, with the icons of its source sets.
+type Variant struct {
+	Name  string
+	Icons models.Icons
+	// SourceSets are the source set names of the variant, like main, free, prod, freeProd, release and freeProdRelease
+	SourceSets []string
+}
+
+var (
+	androidBlockPattern         = regexp.MustCompile(`(?:^|[^\w.])android\s*\{`)
+	namedContainerCallPattern   = regexp.MustCompile(`^(?:getByName|create|named|register|maybeCreate)\s*\(\s*["']([\w-]+)["']\s*\)$`)
+	identifierPattern           = regexp.MustCompile(`^\w+$`)
+	stringLiteralPattern        = regexp.MustCompile(`["']([\w-]+)["']`)
+	dimensionPattern            = regexp.MustCompile(`\b(?:dimension|setDimension)\s*(?:=|\.set)?\s*\(?\s*["']([\w-]+)["']`)
+	dimensionKeywordPattern     = regexp.MustCompile(`\b(?:dimension|setDimension)\b`)
+	flavorDimensionsLinePattern = regexp.MustCompile(`(?m)\bflavorDimensions\b.*$`)
+)
+
+// dynamicContainerCalls configure the flavors and build types computed at runtime, the variants can not be listed statically.
+var dynamicContainerCalls = []string{"all", "configureEach", "each", "forEach", "matching", "withType", "whenObjectAdded"}
+
+// variantFilters disable variants at configuration time.
+var variantFilters = []string{"variantFilter", "beforeVariants"}
+
+// parseVariants returns the build variants of a module build script.
+// It returns an error if the variants can not be determined statically.
+func parseVariants(buildScriptContent string) ([]Variant, error) {
+	content := stripComments(buildScriptContent)
+
+	location := androidBlockPattern.FindStringIndex(content)
+	if location == nil {
+		return nil, fmt.Errorf("no android block found")
+	}
+	androidBody, ok := blockBody(content, location[1]-1)
+	if !ok {
+		return nil, fmt.Errorf("unterminated android block")
+	}
+	for _, filter := range variantFilters {
+		if strings.Contains(content, filter) {
+			return nil, fmt.Errorf("variants are filtered with %s", filter)
+		}
+	}
+
+	buildTypes := []string{"debug", "release"}
+	if body, found, err := namedBlockBody(androidBody, "buildTypes"); err != nil {
+		return nil, err
+	} else if found {
+		children, err := containerChildren(body)
+		if err != nil {
+			return nil, fmt.Errorf("buildTypes: %w", err)
+		}
+		for _, child := range children {
+			buildTypes = appendIfMissing(buildTypes, child.name)
+		}
+	}
+
+	dimensions, err := parseFlavorDimensions(androidBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var flavors []containerChild
+	if body, found, err := namedBlockBody(androidBody, "productFlavors"); err != nil {
+		return nil, err
+	} else if found {
+		if flavors, err = containerChildren(body); err != nil {
+			return nil, fmt.Errorf("productFlavors: %w", err)
+		}
+	}
+
+	flavorsByDimension, err := groupFlavorsByDimension(flavors, dimensions)
+	if err != nil {
+		return nil, err
+	}
+
+	var variants []Variant
+	for _, flavorCombination := range combinations(flavorsByDimension) {
+		for _, buildType := range buildTypes {
+			variants = append(variants, newVariant(flavorCombination, buildType))
+		}
+	}
+	return variants, nil
+}
+
+func newVariant(flavors []string, buildType string) Variant {
+	sourceSets := []string{"main"}
+	sourceSets = append(sourceSets, flavors...)
+
+	flavorName := ""
+	for i, flavor := range flavors {
+		if i == 0 {
+			flavorName = flavor
+		} else {
+			flavorName += capitalize(flavor)
+		}
+	}
+	if len(flavors) > 1 {
+		sourceSets = append(sourceSets, flavorName)
+	}
+	sourceSets = append(sourceSets, buildType)
+
+	name := buildType
+	if flavorName != "" {
+		name = flavorName + capitalize(buildType)
+		sourceSets = append(sourceSets, name)
+	}
+
+	return Variant{Name: name, SourceSets: sourceSets}
+}
+
+// parseFlavorDimensions returns the flavor dimensions in declaration order, from all the flavorDimensions statements.
+func parseFlavorDimensions(androidBody string) ([]string, error) {
+	var dimensions []string
+	for _, line := range flavorDimensionsLinePattern.FindAllString(androidBody, -1) {
+		literals := stringLiteralPattern.FindAllStringSubmatch(line, -1)
+		if len(literals) == 0 {
+			return nil, fmt.Errorf("flavor dimensions are not string literals: %s", strings.TrimSpace(line))
+		}
+		for _, literal := range literals {
+			dimensions = appendIfMissing(dimensions, literal[1])
+		}
+	}
+	return dimensions, nil
+}
+
+// groupFlavorsByDimension returns the flavor names of each dimension. Flavors without a dimension belong to the only dimension,
+// projects without flavors have a single empty combination.
+func groupFlavorsByDimension(flavors []containerChild, dimensions []string) ([][]string, error) {
+	if len(flavors) == 0 {
+		return nil, nil
+	}
+	if len(dimensions) == 0 {
+		dimensions = []string{""}
+	}
+
+	flavorsByDimension := make([][]string, len(dimensions))
+	for _, flavor := range flavors {
+		dimension := ""
+		if match := dimensionPattern.FindStringSubmatch(flavor.body); match != nil {
+			dimension = match[1]
+		} else if dimensionKeywordPattern.MatchString(flavor.body) {
+			return nil, fmt.Errorf("dimension of flavor %s is not a string literal", flavor.name)
+		}
+
+		index := -1
+		for i, d := range dimensions {
+			if d == dimension || (dimension == "" && len(dimensions) == 1) {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			return nil, fmt.Errorf("unknown dimension of flavor %s: %s", flavor.name, dimension)
+		}
+		flavorsByDimension[index] = appendIfMissing(flavorsByDimension[index], flavor.name)
+	}
+
+	for i, dimensionFlavors := range flavorsByDimension {
+		if len(dimensionFlavors) == 0 {
+			return nil, fmt.Errorf("no flavors found for dimension %s", dimensions[i])
+		}
+	}
+	return flavorsByDimension, nil
+}
+
+// combinations returns the flavor combinations, taking one flavor from each dimension.
+func combinations(flavorsByDimension [][]string) [][]string {
+	result := [][]string{nil}
+	for _, flavors := range flavorsByDimension {
+		var next [][]string
+		for _, combination := range result {
+			for _, flavor := range flavors {
+				next = append(next, append(append([]string{}, combination...), flavor))
+			}
+		}
+		result = next
+	}
+	return result
+}
+
+type containerChild struct {
+	name string
+	body string
+}
+
+// containerChildren returns the elements of a named domain object container block (like productFlavors or buildTypes),
+// configured with blocks (release { ... }, getByName("release") { ... }) or created without configuration (create("staging")).
+func containerChildren(body string) ([]containerChild, error) {
+	var (
+		children      []containerChild
+		statements    []string
+		header        strings.Builder
+		lastStatement string
+	)
+
+	addStatement := func() {
+		if statement := strings.TrimSpace(header.String()); statement != "" {
+			statements = append(statements, statement)
+			lastStatement = statement
+		}
+		header.Reset()
+	}
+
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; c {
+		case '"', '\'':
+			end := skipString(body, i)
+			header.WriteString(body[i:end])
+			i = end - 1
+		case '\n', ';':
+			addStatement()
+		case '{':
+			name := strings.TrimSpace(header.String())
+			header.Reset()
+			// The opening brace of the block can be on the next line
+			if name == "" && lastStatement != "" {
+				name = lastStatement
+				statements = statements[:len(statements)-1]
+			}
+			lastStatement = ""
+
+			childBody, ok := blockBody(body, i)
+			if !ok {
+				return nil, fmt.Errorf("unterminated block: %s", name)
+			}
+			i += len(childBody) + 1
+
+			childName, err := containerElementName(name)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, containerChild{name: childName, body: childBody})
+		default:
+			if c != ' ' && c != '\t' && c != '\r' {
+				lastStatement = ""
+			}
+			header.WriteByte(c)
+		}
+	}
+	addStatement()
+
+	for _, statement := range statements {
+		if match := namedContainerCallPattern.FindStringSubmatch(statement); match != nil {
+			children = append(children, containerChild{name: match[1]})
+		}
+	}
+	return children, nil
+}
+
+func containerElementName(header string) (string, error) {
+	if match := namedContainerCallPattern.FindStringSubmatch(header); match != nil {
+		return match[1], nil
+	}
+	if identifierPattern.MatchString(header) {
+		for _, call := range dynamicContainerCalls {
+			if header == call {
+				return "", fmt.Errorf("elements are configured dynamically with %s", call)
+			}
+		}
+		return header, nil
+	}
+	return "", fmt.Errorf("unsupported element declaration: %s", header)
+}
+
+// namedBlockBody returns the body of the first `name {` block directly in body.
+func namedBlockBody(body, name string) (string, bool, error) {
+	pattern := regexp.MustCompile(`(?:^|[^\w.])` + name + `\s*\{`)
+	location := pattern.FindStringIndex(body)
+	if location == nil {
+		return "", false, nil
+	}
+	blockContent, ok := blockBody(body, location[1]-1)
+	if !ok {
+		return "", false, fmt.Errorf("unterminated %s block", name)
+	}
+	return blockContent, true, nil
+}
+
+// blockBody returns the content between the opening brace at openIndex and the matching closing brace.
+func blockBody(content string, openIndex int) (string, bool) {
+	depth := 0
+	for i := openIndex; i < len(content); i++ {
+		switch content[i] {
+		case '"', '\'':
+			i = skipString(content, i) - 1
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return content[openIndex+1 : i], true
+			}
+		}
+	}
+	return "", false
+}
+
+// skipString returns the index after the string literal starting at start.
+func skipString(content string, start int) int {
+	quote := content[start]
+	for i := start + 1; i < len(content); i++ {
+		switch content[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		case '\n':
+			return i
+		}
+	}
+	return len(content)
+}
+
+// stripComments removes the line and block comments outside of the string literals.
+func stripComments(content string) string {
+	var stripped strings.Builder
+	for i := 0; i < len(content); i++ {
+		switch {
+		case content[i] == '"' || content[i] == '\'':
+			end := skipString(content, i)
+			stripped.WriteString(content[i:end])
+			i = end - 1
+		case strings.HasPrefix(content[i:], "//"):
+			end := strings.IndexByte(content[i:], '\n')
+			if end == -1 {
+				return stripped.String()
+			}
+			i += end - 1
+		case strings.HasPrefix(content[i:], "/*"):
+			end := strings.Index(content[i+2:], "*/")
+			if end == -1 {
+				return stripped.String()
+			}
+			i += end + 3
+		default:
+			stripped.WriteByte(content[i])
+		}
+	}
+	return stripped.String()
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func appendIfMissing(items []string, item string) []string {
+	for _, existing := range items {
+		if existing == item {
+			return items
+		}
+	}
+	return append(items, item)
+}
@@ -0,0 +1,191 @@
+package android
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseVariants(t *testing.T) {
+	tests := []struct {
+		name               string
+		buildScriptContent string
+		want               []string
+		wantErr            bool
+	}{
+		{
+			name:               "default build types",
+			buildScriptContent: `android { namespace = "com.example" }`,
+			want:               []string{"debug", "release"},
+		},
+		{
+			name: "custom build type",
+			buildScriptContent: `android {
+    buildTypes {
+        release { minifyEnabled true }
+        staging {
+            initWith debug
+        }
+    }
+}`,
+			want: []string{"debug", "release", "staging"},
+		},
+		{
+			name: "flavor dimensions in groovy dsl",
+			buildScriptContent: `android {
+    flavorDimensions "tier", "env"
+    productFlavors {
+        free { dimension "tier" }
+        paid { dimension "tier" }
+        prod { dimension "env" }
+    }
+}`,
+			want: []string{"freeProdDebug", "freeProdRelease", "paidProdDebug", "paidProdRelease"},
+		},
+		{
+			name: "flavors in kotlin dsl",
+			buildScriptContent: `android {
+    flavorDimensions += "version"
+    productFlavors {
+        create("demo") {
+            dimension = "version"
+        }
+        create("full")
+        {
+            dimension = "version"
+        }
+    }
+    buildTypes {
+        getByName("release") { isMinifyEnabled = true }
+        create("benchmark")
+    }
+}`,
+			want: []string{"demoDebug", "demoRelease", "demoBenchmark", "fullDebug", "fullRelease", "fullBenchmark"},
+		},
+		{
+			name: "flavors without dimension",
+			buildScriptContent: `android {
+    productFlavors {
+        // internal { }
+        dev { applicationIdSuffix ".dev" }
+        prod { }
+    }
+}`,
+			want: []string{"devDebug", "devRelease", "prodDebug", "prodRelease"},
+		},
+		{
+			name:               "no android block",
+			buildScriptContent: `plugins { id("java-library") }`,
+			wantErr:            true,
+		},
+		{
+			name: "dynamically configured flavors",
+			buildScriptContent: `android {
+    productFlavors {
+        all { }
+    }
+}`,
+			wantErr: true,
+		},
+		{
+			name: "filtered variants",
+			buildScriptContent: `android {
+    variantFilter { variant -> }
+}`,
+			wantErr: true,
+		},
+		{
+			name: "dimension not a string literal",
+			buildScriptContent: `android {
+    flavorDimensions "tier"
+    productFlavors {
+        free { dimension tierDimension }
+    }
+}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variants, err := parseVariants(tt.buildScriptContent)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var got []string
+			for _, variant := range variants {
+				got = append(got, variant.Name)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_newVariant(t *testing.T) {
+	tests := []struct {
+		name           string
+		flavors        []string
+		buildType      string
+		wantName       string
+		wantSourceSets []string
+	}{
+		{
+			name:           "no flavors",
+			buildType:      "debug",
+			wantName:       "debug",
+			wantSourceSets: []string{"main", "debug"},
+		},
+		{
+			name:           "single flavor",
+			flavors:        []string{"free"},
+			buildType:      "release",
+			wantName:       "freeRelease",
+			wantSourceSets: []string{"main", "free", "release", "freeRelease"},
+		},
+		{
+			name:           "multiple flavors",
+			flavors:        []string{"free", "prod"},
+			buildType:      "release",
+			wantName:       "freeProdRelease",
+			wantSourceSets: []string{"main", "free", "prod", "freeProd", "release", "freeProdRelease"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variant := newVariant(tt.flavors, tt.buildType)
+			require.Equal(t, tt.wantName, variant.Name)
+			require.Equal(t, tt.wantSourceSets, variant.SourceSets)
+		})
+	}
+}
+
+func Test_stripComments(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "line comment",
+			content: "a // comment\nb",
+			want:    "a \nb",
+		},
+		{
+			name:    "block comment",
+			content: "a /* comment\n */b",
+			want:    "a b",
+		},
+		{
+			name:    "comment markers in string literals",
+			content: `url = "https://example.com/*"`,
+			want:    `url = "https://example.com/*"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, stripComments(tt.content))
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package bazel
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+const (
+	scannerName = "bazel"
+
+	workspaceDirInputTitle   = "Bazel workspace directory"
+	workspaceDirInputSummary = "The directory containing the MODULE.bazel or WORKSPACE file of the Bazel workspace"
+	workspaceDirInputEnvKey  = "BAZEL_WORKSPACE_DIR"
+
+	targetInputTitle   = "Bazel target"
+	targetInputSummary = "The Bazel target pattern to build, like //app:App or //... for every target of the workspace"
+	targetInputEnvKey  = "BAZEL_TARGET"
+
+	testTargetsInputTitle   = "Bazel test targets"
+	testTargetsInputSummary = "The space separated Bazel test targets to run, like //app:AppTests //lib:LibTests or //... for every test of the workspace"
+	testTargetsInputEnvKey  = "BAZEL_TEST_TARGETS"
+
+	allTargetsPattern = "//..."
+)
+
+// Scanner implements ScannerInterface for Bazel workspaces.
+type Scanner struct {
+	workspaces []workspace // populated by DetectPlatform
+}
+
+// NewScanner creates a new Scanner instance.
+func NewScanner() *Scanner {
+	return &Scanner{}
+}
+
+// Name returns the scanner name.
+func (s *Scanner) Name() string {
+	return scannerName
+}
+
+// DetectPlatform checks whether searchDir contains a Bazel workspace and collects the targets of its BUILD files.
+func (s *Scanner) DetectPlatform(searchDir string) (bool, error) {
+	workspaces, err := collectWorkspaces(searchDir)
+	if err != nil {
+		return false, fmt.Errorf("failed to collect Bazel workspaces: %w", err)
+	}
+
+	for _, w := range workspaces {
+		log.TPrintf("Bazel workspace found: %s", w.workspaceFile)
+		log.TPrintf("- %d app, %d binary and %d test targets", len(w.targetsOfKind(targetKindIOSApp, targetKindAndroidApp)), len(w.targetsOfKind(targetKindBinary)), len(w.targetsOfKind(targetKindTest)))
+	}
+	s.workspaces = workspaces
+
+	if len(s.workspaces) == 0 {
+		log.TPrintf("Platform not detected")
+		return false, nil
+	}
+
+	log.TSuccessf("Platform detected")
+	return true, nil
+}
+
+// Evidence returns the workspace files and the BUILD files declaring app and test targets.
+func (s *Scanner) Evidence() models.Evidences {
+	var evidences models.Evidences
+	for _, w := range s.workspaces {
+		evidences.Add(w.workspaceFile, "Bazel workspace")
+		for _, t := range w.targetsOfKind(targetKindIOSApp, targetKindAndroidApp, targetKindTest) {
+			evidences.Add(filepath.Join(w.relDir, t.buildRel), fmt.Sprintf("%s: %s", t.rule, t.label))
+		}
+	}
+	return evidences
+}
+
+// ProjectRoots returns the workspace dirs, keyed by the workspace dir option value.
+func (s *Scanner) ProjectRoots() []models.ProjectRoot {
+	var roots []models.ProjectRoot
+	for _, w := range s.workspaces {
+		roots = append(roots, models.ProjectRoot{Dir: w.relDir, OptionValue: w.relDir})
+	}
+	return roots
+}
+
+// ExcludedScannerNames returns scanners to skip when this scanner detects.
+func (s *Scanner) ExcludedScannerNames() []string {
+	return []string{}
+}
+
+// Options builds the option tree of the workspaces and their buildable targets.
+func (s *Scanner) Options() (models.OptionNode, models.Warnings, models.Icons, error) {
+	return generateOptions(s.workspaces)
+}
+
+// Configs generates the pre-made bitrise.yml templates for each detected workspace.
+func (s *Scanner) Configs(sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	return generateConfigs(s.workspaces, sshKeyActivation)
+}
+
+// DefaultOptions returns the option tree for the manual configuration flow.
+func (s *Scanner) DefaultOptions() models.OptionNode {
+	workspaceDirOption := models.NewOption(workspaceDirInputTitle, workspaceDirInputSummary, workspaceDirInputEnvKey, models.TypeUserInput)
+	targetOption := models.NewOption(targetInputTitle, targetInputSummary, targetInputEnvKey, models.TypeUserInput)
+	workspaceDirOption.AddOption(models.UserInputOptionDefaultValue, targetOption)
+	testTargetsOption := models.NewOption(testTargetsInputTitle, testTargetsInputSummary, testTargetsInputEnvKey, models.TypeUserInput)
+	targetOption.AddOption(models.UserInputOptionDefaultValue, testTargetsOption)
+
+	descriptor := createDefaultConfigDescriptor()
+	testTargetsOption.AddConfig(models.UserInputOptionDefaultValue, models.NewConfigOption(configName(descriptor), nil))
+
+	return *workspaceDirOption
+}
+
+// DefaultConfigs generates the static bitrise.yml templates for the manual configuration flow.
+func (s *Scanner) DefaultConfigs() (models.BitriseConfigMap, error) {
+	descriptor := createDefaultConfigDescriptor()
+	config, err := generateConfigBasedOn(descriptor, models.SSHKeyActivationConditional)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.BitriseConfigMap{
+		configName(descriptor): config,
+	}, nil
+}
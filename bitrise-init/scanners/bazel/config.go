@@ -0,0 +1,168 @@
+package bazel
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/steps"
+	envmanModels "github.com/bitrise-io/envman/v2/models"
+)
+
+const (
+	buildWorkflowID    = models.WorkflowID("build")
+	runTestsWorkflowID = models.WorkflowID("run_tests")
+
+	buildScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+bazel build "$` + targetInputEnvKey + `"
+`
+
+	testScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+# the test targets are passed as separate arguments
+bazel test $` + testTargetsInputEnvKey + ` --test_output=errors
+`
+)
+
+type configDescriptor struct {
+	workdir   string
+	hasTests  bool
+	isDefault bool
+}
+
+func createConfigDescriptor(w workspace, isDefault bool) configDescriptor {
+	d := configDescriptor{
+		workdir:   "$" + workspaceDirInputEnvKey,
+		hasTests:  w.hasTests(),
+		isDefault: isDefault,
+	}
+	if w.relDir == "." {
+		d.workdir = ""
+	}
+	return d
+}
+
+func createDefaultConfigDescriptor() configDescriptor {
+	return configDescriptor{
+		workdir:   "$" + workspaceDirInputEnvKey,
+		hasTests:  true,
+		isDefault: true,
+	}
+}
+
+// targetValues returns the target selector values of a workspace: its app and binary targets, followed by the
+// pattern matching every target.
+func targetValues(w workspace) []string {
+	var values []string
+	for _, t := range w.targetsOfKind(targetKindIOSApp, targetKindAndroidApp, targetKindBinary) {
+		values = append(values, t.label)
+	}
+	return append(values, allTargetsPattern)
+}
+
+// testTargetsValue returns the test targets selector value of a workspace: the labels of its test targets.
+func testTargetsValue(w workspace) string {
+	var labels []string
+	for _, t := range w.targetsOfKind(targetKindTest) {
+		labels = append(labels, t.label)
+	}
+	return strings.Join(labels, " ")
+}
+
+func generateOptions(workspaces []workspace) (models.OptionNode, models.Warnings, models.Icons, error) {
+	if len(workspaces) == 0 {
+		return models.OptionNode{}, nil, nil, fmt.Errorf("no Bazel workspace found")
+	}
+
+	workspaceDirOption := models.NewOption(workspaceDirInputTitle, workspaceDirInputSummary, workspaceDirInputEnvKey, models.TypeSelector)
+	for _, w := range workspaces {
+		descriptor := createConfigDescriptor(w, false)
+
+		targetOption := models.NewOption(targetInputTitle, targetInputSummary, targetInputEnvKey, models.TypeSelector)
+		for _, value := range targetValues(w) {
+			if !w.hasTests() {
+				targetOption.AddConfig(value, models.NewConfigOption(configName(descriptor), nil))
+				continue
+			}
+
+			testTargetsOption := models.NewOption(testTargetsInputTitle, testTargetsInputSummary, testTargetsInputEnvKey, models.TypeSelector)
+			testTargetsOption.AddConfig(testTargetsValue(w), models.NewConfigOption(configName(descriptor), nil))
+			targetOption.AddOption(value, testTargetsOption)
+		}
+		workspaceDirOption.AddOption(w.relDir, targetOption)
+	}
+
+	return *workspaceDirOption, nil, nil, nil
+}
+
+func generateConfigs(workspaces []workspace, sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	if len(workspaces) == 0 {
+		return models.BitriseConfigMap{}, fmt.Errorf("no Bazel workspace found")
+	}
+
+	configs := models.BitriseConfigMap{}
+	for _, w := range workspaces {
+		descriptor := createConfigDescriptor(w, false)
+		config, err := generateConfigBasedOn(descriptor, sshKeyActivation)
+		if err != nil {
+			return nil, err
+		}
+		configs[configName(descriptor)] = config
+	}
+	return configs, nil
+}
+
+func configName(d configDescriptor) string {
+	if d.isDefault {
+		return "default-bazel-config"
+	}
+
+	name := "bazel"
+	if d.workdir == "" {
+		name += "-root"
+	}
+	if d.hasTests {
+		name += "-test"
+	}
+	return name + "-config"
+}
+
+func generateConfigBasedOn(d configDescriptor, sshKey models.SSHKeyActivation) (string, error) {
+	configBuilder := models.NewDefaultConfigBuilder()
+
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.DefaultPrepareStepList(steps.PrepareListParams{SSHKeyActivation: sshKey})...)
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.ActivateBuildCacheForBazel())
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.ScriptStepListItem("Build", buildScriptContent, workdirInputs(d.workdir)...))
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.DefaultDeployStepList()...)
+
+	if d.hasTests {
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.DefaultPrepareStepList(steps.PrepareListParams{SSHKeyActivation: sshKey})...)
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ActivateBuildCacheForBazel())
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Run tests", testScriptContent, workdirInputs(d.workdir)...))
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.DefaultDeployStepList()...)
+	}
+
+	bitriseConfig, err := configBuilder.Generate(scannerName)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := yaml.Marshal(bitriseConfig)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func workdirInputs(workdir string) []envmanModels.EnvironmentItemModel {
+	if workdir == "" {
+		return nil
+	}
+	return []envmanModels.EnvironmentItemModel{{"working_dir": workdir}}
+}
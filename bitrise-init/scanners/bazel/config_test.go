@@ -0,0 +1,53 @@
+package bazel
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateOptions(t *testing.T) {
+	workspaces := []workspace{
+		{
+			relDir: ".",
+			targets: []target{
+				{label: "//app:App", kind: targetKindIOSApp},
+				{label: "//app:AppTests", kind: targetKindTest},
+				{label: "//lib:LibTests", kind: targetKindTest},
+			},
+		},
+		{
+			relDir:  "tools",
+			targets: []target{{label: "//:cli", kind: targetKindBinary}},
+		},
+	}
+
+	options, _, _, err := generateOptions(workspaces)
+	require.NoError(t, err)
+
+	rootTargets := options.ChildOptionMap["."]
+	require.ElementsMatch(t, []string{"//app:App", allTargetsPattern}, keys(rootTargets.ChildOptionMap))
+	testTargets := rootTargets.ChildOptionMap["//app:App"]
+	require.Equal(t, testTargetsInputEnvKey, testTargets.EnvKey)
+	require.Equal(t, "bazel-root-test-config", testTargets.ChildOptionMap["//app:AppTests //lib:LibTests"].Config)
+
+	toolsTargets := options.ChildOptionMap["tools"]
+	require.Equal(t, "bazel-config", toolsTargets.ChildOptionMap["//:cli"].Config)
+}
+
+func TestGenerateConfigBasedOn(t *testing.T) {
+	config, err := generateConfigBasedOn(configDescriptor{hasTests: true}, models.SSHKeyActivationConditional)
+	require.NoError(t, err)
+	require.True(t, strings.Contains(config, "bazel test $BAZEL_TEST_TARGETS --test_output=errors"))
+	require.False(t, strings.Contains(config, "bazel test //..."))
+}
+
+func keys(optionMap map[string]*models.OptionNode) []string {
+	var keys []string
+	for key := range optionMap {
+		keys = append(keys, key)
+	}
+	return keys
+}
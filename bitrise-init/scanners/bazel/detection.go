@@ -0,0 +1,238 @@
+package bazel
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/metrics"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-io/go-utils/sliceutil"
+)
+
+// workspaceFiles mark the root of a Bazel workspace, MODULE.bazel is used by Bzlmod, WORKSPACE by the legacy setup.
+var workspaceFiles = []string{
+	"MODULE.bazel",
+	"WORKSPACE.bazel",
+	"WORKSPACE",
+}
+
+var buildFiles = []string{
+	"BUILD.bazel",
+	"BUILD",
+}
+
+type targetKind string
+
+const (
+	targetKindIOSApp     targetKind = "ios"
+	targetKindAndroidApp targetKind = "android"
+	targetKindBinary     targetKind = "binary"
+	targetKindTest       targetKind = "test"
+)
+
+// appRules are the rules building an app, other *_binary rules are collected as binaries.
+var appRules = map[string]targetKind{
+	"ios_application":     targetKindIOSApp,
+	"ios_app_clip":        targetKindIOSApp,
+	"macos_application":   targetKindIOSApp,
+	"tvos_application":    targetKindIOSApp,
+	"watchos_application": targetKindIOSApp,
+	"android_binary":      targetKindAndroidApp,
+}
+
+var (
+	ruleCallPattern = regexp.MustCompile(`(?m)^([A-Za-z_][A-Za-z0-9_]*)\(`)
+	ruleNamePattern = regexp.MustCompile(`\bname\s*=\s*"([^"]+)"`)
+)
+
+type target struct {
+	label    string
+	rule     string
+	kind     targetKind
+	buildRel string // BUILD file path relative to the workspace
+}
+
+type workspace struct {
+	relDir string
+	// name is the name of the workspace dir, Bazel names the output symlink of the workspace after it
+	name          string
+	workspaceFile string
+	targets       []target
+}
+
+func (w workspace) targetsOfKind(kinds ...targetKind) []target {
+	var targets []target
+	for _, t := range w.targets {
+		for _, kind := range kinds {
+			if t.kind == kind {
+				targets = append(targets, t)
+				break
+			}
+		}
+	}
+	return targets
+}
+
+func (w workspace) hasTests() bool {
+	return len(w.targetsOfKind(targetKindTest)) > 0
+}
+
+var excludeFilters = []pathutil.FilterFunc{
+	pathutil.ComponentFilter(".git", false),
+	pathutil.ComponentFilter("node_modules", false),
+	pathutil.ComponentWithExtensionFilter(".xcodeproj", false),
+}
+
+// collectWorkspaces returns the outermost Bazel workspaces of the search dir with their BUILD targets.
+// Paths are relative to the searchDir.
+func collectWorkspaces(searchDir string) ([]workspace, error) {
+	fileList, err := pathutil.ListPathInDirSortedByComponents(searchDir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceFilePaths, err := pathutil.FilterPaths(fileList, excludeFilters...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The file list is sorted by path components, so outer workspaces are found first
+	var workspaces []workspace
+	for _, pth := range workspaceFilePaths {
+		if !sliceutil.IsStringInSlice(filepath.Base(pth), workspaceFiles) {
+			continue
+		}
+		dir := filepath.Dir(pth)
+		if findWorkspace(workspaces, dir) != nil {
+			continue
+		}
+		workspaces = append(workspaces, workspace{
+			relDir:        dir,
+			name:          filepath.Base(filepath.Join(searchDir, dir)),
+			workspaceFile: pth,
+		})
+	}
+	fileList = removeOutputDirs(fileList, workspaces)
+
+	var buildFilePaths []string
+	for _, buildFile := range buildFiles {
+		paths, err := pathutil.FilterPaths(fileList, append(excludeFilters, pathutil.BaseFilter(buildFile, true))...)
+		if err != nil {
+			return nil, err
+		}
+		buildFilePaths = append(buildFilePaths, paths...)
+	}
+	sort.Strings(buildFilePaths)
+
+	for _, pth := range buildFilePaths {
+		w := findWorkspace(workspaces, filepath.Dir(pth))
+		if w == nil {
+			continue
+		}
+
+		content, err := metrics.ReadStringFromFile(filepath.Join(searchDir, pth))
+		if err != nil {
+			return nil, err
+		}
+
+		buildRel, err := filepath.Rel(w.relDir, pth)
+		if err != nil {
+			return nil, err
+		}
+		w.targets = append(w.targets, parseBuildFile(content, buildRel)...)
+	}
+
+	return workspaces, nil
+}
+
+// outputDirs returns the convenience symlinks Bazel creates in the workspace dir to its output directories.
+func (w workspace) outputDirs() []string {
+	var dirs []string
+	for _, name := range []string{"bazel-bin", "bazel-out", "bazel-testlogs", "bazel-" + w.name} {
+		dirs = append(dirs, filepath.Join(w.relDir, name))
+	}
+	return dirs
+}
+
+// removeOutputDirs drops the content of the workspaces' output symlinks from the file list.
+func removeOutputDirs(fileList []string, workspaces []workspace) []string {
+	var outputDirs []string
+	for _, w := range workspaces {
+		outputDirs = append(outputDirs, w.outputDirs()...)
+	}
+
+	var filtered []string
+	for _, pth := range fileList {
+		isOutput := false
+		for _, dir := range outputDirs {
+			if pth == dir || strings.HasPrefix(pth, dir+string(filepath.Separator)) {
+				isOutput = true
+				break
+			}
+		}
+		if !isOutput {
+			filtered = append(filtered, pth)
+		}
+	}
+	return filtered
+}
+
+func findWorkspace(workspaces []workspace, dir string) *workspace {
+	for i, w := range workspaces {
+		if w.relDir == "." || dir == w.relDir || strings.HasPrefix(dir, w.relDir+string(filepath.Separator)) {
+			return &workspaces[i]
+		}
+	}
+	return nil
+}
+
+// parseBuildFile returns the targets of the top-level rule calls of a BUILD file.
+// buildRel is the BUILD file path relative to the workspace root, it determines the package of the labels.
+func parseBuildFile(content, buildRel string) []target {
+	pkg := filepath.ToSlash(filepath.Dir(buildRel))
+	if pkg == "." {
+		pkg = ""
+	}
+
+	var targets []target
+	callIndexes := ruleCallPattern.FindAllStringSubmatchIndex(content, -1)
+	for i, indexes := range callIndexes {
+		rule := content[indexes[2]:indexes[3]]
+		kind, ok := targetKindOf(rule)
+		if !ok {
+			continue
+		}
+
+		bodyEnd := len(content)
+		if i+1 < len(callIndexes) {
+			bodyEnd = callIndexes[i+1][0]
+		}
+		match := ruleNamePattern.FindStringSubmatch(content[indexes[1]:bodyEnd])
+		if match == nil {
+			continue
+		}
+
+		targets = append(targets, target{
+			label:    "//" + pkg + ":" + match[1],
+			rule:     rule,
+			kind:     kind,
+			buildRel: buildRel,
+		})
+	}
+	return targets
+}
+
+func targetKindOf(rule string) (targetKind, bool) {
+	if kind, ok := appRules[rule]; ok {
+		return kind, true
+	}
+	if strings.HasSuffix(rule, "_test") {
+		return targetKindTest, true
+	}
+	if strings.HasSuffix(rule, "_binary") {
+		return targetKindBinary, true
+	}
+	return "", false
+}
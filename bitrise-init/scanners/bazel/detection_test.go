@@ -0,0 +1,125 @@
+package bazel
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for pth, content := range files {
+		pth = filepath.Join(dir, pth)
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+		require.NoError(t, os.WriteFile(pth, []byte(content), 0644))
+	}
+}
+
+func TestParseBuildFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		buildRel string
+		want     []target
+	}{
+		{
+			name: "app and test targets",
+			content: `load("@build_bazel_rules_apple//apple:ios.bzl", "ios_application", "ios_unit_test")
+
+ios_application(
+    name = "App",
+    bundle_id = "io.bitrise.app",
+)
+
+ios_unit_test(
+    name = "AppTests",
+    deps = [":AppTestsLib"],
+)
+
+swift_library(
+    name = "AppTestsLib",
+)
+`,
+			buildRel: "app/BUILD.bazel",
+			want: []target{
+				{label: "//app:App", rule: "ios_application", kind: targetKindIOSApp, buildRel: "app/BUILD.bazel"},
+				{label: "//app:AppTests", rule: "ios_unit_test", kind: targetKindTest, buildRel: "app/BUILD.bazel"},
+			},
+		},
+		{
+			name: "root package binary",
+			content: `go_binary(
+    name = "server",
+)
+`,
+			buildRel: "BUILD",
+			want: []target{
+				{label: "//:server", rule: "go_binary", kind: targetKindBinary, buildRel: "BUILD"},
+			},
+		},
+		{
+			name:     "no buildable targets",
+			content:  "filegroup(\n    name = \"srcs\",\n)\n",
+			buildRel: "BUILD",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, parseBuildFile(tt.content, tt.buildRel))
+		})
+	}
+}
+
+func TestCollectWorkspaces(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []workspace
+	}{
+		{
+			name:  "no workspace",
+			files: map[string]string{"BUILD": "go_binary(name = \"server\")\n"},
+		},
+		{
+			name: "output symlinks of the workspace are skipped",
+			files: map[string]string{
+				"project/MODULE.bazel":                  "",
+				"project/BUILD":                         "go_test(name = \"server_test\")\n",
+				"project/bazel-out/BUILD":               "go_test(name = \"output_test\")\n",
+				"project/bazel-project/BUILD":           "go_test(name = \"execroot_test\")\n",
+				"project/bazel-tools/BUILD":             "go_test(name = \"tools_test\")\n",
+				"project/nested/WORKSPACE":              "",
+				"project/nested/bazel-nested/lib/BUILD": "go_test(name = \"nested_test\")\n",
+			},
+			want: []workspace{
+				{
+					relDir:        "project",
+					name:          "project",
+					workspaceFile: "project/MODULE.bazel",
+					targets: []target{
+						{label: "//:server_test", rule: "go_test", kind: targetKindTest, buildRel: "BUILD"},
+						{label: "//bazel-tools:tools_test", rule: "go_test", kind: targetKindTest, buildRel: "bazel-tools/BUILD"},
+						{label: "//nested/bazel-nested/lib:nested_test", rule: "go_test", kind: targetKindTest, buildRel: "nested/bazel-nested/lib/BUILD"},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchDir := t.TempDir()
+			writeFiles(t, searchDir, tt.files)
+			t.Chdir(searchDir)
+
+			got, err := collectWorkspaces(searchDir)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestScanner_DetectPlatform_error(t *testing.T) {
+	_, err := NewScanner().DetectPlatform(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+}
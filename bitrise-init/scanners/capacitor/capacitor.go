@@ -0,0 +1,262 @@
+package capacitor
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/bitrise-io/bitrise-init/detectors/gradle"
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/scanners/android"
+	"github.com/bitrise-io/bitrise-init/scanners/cordova"
+	"github.com/bitrise-io/bitrise-init/scanners/crossplatform"
+	"github.com/bitrise-io/bitrise-init/scanners/ionic"
+	"github.com/bitrise-io/bitrise-init/scanners/ios"
+	"github.com/bitrise-io/bitrise-init/scanners/java"
+	"github.com/bitrise-io/bitrise-init/scanners/nodejs"
+	"github.com/bitrise-io/bitrise-init/utility"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+)
+
+const scannerName = "capacitor"
+
+const (
+	projectDirInputTitle   = "Capacitor project directory"
+	projectDirInputSummary = "Path of the directory containing the project's `capacitor.config` and `package.json` files."
+	projectDirInputEnvKey  = "WORKDIR"
+)
+
+// configFiles are the Capacitor config file names, one of them is in the root of every Capacitor project.
+var configFiles = []string{
+	"capacitor.config.ts",
+	"capacitor.config.json",
+	"capacitor.config.js",
+}
+
+// capacitorPackages are the dependencies of a Capacitor app, either of them is in the package.json.
+var capacitorPackages = []string{
+	"@capacitor/core",
+	"@capacitor/cli",
+}
+
+type project struct {
+	projectRelDir string
+	configFile    string
+
+	hasTest         bool
+	hasBuildScript  bool
+	hasYarnLockFile bool
+
+	iosProjects    ios.DetectResult
+	androidProject *gradle.Project
+}
+
+// Scanner implements the project scanner for Capacitor projects.
+type Scanner struct {
+	projects []project
+
+	configDescriptors []configDescriptor
+}
+
+// NewScanner creates a new scanner instance.
+func NewScanner() *Scanner {
+	return &Scanner{}
+}
+
+// Name implements ScannerInterface.Name function.
+func (Scanner) Name() string {
+	return scannerName
+}
+
+// DetectPlatform implements ScannerInterface.DetectPlatform function.
+func (scanner *Scanner) DetectPlatform(searchDir string) (bool, error) {
+	log.TInfof("Collecting Capacitor config files")
+
+	configPths, err := collectConfigFiles(searchDir)
+	if err != nil {
+		return false, err
+	}
+
+	log.TPrintf("%d Capacitor config file detected", len(configPths))
+	for _, configPth := range configPths {
+		log.TPrintf("Checking: %s", configPth)
+
+		projectDir := filepath.Dir(configPth)
+		packageJSONPth := filepath.Join(projectDir, "package.json")
+		if exist, err := pathutil.IsPathExists(packageJSONPth); err != nil || !exist {
+			log.TPrintf("No package.json found next to the Capacitor config")
+			continue
+		}
+
+		packages, err := utility.ParsePackagesJSON(packageJSONPth)
+		if err != nil {
+			log.TWarnf("Failed to parse package.json: %s", err)
+			continue
+		}
+		if !hasCapacitorDependency(packages) {
+			log.TPrintf("No Capacitor dependency found in package.json")
+			continue
+		}
+
+		relProjectDir, err := utility.RelPath(searchDir, projectDir)
+		if err != nil {
+			return false, fmt.Errorf("failed to get relative project dir path: %w", err)
+		}
+
+		iosProjects, androidProject := crossplatform.DetectNativeProjects(projectDir, relProjectDir)
+		if len(iosProjects.Projects) == 0 && androidProject == nil {
+			log.TPrintf("No native projects found, run `npx cap add ios` or `npx cap add android` and commit the native projects")
+			continue
+		}
+
+		hasYarnLockFile := utility.FileExists(filepath.Join(projectDir, "yarn.lock"))
+		log.TPrintf("Js dependency manager is yarn: %t", hasYarnLockFile)
+
+		_, hasTests := packages.Scripts["test"]
+		log.TPrintf("Test script found in package.json: %v", hasTests)
+		_, hasBuildScript := packages.Scripts["build"]
+		log.TPrintf("Build script found in package.json: %v", hasBuildScript)
+
+		scanner.projects = append(scanner.projects, project{
+			projectRelDir:   relProjectDir,
+			configFile:      filepath.Base(configPth),
+			hasTest:         hasTests,
+			hasBuildScript:  hasBuildScript,
+			hasYarnLockFile: hasYarnLockFile,
+			iosProjects:     iosProjects,
+			androidProject:  androidProject,
+		})
+	}
+
+	if len(scanner.projects) == 0 {
+		log.TPrintf("Platform not detected")
+		return false, nil
+	}
+
+	log.TSuccessf("Platform detected")
+	return true, nil
+}
+
+// Evidence implements EvidenceProvider.Evidence function.
+func (scanner *Scanner) Evidence() models.Evidences {
+	var evidences models.Evidences
+	for _, project := range scanner.projects {
+		evidences.Add(filepath.Join(project.projectRelDir, project.configFile), "Capacitor config")
+		evidences.Add(filepath.Join(project.projectRelDir, "package.json"), "Capacitor dependency")
+		if project.hasYarnLockFile {
+			evidences.Add(filepath.Join(project.projectRelDir, "yarn.lock"), "yarn lock file")
+		}
+
+		evidences = append(evidences, project.iosProjects.Evidence()...)
+		if project.androidProject != nil {
+			evidences.Add(filepath.Join(project.androidProject.RootDirEntry.RelPath, "gradlew"), "Gradle wrapper script")
+		}
+	}
+	return evidences
+}
+
+// ProjectRoots implements ProjectRootProvider.ProjectRoots function.
+func (scanner *Scanner) ProjectRoots() []models.ProjectRoot {
+	var roots []models.ProjectRoot
+	for _, project := range scanner.projects {
+		roots = append(roots, models.ProjectRoot{Dir: project.projectRelDir, OptionValue: project.projectRelDir, NativeDirs: project.nativeDirs()})
+	}
+	return roots
+}
+
+// nativeDirs returns the dirs of the native iOS projects and the native Android project.
+func (p project) nativeDirs() []string {
+	dirs := []string{}
+	for _, iosProject := range p.iosProjects.Projects {
+		dirs = append(dirs, filepath.Dir(iosProject.RelPath))
+	}
+	if p.androidProject != nil {
+		dirs = append(dirs, p.androidProject.RootDirEntry.RelPath)
+	}
+	return dirs
+}
+
+// Options implements ScannerInterface.Options function.
+func (scanner *Scanner) Options() (models.OptionNode, models.Warnings, models.Icons, error) {
+	var allWarnings models.Warnings
+	projectRootOption := models.NewOption(projectDirInputTitle, projectDirInputSummary, projectDirInputEnvKey, models.TypeSelector)
+	for _, project := range scanner.projects {
+		options, warnings := scanner.options(project)
+		allWarnings = append(allWarnings, warnings...)
+
+		projectRootOption.AddOption(project.projectRelDir, &options)
+	}
+
+	return *projectRootOption, allWarnings, nil, nil
+}
+
+// Configs implements ScannerInterface.Configs function.
+func (scanner *Scanner) Configs(sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	return scanner.configs(sshKeyActivation)
+}
+
+// DefaultOptions implements ScannerInterface.DefaultOptions function.
+func (scanner *Scanner) DefaultOptions() models.OptionNode {
+	return scanner.defaultOptions()
+}
+
+// DefaultConfigs implements ScannerInterface.DefaultConfigs function.
+func (scanner *Scanner) DefaultConfigs() (models.BitriseConfigMap, error) {
+	return scanner.defaultConfigs()
+}
+
+// ExcludedScannerNames implements ScannerInterface.ExcludedScannerNames function.
+func (Scanner) ExcludedScannerNames() []string {
+	return []string{
+		string(ios.XcodeProjectTypeIOS),
+		string(ios.XcodeProjectTypeMacOS),
+		android.ScannerName,
+		cordova.ScannerName,
+		ionic.ScannerName,
+		nodejs.ScannerName,
+		java.ProjectType,
+	}
+}
+
+// collectConfigFiles returns the absolute paths of the Capacitor config files, outside of node_modules.
+func collectConfigFiles(searchDir string) ([]string, error) {
+	fileList, err := pathutil.ListPathInDirSortedByComponents(searchDir, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var configPths []string
+	for _, configFile := range configFiles {
+		pths, err := pathutil.FilterPaths(fileList, pathutil.BaseFilter(configFile, true), pathutil.ComponentFilter("node_modules", false))
+		if err != nil {
+			return nil, err
+		}
+		for _, pth := range pths {
+			if !hasConfigInDir(configPths, filepath.Dir(pth)) {
+				configPths = append(configPths, pth)
+			}
+		}
+	}
+	return configPths, nil
+}
+
+func hasConfigInDir(configPths []string, dir string) bool {
+	for _, pth := range configPths {
+		if filepath.Dir(pth) == dir {
+			return true
+		}
+	}
+	return false
+}
+
+func hasCapacitorDependency(packages utility.PackagesModel) bool {
+	for _, pkg := range capacitorPackages {
+		if _, found := packages.Dependencies[pkg]; found {
+			return true
+		}
+		if _, found := packages.DevDependencies[pkg]; found {
+			return true
+		}
+	}
+	return false
+}
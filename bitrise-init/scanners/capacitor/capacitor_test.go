@@ -0,0 +1,115 @@
+package capacitor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/bitrise-init/detectors/direntry"
+	"github.com/bitrise-io/bitrise-init/detectors/gradle"
+	"github.com/bitrise-io/bitrise-init/scanners/ios"
+	"github.com/bitrise-io/bitrise-init/utility"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectConfigFiles(t *testing.T) {
+	searchDir := t.TempDir()
+	for _, pth := range []string{
+		"capacitor.config.ts",
+		"capacitor.config.json",
+		"apps/mobile/capacitor.config.json",
+		"node_modules/@capacitor/cli/capacitor.config.ts",
+	} {
+		pth = filepath.Join(searchDir, pth)
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+		require.NoError(t, os.WriteFile(pth, []byte("{}"), 0644))
+	}
+
+	got, err := collectConfigFiles(searchDir)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		filepath.Join(searchDir, "capacitor.config.ts"),
+		filepath.Join(searchDir, "apps/mobile/capacitor.config.json"),
+	}, got)
+}
+
+func TestHasCapacitorDependency(t *testing.T) {
+	tests := []struct {
+		name     string
+		packages utility.PackagesModel
+		want     bool
+	}{
+		{
+			name:     "core dependency",
+			packages: utility.PackagesModel{Dependencies: map[string]string{"@capacitor/core": "^6.0.0"}},
+			want:     true,
+		},
+		{
+			name:     "cli dev dependency",
+			packages: utility.PackagesModel{DevDependencies: map[string]string{"@capacitor/cli": "^6.0.0"}},
+			want:     true,
+		},
+		{
+			name:     "no Capacitor dependency",
+			packages: utility.PackagesModel{Dependencies: map[string]string{"react": "^18.0.0"}},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, hasCapacitorDependency(tt.packages))
+		})
+	}
+}
+
+func TestProjectNativeDirs(t *testing.T) {
+	tests := []struct {
+		name    string
+		project project
+		want    []string
+	}{
+		{
+			name:    "no native projects",
+			project: project{projectRelDir: "."},
+			want:    []string{},
+		},
+		{
+			name: "iOS and Android projects",
+			project: project{
+				projectRelDir:  "app",
+				iosProjects:    ios.DetectResult{Projects: []ios.Project{{RelPath: "app/ios/App/App.xcworkspace"}}},
+				androidProject: &gradle.Project{RootDirEntry: direntry.DirEntry{RelPath: "app/android"}},
+			},
+			want: []string{"app/ios/App", "app/android"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.project.nativeDirs())
+		})
+	}
+}
+
+func TestConfigName(t *testing.T) {
+	tests := []struct {
+		name       string
+		descriptor configDescriptor
+		want       string
+	}{
+		{
+			name:       "iOS only",
+			descriptor: configDescriptor{hasIOS: true},
+			want:       "capacitor-ios-config",
+		},
+		{
+			name:       "both platforms with tests, build script and yarn",
+			descriptor: configDescriptor{hasIOS: true, hasAndroid: true, hasTest: true, hasBuildScript: true, hasYarnLockFile: true},
+			want:       "capacitor-android-ios-test-build-yarn-config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.descriptor.configName())
+		})
+	}
+}
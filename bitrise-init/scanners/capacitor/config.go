@@ -0,0 +1,327 @@
+package capacitor
+
+import (
+	"fmt"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/scanners/android"
+	"github.com/bitrise-io/bitrise-init/scanners/ios"
+	"github.com/bitrise-io/bitrise-init/steps"
+	bitriseModels "github.com/bitrise-io/bitrise/v2/models"
+	envmanModels "github.com/bitrise-io/envman/v2/models"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	defaultConfigName = "default-capacitor-config"
+
+	defaultModule  = "app"
+	defaultVariant = "Debug"
+
+	// cap sync copies the web build into the native projects and installs their native dependencies (CocoaPods included)
+	capSyncScriptTemplate = `#!/usr/bin/env bash
+set -euxo pipefail
+
+npx cap sync %s
+`
+)
+
+const (
+	deployWorkflowDescription = `Tests and builds the web app, syncs it into the native projects with Capacitor, then builds and deploys the native apps using *Deploy to bitrise.io* Step.
+
+Next steps:
+- Set up an [Apple service with API key](https://docs.bitrise.io/en/bitrise-platform/integrations/apple-services-connection/connecting-to-an-apple-service-with-api-key.html).
+- Check out the [Capacitor iOS](https://capacitorjs.com/docs/ios) and [Capacitor Android](https://capacitorjs.com/docs/android) documentation.
+`
+
+	primaryWorkflowDescription = `Runs tests.
+
+Next steps:
+- Check out the [Capacitor workflow](https://capacitorjs.com/docs/basics/workflow) documentation.
+`
+
+	primaryWorkflowNoTestsDescription = `Installs dependencies.
+
+Next steps:
+- Add tests to your project and configure the workflow to run them.
+- Check out the [Capacitor workflow](https://capacitorjs.com/docs/basics/workflow) documentation.
+`
+)
+
+type configDescriptor struct {
+	hasIOS, hasAndroid bool
+	hasTest            bool
+	hasBuildScript     bool
+	hasYarnLockFile    bool
+}
+
+func (d configDescriptor) configName() string {
+	name := "capacitor"
+	if d.hasAndroid {
+		name += "-android"
+	}
+	if d.hasIOS {
+		name += "-ios"
+	}
+	if d.hasTest {
+		name += "-test"
+	}
+	if d.hasBuildScript {
+		name += "-build"
+	}
+	if d.hasYarnLockFile {
+		name += "-yarn"
+	}
+	return name + "-config"
+}
+
+// syncPlatform returns the platform argument of cap sync, empty if both platforms are synced.
+func (d configDescriptor) syncPlatform() string {
+	switch {
+	case d.hasIOS && !d.hasAndroid:
+		return "ios"
+	case d.hasAndroid && !d.hasIOS:
+		return "android"
+	default:
+		return ""
+	}
+}
+
+func generateIOSOptions(result ios.DetectResult, descriptor configDescriptor) (*models.OptionNode, models.Warnings) {
+	var warnings models.Warnings
+
+	projectPathOption := models.NewOption(ios.ProjectPathInputTitle, ios.ProjectPathInputSummary, ios.ProjectPathInputEnvKey, models.TypeSelector)
+	for _, project := range result.Projects {
+		warnings = append(warnings, project.Warnings...)
+
+		schemeOption := models.NewOption(ios.SchemeInputTitle, ios.SchemeInputSummary, ios.SchemeInputEnvKey, models.TypeSelector)
+		projectPathOption.AddOption(project.RelPath, schemeOption)
+
+		for _, scheme := range project.Schemes {
+			exportMethodOption := models.NewOption(ios.DistributionMethodInputTitle, ios.DistributionMethodInputSummary, ios.DistributionMethodEnvKey, models.TypeSelector)
+			schemeOption.AddOption(scheme.Name, exportMethodOption)
+
+			for _, exportMethod := range ios.IosExportMethods {
+				exportMethodOption.AddConfig(exportMethod, models.NewConfigOption(descriptor.configName(), nil))
+			}
+		}
+	}
+
+	return projectPathOption, warnings
+}
+
+// options returns the native project options of a Capacitor project.
+func (scanner *Scanner) options(project project) (models.OptionNode, models.Warnings) {
+	var (
+		rootOption models.OptionNode
+		warnings   models.Warnings
+	)
+
+	descriptor := configDescriptor{
+		hasIOS:          len(project.iosProjects.Projects) > 0,
+		hasAndroid:      project.androidProject != nil,
+		hasTest:         project.hasTest,
+		hasBuildScript:  project.hasBuildScript,
+		hasYarnLockFile: project.hasYarnLockFile,
+	}
+
+	if project.androidProject != nil {
+		androidOptions := models.NewOption(android.ProjectLocationInputTitle, android.ProjectLocationInputSummary, android.ProjectLocationInputEnvKey, models.TypeSelector)
+		rootOption = *androidOptions
+
+		moduleOption := models.NewOption(android.ModuleInputTitle, android.ModuleInputSummary, android.ModuleInputEnvKey, models.TypeUserInput)
+		variantOption := models.NewOption(android.VariantInputTitle, android.VariantInputSummary, android.VariantInputEnvKey, models.TypeOptionalUserInput)
+
+		androidOptions.AddOption(project.androidProject.RootDirEntry.RelPath, moduleOption)
+		moduleOption.AddOption(defaultModule, variantOption)
+
+		if descriptor.hasIOS {
+			iosOptions, iosWarnings := generateIOSOptions(project.iosProjects, descriptor)
+			warnings = append(warnings, iosWarnings...)
+
+			variantOption.AddOption(defaultVariant, iosOptions)
+		} else {
+			variantOption.AddConfig(defaultVariant, models.NewConfigOption(descriptor.configName(), nil))
+		}
+	} else {
+		iosOptions, iosWarnings := generateIOSOptions(project.iosProjects, descriptor)
+		rootOption = *iosOptions
+		warnings = append(warnings, iosWarnings...)
+	}
+
+	scanner.configDescriptors = appendConfigDescriptor(scanner.configDescriptors, descriptor)
+
+	return rootOption, warnings
+}
+
+func (scanner *Scanner) defaultOptions() models.OptionNode {
+	projectRootOption := models.NewOption(projectDirInputTitle, projectDirInputSummary, projectDirInputEnvKey, models.TypeUserInput)
+
+	androidOptions := models.NewOption(android.ProjectLocationInputTitle, android.ProjectLocationInputSummary, android.ProjectLocationInputEnvKey, models.TypeUserInput)
+	moduleOption := models.NewOption(android.ModuleInputTitle, android.ModuleInputSummary, android.ModuleInputEnvKey, models.TypeUserInput)
+	variantOption := models.NewOption(android.VariantInputTitle, android.VariantInputSummary, android.VariantInputEnvKey, models.TypeOptionalUserInput)
+
+	projectRootOption.AddOption(models.UserInputOptionDefaultValue, androidOptions)
+	androidOptions.AddOption("android", moduleOption)
+	moduleOption.AddOption(defaultModule, variantOption)
+
+	projectPathOption := models.NewOption(ios.ProjectPathInputTitle, ios.ProjectPathInputSummary, ios.ProjectPathInputEnvKey, models.TypeUserInput)
+	schemeOption := models.NewOption(ios.SchemeInputTitle, ios.SchemeInputSummary, ios.SchemeInputEnvKey, models.TypeUserInput)
+
+	variantOption.AddOption(defaultVariant, projectPathOption)
+	projectPathOption.AddOption(models.UserInputOptionDefaultValue, schemeOption)
+
+	exportMethodOption := models.NewOption(ios.DistributionMethodInputTitle, ios.DistributionMethodInputSummary, ios.DistributionMethodEnvKey, models.TypeSelector)
+	schemeOption.AddOption(models.UserInputOptionDefaultValue, exportMethodOption)
+	for _, exportMethod := range ios.IosExportMethods {
+		exportMethodOption.AddConfig(exportMethod, models.NewConfigOption(defaultConfigName, nil))
+	}
+
+	return *projectRootOption
+}
+
+func (scanner *Scanner) configs(sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	if len(scanner.configDescriptors) == 0 {
+		return models.BitriseConfigMap{}, fmt.Errorf("invalid state, no config descriptors found")
+	}
+
+	configMap := models.BitriseConfigMap{}
+	for _, descriptor := range scanner.configDescriptors {
+		config, err := generateConfigBasedOn(descriptor, "$"+projectDirInputEnvKey, sshKeyActivation)
+		if err != nil {
+			return models.BitriseConfigMap{}, err
+		}
+		configMap[descriptor.configName()] = config
+	}
+
+	return configMap, nil
+}
+
+func (scanner *Scanner) defaultConfigs() (models.BitriseConfigMap, error) {
+	// Assuming the project uses yarn, has tests and a web build, and both native platforms
+	descriptor := configDescriptor{
+		hasIOS:          true,
+		hasAndroid:      true,
+		hasTest:         true,
+		hasBuildScript:  true,
+		hasYarnLockFile: true,
+	}
+	config, err := generateConfigBasedOn(descriptor, "$"+projectDirInputEnvKey, models.SSHKeyActivationConditional)
+	if err != nil {
+		return models.BitriseConfigMap{}, err
+	}
+
+	return models.BitriseConfigMap{
+		defaultConfigName: config,
+	}, nil
+}
+
+func generateConfigBasedOn(descriptor configDescriptor, workdir string, sshKeyActivation models.SSHKeyActivation) (string, error) {
+	configBuilder := models.NewDefaultConfigBuilder()
+
+	testSteps := getTestSteps(workdir, descriptor.hasYarnLockFile, descriptor.hasTest)
+
+	// ci
+	primaryDescription := primaryWorkflowNoTestsDescription
+	if descriptor.hasTest {
+		primaryDescription = primaryWorkflowDescription
+	}
+
+	configBuilder.SetWorkflowDescriptionTo(models.PrimaryWorkflowID, primaryDescription)
+	configBuilder.AppendStepListItemsTo(models.PrimaryWorkflowID, steps.DefaultPrepareStepList(steps.PrepareListParams{
+		SSHKeyActivation: sshKeyActivation,
+	})...)
+	configBuilder.AppendStepListItemsTo(models.PrimaryWorkflowID, steps.RestoreNPMCache())
+	configBuilder.AppendStepListItemsTo(models.PrimaryWorkflowID, testSteps...)
+	configBuilder.AppendStepListItemsTo(models.PrimaryWorkflowID, steps.SaveNPMCache())
+	configBuilder.AppendStepListItemsTo(models.PrimaryWorkflowID, steps.DefaultDeployStepList()...)
+
+	// cd
+	configBuilder.SetWorkflowDescriptionTo(models.DeployWorkflowID, deployWorkflowDescription)
+	configBuilder.AppendStepListItemsTo(models.DeployWorkflowID, steps.DefaultPrepareStepList(steps.PrepareListParams{
+		SSHKeyActivation: sshKeyActivation,
+	})...)
+	configBuilder.AppendStepListItemsTo(models.DeployWorkflowID, testSteps...)
+	if descriptor.hasBuildScript {
+		configBuilder.AppendStepListItemsTo(models.DeployWorkflowID, getBuildStep(workdir, descriptor.hasYarnLockFile))
+	}
+	configBuilder.AppendStepListItemsTo(models.DeployWorkflowID, steps.ScriptStepListItem(
+		"Sync native projects",
+		fmt.Sprintf(capSyncScriptTemplate, descriptor.syncPlatform()),
+		envmanModels.EnvironmentItemModel{"working_dir": workdir},
+	))
+
+	// android cd
+	if descriptor.hasAndroid {
+		projectLocationEnv := "$" + android.ProjectLocationInputEnvKey
+
+		configBuilder.AppendStepListItemsTo(models.DeployWorkflowID, steps.InstallMissingAndroidToolsStepListItem(
+			envmanModels.EnvironmentItemModel{android.GradlewPathInputKey: "$" + android.ProjectLocationInputEnvKey + "/gradlew"},
+		))
+		configBuilder.AppendStepListItemsTo(models.DeployWorkflowID, steps.AndroidBuildStepListItem(
+			envmanModels.EnvironmentItemModel{android.ProjectLocationInputKey: projectLocationEnv},
+			envmanModels.EnvironmentItemModel{android.ModuleInputKey: "$" + android.ModuleInputEnvKey},
+			envmanModels.EnvironmentItemModel{android.VariantInputKey: "$" + android.VariantInputEnvKey},
+		))
+	}
+
+	// ios cd
+	if descriptor.hasIOS {
+		configBuilder.AppendStepListItemsTo(models.DeployWorkflowID, steps.XcodeArchiveStepListItem(
+			envmanModels.EnvironmentItemModel{ios.ProjectPathInputKey: "$" + ios.ProjectPathInputEnvKey},
+			envmanModels.EnvironmentItemModel{ios.SchemeInputKey: "$" + ios.SchemeInputEnvKey},
+			envmanModels.EnvironmentItemModel{ios.DistributionMethodInputKey: "$" + ios.DistributionMethodEnvKey},
+			envmanModels.EnvironmentItemModel{ios.ConfigurationInputKey: "Release"},
+			envmanModels.EnvironmentItemModel{ios.AutomaticCodeSigningInputKey: ios.AutomaticCodeSigningInputAPIKeyValue},
+		))
+	}
+
+	configBuilder.AppendStepListItemsTo(models.DeployWorkflowID, steps.DefaultDeployStepList()...)
+
+	bitriseDataModel, err := configBuilder.Generate(scannerName)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := yaml.Marshal(bitriseDataModel)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func getTestSteps(workDir string, hasYarnLockFile, hasTest bool) []bitriseModels.StepListItemModel {
+	var testSteps []bitriseModels.StepListItemModel
+
+	if hasYarnLockFile {
+		testSteps = append(testSteps, steps.YarnStepListItem("install", workDir))
+		if hasTest {
+			testSteps = append(testSteps, steps.YarnStepListItem("test", workDir))
+		}
+	} else {
+		testSteps = append(testSteps, steps.NpmStepListItem("install", workDir))
+		if hasTest {
+			testSteps = append(testSteps, steps.NpmStepListItem("test", workDir))
+		}
+	}
+
+	return testSteps
+}
+
+// getBuildStep returns the step running the build script of the package.json, which builds the web assets into the webDir.
+func getBuildStep(workDir string, hasYarnLockFile bool) bitriseModels.StepListItemModel {
+	if hasYarnLockFile {
+		return steps.YarnStepListItem("build", workDir)
+	}
+	return steps.NpmStepListItem("run build", workDir)
+}
+
+func appendConfigDescriptor(descriptors []configDescriptor, descriptor configDescriptor) []configDescriptor {
+	for _, d := range descriptors {
+		if d.configName() == descriptor.configName() {
+			return descriptors
+		}
+	}
+	return append(descriptors, descriptor)
+}
@@ -0,0 +1,87 @@
+// Package crossplatform contains the helpers shared by the cross-platform scanners, like React Native and Capacitor.
+// It is not part of the utility package, as it depends on the ios and android scanners, which import utility.
+package crossplatform
+
+import (
+	"path/filepath"
+
+	"github.com/bitrise-io/bitrise-init/detectors/gradle"
+	"github.com/bitrise-io/bitrise-init/scanners/android"
+	"github.com/bitrise-io/bitrise-init/scanners/ios"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+)
+
+// DetectNativeProjects runs the ios and android detection on the ios and android dirs of a cross-platform project.
+// The returned project paths are relative to the search dir, relProjectDir is the project dir relative to the search dir.
+func DetectNativeProjects(projectDir, relProjectDir string) (ios.DetectResult, *gradle.Project) {
+	var (
+		iosScanner     = ios.NewScanner()
+		androidScanner = android.NewScanner()
+	)
+	iosScanner.ExcludeAppIcon = true
+	iosScanner.SuppressPodFileParseError = true
+
+	isIOSProject, iosProjects, err := hasNativeIOSProject(projectDir, iosScanner)
+	if err != nil {
+		log.TWarnf("failed to check native iOS projects: %s", err)
+	}
+	log.TPrintf("Found native ios project: %v", isIOSProject)
+
+	isAndroidProject, androidProject, err := hasNativeAndroidProject(projectDir, androidScanner)
+	if err != nil {
+		log.TWarnf("failed to check native Android projects: %s", err)
+	}
+	log.TPrintf("Found native android project: %v", isAndroidProject)
+
+	// Update native projects paths relative to search dir (otherwise would be relative to the project dir).
+	var newIosProjects []ios.Project
+	for _, p := range iosProjects.Projects {
+		p.RelPath = filepath.Join(relProjectDir, p.RelPath)
+		newIosProjects = append(newIosProjects, p)
+	}
+	iosProjects.Projects = newIosProjects
+
+	if androidProject != nil {
+		androidProject.RootDirEntry.RelPath = filepath.Join(relProjectDir, androidProject.RootDirEntry.RelPath)
+	}
+
+	return iosProjects, androidProject
+}
+
+func hasNativeIOSProject(projectDir string, iosScanner *ios.Scanner) (bool, ios.DetectResult, error) {
+	absProjectDir, err := pathutil.AbsPath(projectDir)
+	if err != nil {
+		return false, ios.DetectResult{}, err
+	}
+
+	iosDir := filepath.Join(absProjectDir, "ios")
+	if exist, err := pathutil.IsDirExists(iosDir); err != nil || !exist {
+		return false, ios.DetectResult{}, err
+	}
+
+	detected, err := iosScanner.DetectPlatform(projectDir)
+
+	return detected, iosScanner.DetectResult, err
+}
+
+func hasNativeAndroidProject(projectDir string, androidScanner *android.Scanner) (bool, *gradle.Project, error) {
+	absProjectDir, err := pathutil.AbsPath(projectDir)
+	if err != nil {
+		return false, nil, err
+	}
+
+	androidDir := filepath.Join(absProjectDir, "android")
+	if exist, err := pathutil.IsDirExists(androidDir); err != nil || !exist {
+		return false, nil, err
+	}
+
+	if detected, err := androidScanner.DetectPlatform(projectDir); err != nil || !detected {
+		return false, nil, err
+	}
+	if len(androidScanner.Results) == 0 {
+		return false, nil, err
+	}
+
+	return true, &(androidScanner.Results[0].GradleProject), nil
+}
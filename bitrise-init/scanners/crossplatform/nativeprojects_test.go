@@ -0,0 +1,60 @@
+package crossplatform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for pth, content := range files {
+		pth = filepath.Join(dir, pth)
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+		require.NoError(t, os.WriteFile(pth, []byte(content), 0755))
+	}
+}
+
+func TestDetectNativeProjects(t *testing.T) {
+	tests := []struct {
+		name               string
+		files              map[string]string
+		wantIOSProjects    int
+		wantAndroidRelPath string
+	}{
+		{
+			name:  "no native projects",
+			files: map[string]string{"app/package.json": "{}"},
+		},
+		{
+			name: "android project",
+			files: map[string]string{
+				"app/package.json":              "{}",
+				"app/android/gradlew":           "#!/bin/sh",
+				"app/android/settings.gradle":   "include ':app'",
+				"app/android/build.gradle":      "",
+				"app/android/app/build.gradle":  "plugins {\n    id 'com.android.application'\n}\n",
+				"other/android/gradlew":         "#!/bin/sh",
+				"other/android/settings.gradle": "include ':app'",
+			},
+			wantAndroidRelPath: filepath.Join("app", "android"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchDir := t.TempDir()
+			writeFiles(t, searchDir, tt.files)
+			t.Chdir(searchDir)
+
+			iosProjects, androidProject := DetectNativeProjects(filepath.Join(searchDir, "app"), "app")
+			require.Len(t, iosProjects.Projects, tt.wantIOSProjects)
+			if tt.wantAndroidRelPath == "" {
+				require.Nil(t, androidProject)
+				return
+			}
+			require.NotNil(t, androidProject)
+			require.Equal(t, tt.wantAndroidRelPath, androidProject.RootDirEntry.RelPath)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package dotnet
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/steps"
+)
+
+const (
+	buildWorkflowID    = models.WorkflowID("build")
+	runTestsWorkflowID = models.WorkflowID("run_tests")
+
+	nugetCacheKey   = `nuget-{{ checksum "**/*.csproj" }}`
+	nugetCachePaths = "~/.nuget/packages"
+
+	restoreScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+dotnet workload restore "$` + projectInputEnvKey + `"
+dotnet restore "$` + projectInputEnvKey + `"
+`
+
+	buildScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+dotnet build "$` + projectInputEnvKey + `" --no-restore -c "$` + configurationInputEnvKey + `" -f "$` + targetFrameworkInputEnvKey + `"
+`
+
+	testScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+dotnet test "$` + solutionInputEnvKey + `" -c "$` + configurationInputEnvKey + `"
+`
+
+	publishScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+dotnet publish "$` + projectInputEnvKey + `" -c "$` + configurationInputEnvKey + `" -f "$` + targetFrameworkInputEnvKey + `" -o "$BITRISE_DEPLOY_DIR"
+`
+
+	xamarinRestoreScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+msbuild "$` + projectInputEnvKey + `" -t:Restore
+`
+
+	xamarinIOSPublishScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+msbuild "$` + projectInputEnvKey + `" -p:Configuration="$` + configurationInputEnvKey + `" -p:Platform=iPhone -p:BuildIpa=true -p:IpaPackageDir="$BITRISE_DEPLOY_DIR"
+`
+
+	xamarinAndroidPublishScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+msbuild "$` + projectInputEnvKey + `" -t:SignAndroidPackage -p:Configuration="$` + configurationInputEnvKey + `"
+find "$(dirname "$` + projectInputEnvKey + `")/bin/$` + configurationInputEnvKey + `" -name "*-Signed.apk" -exec cp {} "$BITRISE_DEPLOY_DIR" \;
+`
+)
+
+type configDescriptor struct {
+	platform  string
+	xamarin   bool
+	hasTests  bool
+	isDefault bool
+}
+
+func createConfigDescriptor(sln solution, framework targetFramework, isDefault bool) configDescriptor {
+	return configDescriptor{
+		platform: framework.platform,
+		xamarin:  framework.xamarin,
+		// Xamarin solutions can't be built with the dotnet CLI, their tests are not run
+		hasTests:  !framework.xamarin && len(sln.testProjects()) > 0,
+		isDefault: isDefault,
+	}
+}
+
+func createDefaultConfigDescriptor(platform string) configDescriptor {
+	return configDescriptor{
+		platform:  platform,
+		hasTests:  true,
+		isDefault: true,
+	}
+}
+
+func generateOptions(solutions []solution, projectIcons map[string]models.Icons) (models.OptionNode, models.Warnings, models.Icons, error) {
+	if len(solutions) == 0 {
+		return models.OptionNode{}, nil, nil, fmt.Errorf("no .NET MAUI or Xamarin app project found")
+	}
+
+	var allIcons models.Icons
+	solutionOption := models.NewOption(solutionInputTitle, solutionInputSummary, solutionInputEnvKey, models.TypeSelector)
+	for _, sln := range solutions {
+		projectOption := models.NewOption(projectInputTitle, projectInputSummary, projectInputEnvKey, models.TypeSelector)
+		solutionOption.AddOption(sln.relPath, projectOption)
+
+		for _, project := range sln.appProjects() {
+			icons := projectIcons[project.relPath]
+			allIcons = append(allIcons, icons...)
+			iconIDs := make([]string, len(icons))
+			for i, icon := range icons {
+				iconIDs[i] = icon.Filename
+			}
+
+			configurationOption := models.NewOption(configurationInputTitle, configurationInputSummary, configurationInputEnvKey, models.TypeSelector)
+			projectOption.AddOption(project.relPath, configurationOption)
+
+			for _, configuration := range sln.configurations {
+				targetFrameworkOption := models.NewOption(targetFrameworkInputTitle, targetFrameworkInputSummary, targetFrameworkInputEnvKey, models.TypeSelector)
+				configurationOption.AddOption(configuration, targetFrameworkOption)
+
+				for _, framework := range project.targetFrameworks {
+					descriptor := createConfigDescriptor(sln, framework, false)
+					targetFrameworkOption.AddConfig(framework.name, models.NewConfigOption(configName(descriptor), iconIDs))
+				}
+			}
+		}
+	}
+
+	return *solutionOption, nil, allIcons, nil
+}
+
+func generateConfigs(solutions []solution, sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	if len(solutions) == 0 {
+		return models.BitriseConfigMap{}, fmt.Errorf("no .NET MAUI or Xamarin app project found")
+	}
+
+	configs := models.BitriseConfigMap{}
+	for _, sln := range solutions {
+		for _, project := range sln.appProjects() {
+			for _, framework := range project.targetFrameworks {
+				descriptor := createConfigDescriptor(sln, framework, false)
+				config, err := generateConfigBasedOn(descriptor, sshKeyActivation)
+				if err != nil {
+					return nil, err
+				}
+				configs[configName(descriptor)] = config
+			}
+		}
+	}
+	return configs, nil
+}
+
+func configName(d configDescriptor) string {
+	framework := "maui"
+	if d.xamarin {
+		framework = "xamarin"
+	}
+
+	if d.isDefault {
+		return "default-dotnet-" + framework + "-" + d.platform + "-config"
+	}
+
+	name := "dotnet-" + framework + "-" + d.platform
+	if d.hasTests {
+		name += "-test"
+	}
+	return name + "-config"
+}
+
+func generateConfigBasedOn(d configDescriptor, sshKey models.SSHKeyActivation) (string, error) {
+	configBuilder := models.NewDefaultConfigBuilder()
+
+	restoreScript := restoreScriptContent
+	publishScript := publishScriptContent
+	if d.xamarin {
+		restoreScript = xamarinRestoreScriptContent
+		publishScript = xamarinIOSPublishScriptContent
+		if d.platform == platformAndroid {
+			publishScript = xamarinAndroidPublishScriptContent
+		}
+	}
+
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.DefaultPrepareStepList(steps.PrepareListParams{SSHKeyActivation: sshKey})...)
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.RestoreCache(nugetCacheKey))
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.ScriptStepListItem("Restore", restoreScript))
+	if d.platform != platformAndroid {
+		configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.CertificateAndProfileInstallerStepListItem())
+	}
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.ScriptStepListItem("Publish", publishScript))
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.SaveCache(nugetCacheKey, nugetCachePaths))
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.DefaultDeployStepList()...)
+
+	if d.hasTests {
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.DefaultPrepareStepList(steps.PrepareListParams{SSHKeyActivation: sshKey})...)
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.RestoreCache(nugetCacheKey))
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Restore", restoreScript))
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Build", buildScriptContent))
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Run tests", testScriptContent))
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.SaveCache(nugetCacheKey, nugetCachePaths))
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.DefaultDeployStepList()...)
+	}
+
+	bitriseConfig, err := configBuilder.Generate(scannerName)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := yaml.Marshal(bitriseConfig)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,62 @@
+package dotnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigName(t *testing.T) {
+	tests := []struct {
+		name       string
+		descriptor configDescriptor
+		want       string
+	}{
+		{
+			name:       "default MAUI iOS config",
+			descriptor: configDescriptor{platform: platformIOS, isDefault: true},
+			want:       "default-dotnet-maui-ios-config",
+		},
+		{
+			name:       "MAUI Android with tests",
+			descriptor: configDescriptor{platform: platformAndroid, hasTests: true},
+			want:       "dotnet-maui-android-test-config",
+		},
+		{
+			name:       "Xamarin iOS",
+			descriptor: configDescriptor{platform: platformIOS, xamarin: true},
+			want:       "dotnet-xamarin-ios-config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, configName(tt.descriptor))
+		})
+	}
+}
+
+func TestCreateConfigDescriptor(t *testing.T) {
+	sln := solution{projects: []csproj{{relPath: "App.Tests/App.Tests.csproj", isTest: true}}}
+
+	tests := []struct {
+		name      string
+		framework targetFramework
+		want      configDescriptor
+	}{
+		{
+			name:      "MAUI runs the tests",
+			framework: targetFramework{name: "net8.0-ios", platform: platformIOS},
+			want:      configDescriptor{platform: platformIOS, hasTests: true},
+		},
+		{
+			name:      "Xamarin doesn't run the tests",
+			framework: targetFramework{name: xamarinAndroidFramework, platform: platformAndroid, xamarin: true},
+			want:      configDescriptor{platform: platformAndroid, xamarin: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, createConfigDescriptor(sln, tt.framework, false))
+		})
+	}
+}
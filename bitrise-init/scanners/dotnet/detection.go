@@ -0,0 +1,295 @@
+package dotnet
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/beevik/etree"
+	"github.com/bitrise-io/bitrise-init/metrics"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-io/go-utils/sliceutil"
+)
+
+const (
+	solutionExtension = ".sln"
+	projectExtension  = ".csproj"
+
+	platformIOS         = "ios"
+	platformAndroid     = "android"
+	platformMacCatalyst = "maccatalyst"
+
+	// Target framework identifiers of the Xamarin platforms, used as target framework option values of Xamarin projects
+	xamarinIOSFramework     = "Xamarin.iOS"
+	xamarinAndroidFramework = "MonoAndroid"
+)
+
+// defaultConfigurations are used when the solution doesn't list its configurations, or for projects without a solution.
+var defaultConfigurations = []string{"Debug", "Release"}
+
+// mobilePlatforms are the platforms of the target frameworks which can be built on Bitrise.
+var mobilePlatforms = []string{platformIOS, platformAndroid, platformMacCatalyst}
+
+// xamarinTargetsImports map the MSBuild targets imported by Xamarin projects to their platform and target framework.
+var xamarinTargetsImports = map[string]targetFramework{
+	"Xamarin.iOS.CSharp.targets":     {name: xamarinIOSFramework, platform: platformIOS, xamarin: true},
+	"Xamarin.Android.CSharp.targets": {name: xamarinAndroidFramework, platform: platformAndroid, xamarin: true},
+}
+
+var testPackageReferences = []string{
+	"Microsoft.NET.Test.Sdk",
+	"xunit",
+	"NUnit",
+	"MSTest.TestFramework",
+}
+
+var (
+	solutionProjectPattern       = regexp.MustCompile(`(?m)^Project\("\{[^}]+\}"\)\s*=\s*"[^"]*",\s*"([^"]+\.csproj)"`)
+	solutionConfigurationPattern = regexp.MustCompile(`(?m)^\s*([^|=\s][^|=]*)\|[^=]+=`)
+)
+
+type targetFramework struct {
+	name     string // like net8.0-ios or Xamarin.iOS
+	platform string
+	xamarin  bool
+}
+
+type csproj struct {
+	relPath          string
+	targetFrameworks []targetFramework // mobile target frameworks only
+	isTest           bool
+	iconPaths        []string // relative to the project dir
+}
+
+func (p csproj) isApp() bool {
+	return !p.isTest && len(p.targetFrameworks) > 0
+}
+
+type solution struct {
+	relPath        string // the .sln file, or the .csproj file of a project without a solution
+	configurations []string
+	projects       []csproj
+}
+
+func (s solution) appProjects() []csproj {
+	var projects []csproj
+	for _, p := range s.projects {
+		if p.isApp() {
+			projects = append(projects, p)
+		}
+	}
+	return projects
+}
+
+func (s solution) testProjects() []csproj {
+	var projects []csproj
+	for _, p := range s.projects {
+		if p.isTest {
+			projects = append(projects, p)
+		}
+	}
+	return projects
+}
+
+var excludeFilters = []pathutil.FilterFunc{
+	pathutil.ComponentFilter(".git", false),
+	pathutil.ComponentFilter("node_modules", false),
+	pathutil.ComponentFilter("bin", false),
+	pathutil.ComponentFilter("obj", false),
+}
+
+// collectSolutions returns the solutions of the search dir with at least one MAUI or Xamarin app project.
+// Projects which are not part of any solution are returned as a solution of their own.
+// Paths are relative to the searchDir.
+func collectSolutions(searchDir string) ([]solution, error) {
+	fileList, err := pathutil.ListPathInDirSortedByComponents(searchDir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	solutionPaths, err := pathutil.FilterPaths(fileList, append(excludeFilters, pathutil.ExtensionFilter(solutionExtension, true))...)
+	if err != nil {
+		return nil, err
+	}
+	projectPaths, err := pathutil.FilterPaths(fileList, append(excludeFilters, pathutil.ExtensionFilter(projectExtension, true))...)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := map[string]csproj{}
+	for _, pth := range projectPaths {
+		project, err := parseProjectFile(searchDir, pth)
+		if err != nil {
+			log.TWarnf("Failed to parse %s: %s", pth, err)
+			continue
+		}
+		projects[pth] = project
+	}
+
+	var solutions []solution
+	var projectsInSolution []string
+	for _, pth := range solutionPaths {
+		content, err := metrics.ReadStringFromFile(filepath.Join(searchDir, pth))
+		if err != nil {
+			return nil, err
+		}
+
+		sln := solution{relPath: pth, configurations: parseSolutionConfigurations(content)}
+		for _, projectRelPath := range parseSolutionProjects(content) {
+			projectPth := filepath.Join(filepath.Dir(pth), projectRelPath)
+			project, ok := projects[projectPth]
+			if !ok {
+				continue
+			}
+			sln.projects = append(sln.projects, project)
+			projectsInSolution = append(projectsInSolution, projectPth)
+		}
+
+		if len(sln.appProjects()) > 0 {
+			solutions = append(solutions, sln)
+		}
+	}
+
+	for _, pth := range projectPaths {
+		project, ok := projects[pth]
+		if !ok || !project.isApp() || sliceutil.IsStringInSlice(pth, projectsInSolution) {
+			continue
+		}
+		solutions = append(solutions, solution{relPath: pth, configurations: defaultConfigurations, projects: []csproj{project}})
+	}
+
+	sort.Slice(solutions, func(i, j int) bool {
+		return solutions[i].relPath < solutions[j].relPath
+	})
+	return solutions, nil
+}
+
+// parseSolutionProjects returns the C# project paths of a solution, relative to the solution dir.
+func parseSolutionProjects(content string) []string {
+	var paths []string
+	for _, match := range solutionProjectPattern.FindAllStringSubmatch(content, -1) {
+		paths = append(paths, filepath.FromSlash(strings.ReplaceAll(match[1], `\`, "/")))
+	}
+	return paths
+}
+
+// parseSolutionConfigurations returns the configuration names of the SolutionConfigurationPlatforms section.
+func parseSolutionConfigurations(content string) []string {
+	start := strings.Index(content, "GlobalSection(SolutionConfigurationPlatforms)")
+	if start == -1 {
+		return defaultConfigurations
+	}
+	section := content[start:]
+	if end := strings.Index(section, "EndGlobalSection"); end != -1 {
+		section = section[:end]
+	}
+	// Skip the section header line
+	if newLine := strings.Index(section, "\n"); newLine != -1 {
+		section = section[newLine+1:]
+	}
+
+	var configurations []string
+	for _, match := range solutionConfigurationPattern.FindAllStringSubmatch(section, -1) {
+		configuration := strings.TrimSpace(match[1])
+		if !sliceutil.IsStringInSlice(configuration, configurations) {
+			configurations = append(configurations, configuration)
+		}
+	}
+	if len(configurations) == 0 {
+		return defaultConfigurations
+	}
+	return configurations
+}
+
+func parseProjectFile(searchDir, relPath string) (csproj, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromFile(filepath.Join(searchDir, relPath)); err != nil {
+		return csproj{}, err
+	}
+	metrics.FileRead()
+
+	project := csproj{relPath: relPath}
+	project.targetFrameworks = parseTargetFrameworks(doc)
+	project.isTest = isTestProject(doc)
+	project.iconPaths = parseMauiIcons(doc)
+	return project, nil
+}
+
+// parseTargetFrameworks returns the mobile target frameworks of an SDK style project,
+// or the Xamarin platform of a legacy Xamarin project.
+func parseTargetFrameworks(doc *etree.Document) []targetFramework {
+	var frameworks []targetFramework
+	var names []string
+	for _, tag := range []string{"TargetFrameworks", "TargetFramework"} {
+		for _, element := range doc.FindElements("//" + tag) {
+			for _, name := range strings.Split(element.Text(), ";") {
+				name = strings.TrimSpace(name)
+				// Properties, like $(TargetFrameworks), are not resolved
+				if name == "" || strings.Contains(name, "$(") || sliceutil.IsStringInSlice(name, names) {
+					continue
+				}
+				names = append(names, name)
+
+				platform := targetFrameworkPlatform(name)
+				if sliceutil.IsStringInSlice(platform, mobilePlatforms) {
+					frameworks = append(frameworks, targetFramework{name: name, platform: platform})
+				}
+			}
+		}
+	}
+
+	for _, element := range doc.FindElements("//Import") {
+		imported := strings.ReplaceAll(element.SelectAttrValue("Project", ""), `\`, "/")
+		if framework, ok := xamarinTargetsImports[filepath.Base(imported)]; ok {
+			frameworks = append(frameworks, framework)
+		}
+	}
+
+	return frameworks
+}
+
+// targetFrameworkPlatform returns the platform of a target framework moniker, like ios for net8.0-ios17.0.
+func targetFrameworkPlatform(name string) string {
+	split := strings.SplitN(name, "-", 2)
+	if len(split) != 2 {
+		return ""
+	}
+	platform := strings.ToLower(split[1])
+	for _, mobilePlatform := range mobilePlatforms {
+		if strings.HasPrefix(platform, mobilePlatform) {
+			return mobilePlatform
+		}
+	}
+	return platform
+}
+
+func isTestProject(doc *etree.Document) bool {
+	for _, element := range doc.FindElements("//IsTestProject") {
+		if strings.EqualFold(strings.TrimSpace(element.Text()), "true") {
+			return true
+		}
+	}
+	for _, element := range doc.FindElements("//PackageReference") {
+		if sliceutil.IsStringInSlice(element.SelectAttrValue("Include", ""), testPackageReferences) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMauiIcons returns the image files of the MauiIcon items, relative to the project dir.
+func parseMauiIcons(doc *etree.Document) []string {
+	var paths []string
+	for _, element := range doc.FindElements("//MauiIcon") {
+		for _, attribute := range []string{"Include", "ForegroundFile"} {
+			pth := element.SelectAttrValue(attribute, "")
+			if pth == "" || strings.Contains(pth, "$(") {
+				continue
+			}
+			paths = append(paths, filepath.FromSlash(strings.ReplaceAll(pth, `\`, "/")))
+		}
+	}
+	return paths
+}
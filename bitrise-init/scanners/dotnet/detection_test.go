@@ -0,0 +1,159 @@
+package dotnet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const mauiProject = `<Project Sdk="Microsoft.NET.Sdk">
+	<PropertyGroup>
+		<TargetFrameworks>net8.0-android;net8.0-ios;net8.0-maccatalyst</TargetFrameworks>
+		<TargetFrameworks Condition="$([MSBuild]::IsOSPlatform('windows'))">$(TargetFrameworks);net8.0-windows10.0.19041.0</TargetFrameworks>
+		<OutputType>Exe</OutputType>
+	</PropertyGroup>
+	<ItemGroup>
+		<MauiIcon Include="Resources\AppIcon\appicon.svg" ForegroundFile="Resources\AppIcon\appiconfg.svg" Color="#512BD4" />
+	</ItemGroup>
+</Project>
+`
+
+const testProject = `<Project Sdk="Microsoft.NET.Sdk">
+	<PropertyGroup>
+		<TargetFramework>net8.0</TargetFramework>
+	</PropertyGroup>
+	<ItemGroup>
+		<PackageReference Include="xunit" Version="2.5.3" />
+	</ItemGroup>
+</Project>
+`
+
+const solutionContent = `Microsoft Visual Studio Solution File, Format Version 12.00
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App", "App\App.csproj", "{0B5F6E3A-1C2D-4E5F-8A9B-0C1D2E3F4A5B}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App.Tests", "App.Tests\App.Tests.csproj", "{1C6A7F4B-2D3E-5F6A-9B0C-1D2E3F4A5B6C}"
+EndProject
+Global
+	GlobalSection(SolutionConfigurationPlatforms) = preSolution
+		Debug|Any CPU = Debug|Any CPU
+		Release|Any CPU = Release|Any CPU
+	EndGlobalSection
+EndGlobal
+`
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for pth, content := range files {
+		pth = filepath.Join(dir, pth)
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+		require.NoError(t, os.WriteFile(pth, []byte(content), 0644))
+	}
+}
+
+func TestCollectSolutions(t *testing.T) {
+	mauiFrameworks := []targetFramework{
+		{name: "net8.0-android", platform: platformAndroid},
+		{name: "net8.0-ios", platform: platformIOS},
+		{name: "net8.0-maccatalyst", platform: platformMacCatalyst},
+	}
+	mauiIcons := []string{filepath.Join("Resources", "AppIcon", "appicon.svg"), filepath.Join("Resources", "AppIcon", "appiconfg.svg")}
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []solution
+	}{
+		{
+			name:  "no app project",
+			files: map[string]string{"Lib/Lib.csproj": testProject},
+		},
+		{
+			name: "solution with app and test projects",
+			files: map[string]string{
+				"App.sln":                        solutionContent,
+				"App/App.csproj":                 mauiProject,
+				"App.Tests/App.Tests.csproj":     testProject,
+				"App/bin/Debug/Generated.csproj": mauiProject,
+			},
+			want: []solution{
+				{
+					relPath:        "App.sln",
+					configurations: []string{"Debug", "Release"},
+					projects: []csproj{
+						{relPath: "App/App.csproj", targetFrameworks: mauiFrameworks, iconPaths: mauiIcons},
+						{relPath: "App.Tests/App.Tests.csproj", isTest: true},
+					},
+				},
+			},
+		},
+		{
+			name: "app project without solution, unparsable project is skipped",
+			files: map[string]string{
+				"App/App.csproj":       mauiProject,
+				"Broken/Broken.csproj": "<Project",
+			},
+			want: []solution{
+				{
+					relPath:        "App/App.csproj",
+					configurations: defaultConfigurations,
+					projects:       []csproj{{relPath: "App/App.csproj", targetFrameworks: mauiFrameworks, iconPaths: mauiIcons}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchDir := t.TempDir()
+			writeFiles(t, searchDir, tt.files)
+			t.Chdir(searchDir)
+
+			got, err := collectSolutions(searchDir)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLookupIcons(t *testing.T) {
+	tests := []struct {
+		name      string
+		iconPaths []string
+		files     map[string]string
+		wantPaths []string
+	}{
+		{
+			name:      "SVG icons of the MAUI template are skipped",
+			iconPaths: []string{"Resources/AppIcon/appicon.svg", "Resources/AppIcon/appiconfg.svg"},
+			files:     map[string]string{"App/Resources/AppIcon/appicon.svg": "<svg/>", "App/Resources/AppIcon/appiconfg.svg": "<svg/>"},
+		},
+		{
+			name:      "PNG icon",
+			iconPaths: []string{"Resources/AppIcon/appicon.png", "Resources/AppIcon/missing.png"},
+			files:     map[string]string{"App/Resources/AppIcon/appicon.png": ""},
+			wantPaths: []string{"App/Resources/AppIcon/appicon.png"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchDir := t.TempDir()
+			writeFiles(t, searchDir, tt.files)
+
+			icons, err := lookupIcons(searchDir, csproj{relPath: "App/App.csproj", iconPaths: tt.iconPaths})
+			require.NoError(t, err)
+
+			var gotPaths []string
+			for _, icon := range icons {
+				relPath, err := filepath.Rel(searchDir, icon.Path)
+				require.NoError(t, err)
+				gotPaths = append(gotPaths, relPath)
+			}
+			require.Equal(t, tt.wantPaths, gotPaths)
+		})
+	}
+}
+
+func TestScanner_DetectPlatform_error(t *testing.T) {
+	_, err := NewScanner().DetectPlatform(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+}
@@ -0,0 +1,196 @@
+package dotnet
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/utility"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+const (
+	scannerName = "dotnet"
+
+	solutionInputTitle   = "Solution"
+	solutionInputSummary = "The .sln file of the solution, or the .csproj file of an app project without a solution"
+	solutionInputEnvKey  = "DOTNET_SOLUTION"
+
+	projectInputTitle   = "App project"
+	projectInputSummary = "The .csproj file of the .NET MAUI or Xamarin app to build"
+	projectInputEnvKey  = "DOTNET_PROJECT"
+
+	configurationInputTitle   = "Configuration"
+	configurationInputSummary = "The build configuration, like Debug or Release"
+	configurationInputEnvKey  = "DOTNET_CONFIGURATION"
+
+	targetFrameworkInputTitle   = "Target framework"
+	targetFrameworkInputSummary = "The target framework of the app project to build, like net8.0-ios or net8.0-android"
+	targetFrameworkInputEnvKey  = "DOTNET_TARGET_FRAMEWORK"
+
+	platformInputTitle   = "Platform"
+	platformInputSummary = "The platform of the app to build"
+)
+
+var defaultPlatforms = []string{platformIOS, platformAndroid}
+
+// Scanner implements ScannerInterface for .NET MAUI and Xamarin projects.
+type Scanner struct {
+	searchDir string
+	solutions []solution // populated by DetectPlatform
+}
+
+// NewScanner creates a new Scanner instance.
+func NewScanner() *Scanner {
+	return &Scanner{}
+}
+
+// Name returns the scanner name.
+func (s *Scanner) Name() string {
+	return scannerName
+}
+
+// DetectPlatform checks whether searchDir contains a .NET MAUI or Xamarin app project.
+func (s *Scanner) DetectPlatform(searchDir string) (bool, error) {
+	s.searchDir = searchDir
+
+	solutions, err := collectSolutions(searchDir)
+	if err != nil {
+		return false, fmt.Errorf("failed to collect .NET solutions: %w", err)
+	}
+
+	for _, sln := range solutions {
+		log.TPrintf("Solution found: %s", sln.relPath)
+		for _, project := range sln.appProjects() {
+			log.TPrintf("- app project: %s (%s)", project.relPath, strings.Join(targetFrameworkNames(project), ", "))
+		}
+		for _, project := range sln.testProjects() {
+			log.TPrintf("- test project: %s", project.relPath)
+		}
+	}
+	s.solutions = solutions
+
+	if len(s.solutions) == 0 {
+		log.TPrintf("Platform not detected")
+		return false, nil
+	}
+
+	log.TSuccessf("Platform detected")
+	return true, nil
+}
+
+// Evidence returns the solutions and the app and test projects found in them.
+func (s *Scanner) Evidence() models.Evidences {
+	var evidences models.Evidences
+	for _, sln := range s.solutions {
+		if filepath.Ext(sln.relPath) == solutionExtension {
+			evidences.Add(sln.relPath, "Visual Studio solution")
+		}
+		for _, project := range sln.appProjects() {
+			evidences.Add(project.relPath, fmt.Sprintf("target frameworks: %s", strings.Join(targetFrameworkNames(project), ", ")))
+		}
+		for _, project := range sln.testProjects() {
+			evidences.Add(project.relPath, "test project")
+		}
+	}
+	return evidences
+}
+
+// ProjectRoots returns the solution dirs, keyed by the solution option value.
+func (s *Scanner) ProjectRoots() []models.ProjectRoot {
+	var roots []models.ProjectRoot
+	for _, sln := range s.solutions {
+		roots = append(roots, models.ProjectRoot{Dir: filepath.Dir(sln.relPath), OptionValue: sln.relPath})
+	}
+	return roots
+}
+
+// ExcludedScannerNames returns scanners to skip when this scanner detects.
+func (s *Scanner) ExcludedScannerNames() []string {
+	return []string{}
+}
+
+// Options builds the option tree of the solutions, their app projects, configurations and target frameworks.
+func (s *Scanner) Options() (models.OptionNode, models.Warnings, models.Icons, error) {
+	projectIcons := map[string]models.Icons{}
+	for _, sln := range s.solutions {
+		for _, project := range sln.appProjects() {
+			icons, err := lookupIcons(s.searchDir, project)
+			if err != nil {
+				log.TWarnf("Failed to find icons of %s: %s", project.relPath, err)
+				continue
+			}
+			projectIcons[project.relPath] = icons
+		}
+	}
+
+	return generateOptions(s.solutions, projectIcons)
+}
+
+// Configs generates the pre-made bitrise.yml templates for each platform of the detected app projects.
+func (s *Scanner) Configs(sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	return generateConfigs(s.solutions, sshKeyActivation)
+}
+
+// DefaultOptions returns the option tree for the manual configuration flow.
+func (s *Scanner) DefaultOptions() models.OptionNode {
+	solutionOption := models.NewOption(solutionInputTitle, solutionInputSummary, solutionInputEnvKey, models.TypeUserInput)
+	projectOption := models.NewOption(projectInputTitle, projectInputSummary, projectInputEnvKey, models.TypeUserInput)
+	configurationOption := models.NewOption(configurationInputTitle, configurationInputSummary, configurationInputEnvKey, models.TypeUserInput)
+	platformOption := models.NewOption(platformInputTitle, platformInputSummary, "", models.TypeSelector)
+
+	solutionOption.AddOption(models.UserInputOptionDefaultValue, projectOption)
+	projectOption.AddOption(models.UserInputOptionDefaultValue, configurationOption)
+	configurationOption.AddOption(models.UserInputOptionDefaultValue, platformOption)
+
+	for _, platform := range defaultPlatforms {
+		targetFrameworkOption := models.NewOption(targetFrameworkInputTitle, targetFrameworkInputSummary, targetFrameworkInputEnvKey, models.TypeUserInput)
+		platformOption.AddOption(platform, targetFrameworkOption)
+
+		descriptor := createDefaultConfigDescriptor(platform)
+		targetFrameworkOption.AddConfig(models.UserInputOptionDefaultValue, models.NewConfigOption(configName(descriptor), nil))
+	}
+
+	return *solutionOption
+}
+
+// DefaultConfigs generates the static bitrise.yml templates for the manual configuration flow.
+func (s *Scanner) DefaultConfigs() (models.BitriseConfigMap, error) {
+	configs := models.BitriseConfigMap{}
+	for _, platform := range defaultPlatforms {
+		descriptor := createDefaultConfigDescriptor(platform)
+		config, err := generateConfigBasedOn(descriptor, models.SSHKeyActivationConditional)
+		if err != nil {
+			return nil, err
+		}
+		configs[configName(descriptor)] = config
+	}
+	return configs, nil
+}
+
+// lookupIcons returns the PNG images of the MauiIcon items of a project.
+// SVG icons, like the ones of the MAUI project template, are skipped: they are only converted to PNG during the build.
+func lookupIcons(searchDir string, project csproj) (models.Icons, error) {
+	var iconPaths []string
+	for _, iconPath := range project.iconPaths {
+		pth := filepath.Join(searchDir, filepath.Dir(project.relPath), iconPath)
+		if !strings.EqualFold(filepath.Ext(pth), ".png") {
+			log.TDebugf("Skipping icon %s, only PNG icons are supported", iconPath)
+			continue
+		}
+		if !utility.FileExists(pth) {
+			continue
+		}
+		iconPaths = append(iconPaths, pth)
+	}
+	return utility.CreateIconDescriptors(iconPaths, searchDir)
+}
+
+func targetFrameworkNames(project csproj) []string {
+	var names []string
+	for _, framework := range project.targetFrameworks {
+		names = append(names, framework.name)
+	}
+	return names
+}
@@ -0,0 +1,232 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/steps"
+	envmanModels "github.com/bitrise-io/envman/v2/models"
+)
+
+const (
+	runTestsWorkflowID = models.WorkflowID("run_tests")
+
+	// the module cache is keyed on every go.sum, so the multi-module projects are covered too
+	moduleCacheKey   = `go-{{ checksum "**/go.sum" }}`
+	moduleCachePaths = "~/go/pkg/mod\n~/.cache/go-build"
+
+	goVersionInstallScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+bitrise tools install golang $GO_VERSION
+`
+
+	// golangci-lint releases installed by the configs: v2 reads the v2 config format only, v1 the earlier format only
+	golangciV1Version = "v1.64.8"
+	golangciV2Version = "v2.1.6"
+
+	downloadCommand = "go mod download"
+	testCommand     = "go test ./..."
+	vetCommand      = "go vet ./..."
+	golangciCommand = "golangci-lint run ./..."
+)
+
+const (
+	layoutSingleModule = "single-module"
+	layoutMultiModule  = "multi-module"
+	layoutWorkspace    = "workspace"
+)
+
+type configDescriptor struct {
+	workdir     string
+	layout      string
+	goVersion   string
+	hasTests    bool
+	hasGolangci bool
+	golangciV2  bool
+	isDefault   bool
+}
+
+func createConfigDescriptor(proj project) configDescriptor {
+	d := configDescriptor{
+		workdir:     "$" + projectDirInputEnvKey,
+		layout:      layoutSingleModule,
+		goVersion:   proj.goVersion,
+		hasTests:    len(proj.testPackages) > 0,
+		hasGolangci: proj.golangciFile != "",
+		golangciV2:  proj.golangciV2,
+	}
+	switch {
+	case proj.isWorkspace:
+		d.layout = layoutWorkspace
+	case proj.isMultiModule():
+		d.layout = layoutMultiModule
+	}
+	if proj.relDir == "." {
+		d.workdir = ""
+	}
+	return d
+}
+
+func defaultConfigDescriptor() configDescriptor {
+	return configDescriptor{
+		workdir:   "$" + projectDirInputEnvKey,
+		layout:    layoutSingleModule,
+		hasTests:  true,
+		isDefault: true,
+	}
+}
+
+func generateOptions(projects []project) (models.OptionNode, models.Warnings, models.Icons, error) {
+	if len(projects) == 0 {
+		return models.OptionNode{}, nil, nil, fmt.Errorf("no Go module found")
+	}
+
+	projectDirOption := models.NewOption(projectDirInputTitle, projectDirInputSummary, projectDirInputEnvKey, models.TypeSelector)
+	for _, proj := range projects {
+		configOption := models.NewConfigOption(configName(createConfigDescriptor(proj)), nil)
+		projectDirOption.AddConfig(proj.relDir, configOption)
+	}
+
+	return *projectDirOption, nil, nil, nil
+}
+
+func generateConfigs(projects []project, sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	if len(projects) == 0 {
+		return models.BitriseConfigMap{}, fmt.Errorf("no Go module found")
+	}
+
+	configs := models.BitriseConfigMap{}
+	for _, proj := range projects {
+		descriptor := createConfigDescriptor(proj)
+		config, err := generateConfigBasedOn(descriptor, sshKeyActivation)
+		if err != nil {
+			return nil, err
+		}
+		configs[configName(descriptor)] = config
+	}
+	return configs, nil
+}
+
+func configName(d configDescriptor) string {
+	if d.isDefault {
+		return "default-go-config"
+	}
+
+	name := "go"
+	if d.workdir == "" {
+		name += "-root"
+	}
+	if d.layout != layoutSingleModule {
+		name += "-" + d.layout
+	}
+	if d.goVersion != "" {
+		name += "-go" + d.goVersion
+	}
+	if d.hasTests {
+		name += "-test"
+	}
+	if d.hasGolangci {
+		name += "-golangci-" + d.golangciVersion()
+	}
+	return name + "-config"
+}
+
+func generateConfigBasedOn(d configDescriptor, sshKey models.SSHKeyActivation) (string, error) {
+	configBuilder := models.NewDefaultConfigBuilder()
+
+	if d.goVersion != "" {
+		configBuilder.AddTool("golang", toolVersion(d.goVersion))
+	}
+
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.DefaultPrepareStepList(steps.PrepareListParams{SSHKeyActivation: sshKey})...)
+
+	if d.isDefault {
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Install Go", goVersionInstallScriptContent))
+	}
+
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.RestoreCache(moduleCacheKey))
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Download dependencies", moduleScript(d.layout, downloadCommand), workdirInputs(d.workdir)...))
+	if d.hasGolangci {
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Run golangci-lint", moduleScript(d.layout, golangciCommand, golangciInstallCommand(d.golangciVersion())), workdirInputs(d.workdir)...))
+	} else {
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Run go vet", moduleScript(d.layout, vetCommand), workdirInputs(d.workdir)...))
+	}
+	if d.hasTests {
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Run tests", moduleScript(d.layout, testCommand), workdirInputs(d.workdir)...))
+	}
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.SaveCache(moduleCacheKey, moduleCachePaths))
+
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.DefaultDeployStepList()...)
+
+	bitriseConfig, err := configBuilder.Generate(scannerName)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := yaml.Marshal(bitriseConfig)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// golangciVersion returns the golangci-lint release reading the config format of the project.
+func (d configDescriptor) golangciVersion() string {
+	if d.golangciV2 {
+		return golangciV2Version
+	}
+	return golangciV1Version
+}
+
+// golangciInstallCommand installs the golangci-lint release with the install script of the same release,
+// the script verifies the checksum of the downloaded binary.
+func golangciInstallCommand(version string) string {
+	return fmt.Sprintf(`curl -sSfL https://raw.githubusercontent.com/golangci/golangci-lint/%[1]s/install.sh | sh -s -- -b "$(go env GOPATH)/bin" %[1]s`, version)
+}
+
+// moduleScript returns a script running the command in every module of the project, after the setup commands.
+// The go command only matches the packages of the current module with ./..., so the modules are visited one by one:
+// the modules of a workspace are listed by the go command, the modules of a multi-module repository are searched for.
+func moduleScript(layout, command string, setupCommands ...string) string {
+	script := "#!/usr/bin/env bash\nset -euxo pipefail\n\n"
+	if len(setupCommands) > 0 {
+		script += strings.Join(setupCommands, "\n") + "\n\n"
+	}
+
+	switch layout {
+	case layoutWorkspace:
+		script += fmt.Sprintf(`for module in $(go list -m -f '{{.Dir}}'); do
+  (cd "$module" && %s)
+done
+`, command)
+	case layoutMultiModule:
+		script += fmt.Sprintf(`find . -name go.mod -not -path "*/vendor/*" -not -path "*/testdata/*" -not -path "*/node_modules/*" | while read -r modfile; do
+  (cd "$(dirname "$modfile")" && %s)
+done
+`, command)
+	default:
+		script += command + "\n"
+	}
+	return script
+}
+
+// toolVersion returns the version of the Go tool declaration.
+// A go directive without patch version (go 1.21) is a minimum version, so the latest patch release is used.
+func toolVersion(goVersion string) string {
+	if strings.Count(goVersion, ".") == 1 {
+		return goVersion + ":latest"
+	}
+	return goVersion
+}
+
+func workdirInputs(workdir string) []envmanModels.EnvironmentItemModel {
+	if workdir == "" {
+		return nil
+	}
+	return []envmanModels.EnvironmentItemModel{{"working_dir": workdir}}
+}
@@ -0,0 +1,68 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigName(t *testing.T) {
+	tests := []struct {
+		name       string
+		descriptor configDescriptor
+		want       string
+	}{
+		{
+			name:       "default config",
+			descriptor: defaultConfigDescriptor(),
+			want:       "default-go-config",
+		},
+		{
+			name:       "root module with the Go version and tests",
+			descriptor: configDescriptor{layout: layoutSingleModule, goVersion: "1.22", hasTests: true},
+			want:       "go-root-go1.22-test-config",
+		},
+		{
+			name:       "same layout with an other Go version",
+			descriptor: configDescriptor{layout: layoutSingleModule, goVersion: "1.23.1", hasTests: true},
+			want:       "go-root-go1.23.1-test-config",
+		},
+		{
+			name:       "workspace in a subdir with a v2 golangci-lint config",
+			descriptor: configDescriptor{workdir: "$" + projectDirInputEnvKey, layout: layoutWorkspace, goVersion: "1.23", hasGolangci: true, golangciV2: true},
+			want:       "go-workspace-go1.23-golangci-" + golangciV2Version + "-config",
+		},
+		{
+			name:       "multi-module without Go version and a v1 golangci-lint config",
+			descriptor: configDescriptor{layout: layoutMultiModule, hasGolangci: true},
+			want:       "go-root-multi-module-golangci-" + golangciV1Version + "-config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, configName(tt.descriptor))
+		})
+	}
+}
+
+func TestGolangciInstallCommand(t *testing.T) {
+	require.Equal(t,
+		`curl -sSfL https://raw.githubusercontent.com/golangci/golangci-lint/v2.1.6/install.sh | sh -s -- -b "$(go env GOPATH)/bin" v2.1.6`,
+		golangciInstallCommand("v2.1.6"),
+	)
+}
+
+func TestToolVersion(t *testing.T) {
+	tests := []struct {
+		goVersion string
+		want      string
+	}{
+		{goVersion: "1.22", want: "1.22:latest"},
+		{goVersion: "1.22.3", want: "1.22.3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goVersion, func(t *testing.T) {
+			require.Equal(t, tt.want, toolVersion(tt.goVersion))
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package golang
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/metrics"
+	"github.com/bitrise-io/bitrise-init/utility"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+)
+
+const (
+	modFile  = "go.mod"
+	workFile = "go.work"
+	sumFile  = "go.sum"
+)
+
+// golangciConfigFiles are the config file names golangci-lint looks for in the working directory.
+var golangciConfigFiles = []string{
+	".golangci.yml",
+	".golangci.yaml",
+	".golangci.toml",
+	".golangci.json",
+}
+
+var (
+	goDirectivePattern        = regexp.MustCompile(`(?m)^go\s+(\d+\.\d+(?:\.\d+)?)\s*$`)
+	toolchainDirectivePattern = regexp.MustCompile(`(?m)^toolchain\s+go(\d+\.\d+(?:\.\d+)?)\s*$`)
+	useDirectivePattern       = regexp.MustCompile(`(?m)^use\s+(\S+)\s*$`)
+	useBlockPattern           = regexp.MustCompile(`(?ms)^use\s*\((.*?)\)`)
+	// golangciV2ConfigPattern matches the version field of the golangci-lint v2 config format, in YAML, TOML or JSON
+	golangciV2ConfigPattern = regexp.MustCompile(`(?m)^\s*"?version"?\s*[:=]\s*["']?2["']?\s*,?\s*$`)
+)
+
+var excludeFilters = []pathutil.FilterFunc{
+	pathutil.ComponentFilter(".git", false),
+	pathutil.ComponentFilter("node_modules", false),
+	pathutil.ComponentFilter("vendor", false),
+	pathutil.ComponentFilter("testdata", false),
+}
+
+type project struct {
+	relDir       string
+	isWorkspace  bool     // the project dir has a go.work file
+	modules      []string // module dirs, relative to the project dir
+	goVersion    string
+	testPackages []string // package dirs with _test.go files, relative to the project dir
+	golangciFile string
+	// golangciV2 is true if the golangci-lint config uses the v2 config format
+	golangciV2 bool
+}
+
+func (p project) isMultiModule() bool {
+	return len(p.modules) > 1
+}
+
+// collectProjects groups the Go modules of the search dir into projects.
+// A go.work file makes a project of the modules it uses, other modules belong to the outermost module containing them,
+// so a multi-module repository is one project tested module by module. Paths are relative to the searchDir.
+func collectProjects(searchDir string) ([]project, error) {
+	fileList, err := pathutil.ListPathInDirSortedByComponents(searchDir, true)
+	if err != nil {
+		return nil, err
+	}
+	fileList, err = pathutil.FilterPaths(fileList, excludeFilters...)
+	if err != nil {
+		return nil, err
+	}
+
+	workPaths, err := pathutil.FilterPaths(fileList, pathutil.BaseFilter(workFile, true))
+	if err != nil {
+		return nil, err
+	}
+	modPaths, err := pathutil.FilterPaths(fileList, pathutil.BaseFilter(modFile, true))
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []project
+	workspaceModules := map[string]bool{}
+	for _, workPath := range workPaths {
+		proj := project{relDir: filepath.Dir(workPath), isWorkspace: true}
+
+		content, err := metrics.ReadStringFromFile(filepath.Join(searchDir, workPath))
+		if err != nil {
+			log.TWarnf("Failed to read %s: %s", workPath, err)
+			continue
+		}
+		for _, module := range parseUseDirectives(content) {
+			proj.modules = append(proj.modules, module)
+			workspaceModules[filepath.Join(proj.relDir, module)] = true
+		}
+		proj.goVersion = parseGoVersion(content)
+
+		projects = append(projects, proj)
+	}
+
+	for _, modPath := range modPaths {
+		moduleDir := filepath.Dir(modPath)
+		if workspaceModules[moduleDir] {
+			continue
+		}
+
+		if i := containingProjectIndex(projects, moduleDir); i >= 0 {
+			if projects[i].isWorkspace {
+				log.TWarnf("Module %s is not used by the %s workspace, skipping", moduleDir, filepath.Join(projects[i].relDir, workFile))
+				continue
+			}
+			module, err := filepath.Rel(projects[i].relDir, moduleDir)
+			if err != nil {
+				return nil, err
+			}
+			projects[i].modules = append(projects[i].modules, module)
+			continue
+		}
+
+		projects = append(projects, project{relDir: moduleDir, modules: []string{"."}})
+	}
+
+	for i := range projects {
+		proj := &projects[i]
+		if proj.goVersion == "" {
+			proj.goVersion = readModuleGoVersion(searchDir, *proj)
+		}
+		proj.testPackages = collectTestPackages(fileList, *proj)
+		proj.golangciFile = findGolangciConfig(filepath.Join(searchDir, proj.relDir))
+		if proj.golangciFile != "" {
+			proj.golangciV2 = isGolangciV2Config(filepath.Join(searchDir, proj.relDir, proj.golangciFile))
+		}
+	}
+
+	return projects, nil
+}
+
+// containingProjectIndex returns the index of the project containing the dir, or -1.
+func containingProjectIndex(projects []project, dir string) int {
+	for i, proj := range projects {
+		if isInDir(proj.relDir, dir) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isInDir(dir, pth string) bool {
+	if dir == "." {
+		return true
+	}
+	return pth == dir || strings.HasPrefix(pth, dir+string(filepath.Separator))
+}
+
+// parseUseDirectives returns the module dirs of a go.work file, both the single line and the block form of the use directive.
+func parseUseDirectives(content string) []string {
+	var modules []string
+	for _, match := range useDirectivePattern.FindAllStringSubmatch(content, -1) {
+		if match[1] != "(" {
+			modules = append(modules, filepath.Clean(strings.Trim(match[1], `"`)))
+		}
+	}
+	for _, match := range useBlockPattern.FindAllStringSubmatch(content, -1) {
+		for _, line := range strings.Split(match[1], "\n") {
+			line = strings.TrimSpace(strings.SplitN(line, "//", 2)[0])
+			if line != "" {
+				modules = append(modules, filepath.Clean(strings.Trim(line, `"`)))
+			}
+		}
+	}
+	return modules
+}
+
+// parseGoVersion returns the Go version of a go.mod or go.work file.
+// The toolchain directive wins over the go directive, as the go command switches to that toolchain.
+func parseGoVersion(content string) string {
+	if match := toolchainDirectivePattern.FindStringSubmatch(content); match != nil {
+		return match[1]
+	}
+	if match := goDirectivePattern.FindStringSubmatch(content); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// readModuleGoVersion returns the Go version of the project's root module, or of its first module.
+func readModuleGoVersion(searchDir string, proj project) string {
+	if len(proj.modules) == 0 {
+		return ""
+	}
+	module := proj.modules[0]
+	for _, m := range proj.modules {
+		if m == "." {
+			module = m
+		}
+	}
+
+	content, err := metrics.ReadStringFromFile(filepath.Join(searchDir, proj.relDir, module, modFile))
+	if err != nil {
+		log.TWarnf("Failed to read %s: %s", filepath.Join(proj.relDir, module, modFile), err)
+		return ""
+	}
+	return parseGoVersion(content)
+}
+
+// collectTestPackages returns the package dirs of the project's modules containing _test.go files.
+func collectTestPackages(fileList []string, proj project) []string {
+	seen := map[string]bool{}
+	var packages []string
+	for _, pth := range fileList {
+		if !strings.HasSuffix(pth, "_test.go") || !isInDir(proj.relDir, pth) {
+			continue
+		}
+		packageDir, err := filepath.Rel(proj.relDir, filepath.Dir(pth))
+		if err != nil || seen[packageDir] || !isInModule(proj.modules, packageDir) {
+			continue
+		}
+		seen[packageDir] = true
+		packages = append(packages, packageDir)
+	}
+	sort.Strings(packages)
+	return packages
+}
+
+func isInModule(modules []string, dir string) bool {
+	for _, module := range modules {
+		if isInDir(module, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+func findGolangciConfig(projectDir string) string {
+	for _, configFile := range golangciConfigFiles {
+		if utility.FileExists(filepath.Join(projectDir, configFile)) {
+			return configFile
+		}
+	}
+	return ""
+}
+
+// isGolangciV2Config reports whether the golangci-lint config file declares the v2 config format.
+func isGolangciV2Config(pth string) bool {
+	content, err := metrics.ReadStringFromFile(pth)
+	if err != nil {
+		log.TWarnf("Failed to read %s: %s", pth, err)
+		return false
+	}
+	return golangciV2ConfigPattern.MatchString(content)
+}
@@ -0,0 +1,114 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for pth, content := range files {
+		pth = filepath.Join(dir, pth)
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+		require.NoError(t, os.WriteFile(pth, []byte(content), 0644))
+	}
+}
+
+func TestCollectProjects(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []project
+	}{
+		{
+			name: "single module with tests and a v2 golangci-lint config",
+			files: map[string]string{
+				"go.mod":          "module example.com/app\n\ngo 1.22\n",
+				"main.go":         "package main\n",
+				"pkg/lib_test.go": "package pkg\n",
+				".golangci.yml":   "version: \"2\"\nlinters:\n  default: standard\n",
+			},
+			want: []project{
+				{relDir: ".", modules: []string{"."}, goVersion: "1.22", testPackages: []string{"pkg"}, golangciFile: ".golangci.yml", golangciV2: true},
+			},
+		},
+		{
+			name: "multi-module repository with the toolchain directive and a v1 golangci-lint config",
+			files: map[string]string{
+				"go.mod":          "module example.com/app\n\ngo 1.21\n\ntoolchain go1.22.3\n",
+				"tools/go.mod":    "module example.com/app/tools\n\ngo 1.21\n",
+				"tools/a_test.go": "package tools\n",
+				".golangci.yaml":  "linters:\n  enable:\n    - gofmt\n",
+			},
+			want: []project{
+				{relDir: ".", modules: []string{".", "tools"}, goVersion: "1.22.3", testPackages: []string{"tools"}, golangciFile: ".golangci.yaml"},
+			},
+		},
+		{
+			name: "workspace skips the modules it doesn't use",
+			files: map[string]string{
+				"go.work":        "go 1.23\n\nuse (\n\t./api\n\t./cli\n)\n",
+				"api/go.mod":     "module example.com/api\n",
+				"cli/go.mod":     "module example.com/cli\n",
+				"unused/go.mod":  "module example.com/unused\n",
+				".golangci.toml": "version = \"2\"\n",
+			},
+			want: []project{
+				{relDir: ".", isWorkspace: true, modules: []string{"api", "cli"}, goVersion: "1.23", golangciFile: ".golangci.toml", golangciV2: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchDir := t.TempDir()
+			writeFiles(t, searchDir, tt.files)
+
+			got, err := collectProjects(searchDir)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseGoVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "go directive", content: "module example.com/app\n\ngo 1.22\n", want: "1.22"},
+		{name: "toolchain directive wins", content: "module example.com/app\n\ngo 1.21\ntoolchain go1.22.3\n", want: "1.22.3"},
+		{name: "no directive", content: "module example.com/app\n", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, parseGoVersion(tt.content))
+		})
+	}
+}
+
+func TestIsGolangciV2Config(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    bool
+	}{
+		{name: "yaml v2", file: ".golangci.yml", content: "version: \"2\"\n", want: true},
+		{name: "yaml v2 unquoted", file: ".golangci.yml", content: "run:\n  timeout: 5m\nversion: 2\n", want: true},
+		{name: "toml v2", file: ".golangci.toml", content: "version = '2'\n", want: true},
+		{name: "json v2", file: ".golangci.json", content: "{\n  \"version\": \"2\",\n  \"linters\": {}\n}\n", want: true},
+		{name: "v1 config", file: ".golangci.yml", content: "linters:\n  enable:\n    - govet\n", want: false},
+		{name: "nested version key", file: ".golangci.yml", content: "run:\n  go: \"1.22\"\n", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, map[string]string{tt.file: tt.content})
+
+			require.Equal(t, tt.want, isGolangciV2Config(filepath.Join(dir, tt.file)))
+		})
+	}
+}
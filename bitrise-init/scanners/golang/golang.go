@@ -0,0 +1,131 @@
+package golang
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+const (
+	scannerName = "go"
+
+	projectDirInputTitle   = "Go project directory"
+	projectDirInputSummary = "The directory containing the go.mod or go.work file of the project"
+	projectDirInputEnvKey  = "GO_PROJECT_DIR"
+
+	goVersionInputTitle   = "Go version"
+	goVersionInputSummary = "The Go version to be used for the project. Use exact (1.22.3) or partial (1.22:latest, 1:installed) versions."
+	goVersionEnvKey       = "GO_VERSION"
+)
+
+// Scanner implements ScannerInterface for Go module projects.
+type Scanner struct {
+	projects []project // populated by DetectPlatform
+}
+
+// NewScanner creates a new Scanner instance.
+func NewScanner() *Scanner {
+	return &Scanner{}
+}
+
+// Name returns the scanner name.
+func (s *Scanner) Name() string {
+	return scannerName
+}
+
+// DetectPlatform checks whether searchDir contains Go modules and reads their Go version, tests and linter config.
+func (s *Scanner) DetectPlatform(searchDir string) (bool, error) {
+	projects, err := collectProjects(searchDir)
+	if err != nil {
+		return false, fmt.Errorf("failed to collect Go projects: %w", err)
+	}
+
+	for _, proj := range projects {
+		if proj.isWorkspace {
+			log.TPrintf("Go workspace found: %s", proj.relDir)
+		} else {
+			log.TPrintf("Go project found: %s", proj.relDir)
+		}
+		log.TPrintf("- modules: %s", strings.Join(proj.modules, ", "))
+		log.TPrintf("- Go version: %s", proj.goVersion)
+		log.TPrintf("- test packages: %d", len(proj.testPackages))
+		log.TPrintf("- golangci-lint config: %s", proj.golangciFile)
+	}
+	s.projects = projects
+
+	if len(s.projects) == 0 {
+		log.TPrintf("Platform not detected")
+		return false, nil
+	}
+
+	log.TSuccessf("Platform detected")
+	return true, nil
+}
+
+// Evidence returns the module and workspace files of the detected projects and the tools found in them.
+func (s *Scanner) Evidence() models.Evidences {
+	var evidences models.Evidences
+	for _, proj := range s.projects {
+		if proj.isWorkspace {
+			evidences.Add(filepath.Join(proj.relDir, workFile), fmt.Sprintf("Go workspace of %d modules", len(proj.modules)))
+		}
+		for _, module := range proj.modules {
+			evidences.Add(filepath.Join(proj.relDir, module, modFile), "Go module")
+		}
+		if len(proj.testPackages) > 0 {
+			evidences.Add(proj.relDir, fmt.Sprintf("%d Go test packages", len(proj.testPackages)))
+		}
+		if proj.golangciFile != "" {
+			evidences.Add(filepath.Join(proj.relDir, proj.golangciFile), "golangci-lint config")
+		}
+	}
+	return evidences
+}
+
+// ProjectRoots returns the project dirs, keyed by the project dir option value.
+func (s *Scanner) ProjectRoots() []models.ProjectRoot {
+	var roots []models.ProjectRoot
+	for _, proj := range s.projects {
+		roots = append(roots, models.ProjectRoot{Dir: proj.relDir, OptionValue: proj.relDir})
+	}
+	return roots
+}
+
+// ExcludedScannerNames returns scanners to skip when this scanner detects.
+func (s *Scanner) ExcludedScannerNames() []string {
+	return []string{}
+}
+
+// Options builds the option tree of the detected projects.
+func (s *Scanner) Options() (models.OptionNode, models.Warnings, models.Icons, error) {
+	return generateOptions(s.projects)
+}
+
+// Configs generates the pre-made bitrise.yml templates for each detected project.
+func (s *Scanner) Configs(sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	return generateConfigs(s.projects, sshKeyActivation)
+}
+
+// DefaultOptions returns the option tree for the manual configuration flow.
+func (s *Scanner) DefaultOptions() models.OptionNode {
+	projectDirOption := models.NewOption(projectDirInputTitle, projectDirInputSummary, projectDirInputEnvKey, models.TypeUserInput)
+	versionOption := models.NewOption(goVersionInputTitle, goVersionInputSummary, goVersionEnvKey, models.TypeUserInput)
+
+	projectDirOption.AddOption(models.UserInputOptionDefaultValue, versionOption)
+	versionOption.AddConfig(models.UserInputOptionDefaultValue, models.NewConfigOption(configName(defaultConfigDescriptor()), nil))
+
+	return *projectDirOption
+}
+
+// DefaultConfigs generates the static bitrise.yml templates for the manual configuration flow.
+func (s *Scanner) DefaultConfigs() (models.BitriseConfigMap, error) {
+	descriptor := defaultConfigDescriptor()
+	config, err := generateConfigBasedOn(descriptor, models.SSHKeyActivationConditional)
+	if err != nil {
+		return nil, err
+	}
+	return models.BitriseConfigMap{configName(descriptor): config}, nil
+}
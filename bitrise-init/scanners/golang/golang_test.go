@@ -0,0 +1,22 @@
+package golang
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanner_DetectPlatform(t *testing.T) {
+	t.Run("collecting the projects fails", func(t *testing.T) {
+		detected, err := NewScanner().DetectPlatform(filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+		require.False(t, detected)
+	})
+
+	t.Run("no Go project", func(t *testing.T) {
+		detected, err := NewScanner().DetectPlatform(t.TempDir())
+		require.NoError(t, err)
+		require.False(t, detected)
+	})
+}
@@ -0,0 +1,319 @@
+package ios
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/bitrise-init/metrics"
+	"github.com/bitrise-io/bitrise-init/utility"
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+)
+
+// ProjectGenerator is a tool generating the Xcode project from a manifest.
+type ProjectGenerator string
+
+const (
+	ProjectGeneratorNone     ProjectGenerator = ""
+	ProjectGeneratorTuist    ProjectGenerator = "tuist"
+	ProjectGeneratorXcodeGen ProjectGenerator = "xcodegen"
+)
+
+const (
+	tuistProjectManifest   = "Project.swift"
+	tuistWorkspaceManifest = "Workspace.swift"
+	tuistPackageManifest   = "Tuist/Package.swift"
+	xcodeGenManifest       = "project.yml"
+)
+
+// ProjectGeneration is the generation step needed before building a generated Xcode project.
+// Manifest is the path of the generator manifest, relative to the search dir.
+type ProjectGeneration struct {
+	Generator ProjectGenerator
+	Manifest  string
+}
+
+// Dir returns the directory of the manifest, where the generator runs.
+func (generation ProjectGeneration) Dir() string {
+	return filepath.Dir(generation.Manifest)
+}
+
+var configNameUnsafeCharactersPattern = regexp.MustCompile(`[^A-Za-z0-9._]+`)
+
+// configQualifier returns the config name qualifier of the generation, like -tuist or -xcodegen-apps-ios for apps/ios/project.yml.
+// The generation step runs in the manifest dir, so projects generated in different dirs need different configs.
+func (generation ProjectGeneration) configQualifier() string {
+	if generation.Generator == ProjectGeneratorNone {
+		return ""
+	}
+	qualifier := "-" + string(generation.Generator)
+	if dir := generation.Dir(); dir != "." {
+		qualifier += "-" + strings.Trim(configNameUnsafeCharactersPattern.ReplaceAllString(filepath.ToSlash(dir), "-"), "-")
+	}
+	return qualifier
+}
+
+// generatorManifest is a Tuist or XcodeGen manifest found in the search dir.
+type generatorManifest struct {
+	generator ProjectGenerator
+	relPath   string
+}
+
+func (manifest generatorManifest) relDir() string {
+	return filepath.Dir(manifest.relPath)
+}
+
+// findGeneratorManifests returns the Tuist and XcodeGen manifests of the search dir.
+// Nested manifests of the same generator are skipped, as the outer manifest's generation includes them.
+func findGeneratorManifests(searchDir string) ([]generatorManifest, error) {
+	fileList, err := pathutil.ListPathInDirSortedByComponents(searchDir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := []pathutil.FilterFunc{
+		pathutil.BaseFilter(tuistProjectManifest, true),
+		pathutil.ComponentFilter("node_modules", false),
+		pathutil.ComponentFilter("Pods", false),
+		pathutil.ComponentFilter("Carthage", false),
+		pathutil.ComponentFilter(".build", false),
+	}
+	tuistManifests, err := pathutil.FilterPaths(fileList, filters...)
+	if err != nil {
+		return nil, err
+	}
+	filters[0] = pathutil.BaseFilter(tuistWorkspaceManifest, true)
+	tuistWorkspaceManifests, err := pathutil.FilterPaths(fileList, filters...)
+	if err != nil {
+		return nil, err
+	}
+	filters[0] = pathutil.BaseFilter(xcodeGenManifest, true)
+	xcodeGenManifests, err := pathutil.FilterPaths(fileList, filters...)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifests []generatorManifest
+	// Workspace manifests first, so that the projects of a Tuist workspace are generated together
+	for _, pth := range append(tuistWorkspaceManifests, tuistManifests...) {
+		manifests = appendManifest(manifests, generatorManifest{generator: ProjectGeneratorTuist, relPath: pth})
+	}
+	for _, pth := range xcodeGenManifests {
+		// project.yml is a common name, only XcodeGen specs are kept
+		content, err := metrics.ReadStringFromFile(filepath.Join(searchDir, pth))
+		if err != nil {
+			return nil, err
+		}
+		if !strings.Contains(content, "targets:") {
+			continue
+		}
+		manifests = appendManifest(manifests, generatorManifest{generator: ProjectGeneratorXcodeGen, relPath: pth})
+	}
+	return manifests, nil
+}
+
+func appendManifest(manifests []generatorManifest, manifest generatorManifest) []generatorManifest {
+	for _, m := range manifests {
+		if m.generator == manifest.generator && isInDir(manifest.relDir(), m.relDir()) {
+			return manifests
+		}
+	}
+	return append(manifests, manifest)
+}
+
+func isInDir(pth, dir string) bool {
+	return dir == "." || pth == dir || strings.HasPrefix(pth, dir+string(filepath.Separator))
+}
+
+// generationExcludedDirs are not copied for the generation: the generators don't use them and they can be large.
+var generationExcludedDirs = []string{".git", "node_modules"}
+
+// generateProjects copies the search dir into a temporary directory and runs the project generators in the copy.
+// Manifests of a generator which is not installed are skipped.
+// Returns the directory of the copy and the manifests generated successfully, the caller removes the copy.
+func generateProjects(searchDir string, manifests []generatorManifest) (string, []generatorManifest, error) {
+	var available []generatorManifest
+	for _, manifest := range manifests {
+		if _, err := exec.LookPath(string(manifest.generator)); err != nil {
+			log.TWarnf("%s manifest found in %s, but %s is not installed", manifest.generator, manifest.relPath, manifest.generator)
+			continue
+		}
+		available = append(available, manifest)
+	}
+	if len(available) == 0 {
+		return "", nil, nil
+	}
+
+	tmpDir, err := os.MkdirTemp("", "generated-xcode-projects")
+	if err != nil {
+		return "", nil, err
+	}
+	if err := copyDir(searchDir, tmpDir, generationExcludedDirs); err != nil {
+		if removeErr := os.RemoveAll(tmpDir); removeErr != nil {
+			log.TWarnf("Failed to remove %s: %s", tmpDir, removeErr)
+		}
+		return "", nil, fmt.Errorf("failed to copy the project: %w", err)
+	}
+
+	var generated []generatorManifest
+	for _, manifest := range available {
+		log.TInfof("Generating Xcode project with %s from %s", manifest.generator, manifest.relPath)
+
+		dir := filepath.Join(tmpDir, manifest.relDir())
+		var err error
+		for _, args := range generateCommandArgs(manifest.generator, utility.FileExists(filepath.Join(dir, tuistPackageManifest))) {
+			if err = runCommand(dir, args[0], args[1:]...); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			log.TWarnf("Failed to generate Xcode project: %s", err)
+			continue
+		}
+		generated = append(generated, manifest)
+	}
+	return tmpDir, generated, nil
+}
+
+// copyDir copies the contents of srcDir into dstDir, skipping the directories named like one of excludedDirs.
+// Symlinks are copied as symlinks.
+func copyDir(srcDir, dstDir string, excludedDirs []string) error {
+	return filepath.WalkDir(srcDir, func(pth string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(srcDir, pth)
+		if err != nil {
+			return err
+		}
+		dstPath := filepath.Join(dstDir, relPath)
+
+		switch {
+		case entry.IsDir():
+			if relPath == "." {
+				return nil
+			}
+			if slices.Contains(excludedDirs, entry.Name()) {
+				return filepath.SkipDir
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			return os.Mkdir(dstPath, info.Mode().Perm())
+		case entry.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(pth)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(target, dstPath)
+		case entry.Type().IsRegular():
+			return copyFile(pth, dstPath)
+		default:
+			return nil
+		}
+	})
+}
+
+func copyFile(srcPath, dstPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := src.Close(); err != nil {
+			log.TWarnf("Unable to close file %s: %s", srcPath, err)
+		}
+	}()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+	dst, err := os.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+func generateCommandArgs(generator ProjectGenerator, hasTuistPackage bool) [][]string {
+	switch generator {
+	case ProjectGeneratorTuist:
+		if hasTuistPackage {
+			return [][]string{{"tuist", "install"}, {"tuist", "generate", "--no-open"}}
+		}
+		return [][]string{{"tuist", "generate", "--no-open"}}
+	case ProjectGeneratorXcodeGen:
+		return [][]string{{"xcodegen", "generate"}}
+	default:
+		return nil
+	}
+}
+
+func runCommand(dir, name string, args ...string) error {
+	cmd := command.New(name, args...)
+	cmd.SetDir(dir)
+	log.TPrintf("$ %s", cmd.PrintableCommandArgs())
+	startTime := time.Now()
+	out, err := cmd.RunAndReturnTrimmedCombinedOutput()
+	metrics.CommandRun(cmd.PrintableCommandArgs(), startTime)
+	if err != nil {
+		return fmt.Errorf("%s failed: %s: %w", cmd.PrintableCommandArgs(), out, err)
+	}
+	return nil
+}
+
+// installScriptContent is the content of the Script Step installing the generator, if the stack doesn't provide it.
+func installScriptContent(generator ProjectGenerator) string {
+	var install string
+	switch generator {
+	case ProjectGeneratorTuist:
+		install = "brew tap tuist/tuist\n  brew install --formula tuist"
+	case ProjectGeneratorXcodeGen:
+		install = "brew install xcodegen"
+	default:
+		return ""
+	}
+
+	return fmt.Sprintf("#!/usr/bin/env bash\nset -euxo pipefail\n\nif ! command -v %s >/dev/null 2>&1; then\n  %s\nfi\n", generator, install)
+}
+
+// generationScriptContent is the content of the Script Step running the project generation in the builds,
+// the Step runs in the directory of the manifest.
+func generationScriptContent(generation ProjectGeneration) string {
+	var commands []string
+	for _, args := range generateCommandArgs(generation.Generator, generation.Generator == ProjectGeneratorTuist) {
+		commands = append(commands, strings.Join(args, " "))
+	}
+	if generation.Generator == ProjectGeneratorTuist {
+		// tuist install is only needed if there are dependencies declared
+		commands[0] = fmt.Sprintf("if [ -f %s ]; then %s; fi", tuistPackageManifest, commands[0])
+	}
+
+	return "#!/usr/bin/env bash\nset -euxo pipefail\n\n" + strings.Join(commands, "\n") + "\n"
+}
+
+// projectGeneration returns the generation step of a project found in the generated copy of the search dir.
+func projectGeneration(projectRelPath string, manifests []generatorManifest) ProjectGeneration {
+	for _, manifest := range manifests {
+		if isInDir(projectRelPath, manifest.relDir()) {
+			return ProjectGeneration{Generator: manifest.generator, Manifest: manifest.relPath}
+		}
+	}
+	return ProjectGeneration{}
+}
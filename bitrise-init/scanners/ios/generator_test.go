@@ -0,0 +1,166 @@
+package ios
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for pth, content := range files {
+		pth = filepath.Join(dir, pth)
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+		require.NoError(t, os.WriteFile(pth, []byte(content), 0644))
+	}
+}
+
+func Test_findGeneratorManifests(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []generatorManifest
+	}{
+		{
+			name:  "No manifests",
+			files: map[string]string{"App/AppDelegate.swift": ""},
+		},
+		{
+			name: "Tuist workspace includes its nested projects",
+			files: map[string]string{
+				"Workspace.swift":            "",
+				"App/Project.swift":          "",
+				"Framework/Project.swift":    "",
+				"node_modules/Project.swift": "",
+			},
+			want: []generatorManifest{{generator: ProjectGeneratorTuist, relPath: "Workspace.swift"}},
+		},
+		{
+			name: "Tuist and XcodeGen projects side by side",
+			files: map[string]string{
+				"tuist/Project.swift":  "",
+				"xcodegen/project.yml": "name: App\ntargets:\n  App:\n    type: application\n",
+				"docs/project.yml":     "name: Docs\n",
+			},
+			want: []generatorManifest{
+				{generator: ProjectGeneratorTuist, relPath: "tuist/Project.swift"},
+				{generator: ProjectGeneratorXcodeGen, relPath: "xcodegen/project.yml"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchDir := t.TempDir()
+			writeFiles(t, searchDir, tt.files)
+			t.Chdir(searchDir)
+
+			got, err := findGeneratorManifests(searchDir)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_copyDir(t *testing.T) {
+	srcDir := t.TempDir()
+	writeFiles(t, srcDir, map[string]string{
+		"Project.swift":                     "project",
+		"App/Sources/AppDelegate.swift":     "app",
+		".git/HEAD":                         "ref: refs/heads/main",
+		"node_modules/package/index.js":     "module",
+		"App/node_modules/package/index.js": "module",
+	})
+	require.NoError(t, os.Chmod(filepath.Join(srcDir, "Project.swift"), 0755))
+	require.NoError(t, os.Symlink("Sources/AppDelegate.swift", filepath.Join(srcDir, "App", "Link.swift")))
+
+	dstDir := t.TempDir()
+	require.NoError(t, copyDir(srcDir, dstDir, generationExcludedDirs))
+
+	var got []string
+	require.NoError(t, filepath.WalkDir(dstDir, func(pth string, entry os.DirEntry, err error) error {
+		require.NoError(t, err)
+		if !entry.IsDir() {
+			relPath, err := filepath.Rel(dstDir, pth)
+			require.NoError(t, err)
+			got = append(got, relPath)
+		}
+		return nil
+	}))
+	require.ElementsMatch(t, []string{"Project.swift", "App/Sources/AppDelegate.swift", "App/Link.swift"}, got)
+
+	info, err := os.Stat(filepath.Join(dstDir, "Project.swift"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	target, err := os.Readlink(filepath.Join(dstDir, "App", "Link.swift"))
+	require.NoError(t, err)
+	require.Equal(t, "Sources/AppDelegate.swift", target)
+}
+
+func Test_sourceIcons(t *testing.T) {
+	searchDir := t.TempDir()
+	generatedDir := t.TempDir()
+	iconPath := "App/Assets.xcassets/AppIcon.appiconset/icon.png"
+	writeFiles(t, searchDir, map[string]string{iconPath: ""})
+	writeFiles(t, generatedDir, map[string]string{
+		iconPath:                "",
+		"Derived/Generated.png": "",
+	})
+
+	icons := models.Icons{
+		{Filename: "source.png", Path: filepath.Join(generatedDir, iconPath)},
+		{Filename: "generated.png", Path: filepath.Join(generatedDir, "Derived", "Generated.png")},
+	}
+	want := models.Icons{{Filename: "source.png", Path: filepath.Join(searchDir, iconPath)}}
+	require.Equal(t, want, sourceIcons(icons, generatedDir, searchDir))
+}
+
+func Test_generationScriptContent(t *testing.T) {
+	tests := []struct {
+		name       string
+		generation ProjectGeneration
+		want       string
+	}{
+		{
+			name:       "Tuist",
+			generation: ProjectGeneration{Generator: ProjectGeneratorTuist, Manifest: "Project.swift"},
+			want:       "#!/usr/bin/env bash\nset -euxo pipefail\n\nif [ -f Tuist/Package.swift ]; then tuist install; fi\ntuist generate --no-open\n",
+		},
+		{
+			name:       "XcodeGen",
+			generation: ProjectGeneration{Generator: ProjectGeneratorXcodeGen, Manifest: "ios/project.yml"},
+			want:       "#!/usr/bin/env bash\nset -euxo pipefail\n\nxcodegen generate\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, generationScriptContent(tt.generation))
+		})
+	}
+}
+
+func Test_installScriptContent(t *testing.T) {
+	tests := []struct {
+		name      string
+		generator ProjectGenerator
+		want      string
+	}{
+		{
+			name:      "Tuist",
+			generator: ProjectGeneratorTuist,
+			want:      "#!/usr/bin/env bash\nset -euxo pipefail\n\nif ! command -v tuist >/dev/null 2>&1; then\n  brew tap tuist/tuist\n  brew install --formula tuist\nfi\n",
+		},
+		{
+			name:      "XcodeGen",
+			generator: ProjectGeneratorXcodeGen,
+			want:      "#!/usr/bin/env bash\nset -euxo pipefail\n\nif ! command -v xcodegen >/dev/null 2>&1; then\n  brew install xcodegen\nfi\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, installScriptContent(tt.generator))
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package ios
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/utility"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+//------------------
+// ScannerInterface
+//------------------
+
+// Scanner ...
+type Scanner struct {
+	DetectResult DetectResult
+
+	ConfigDescriptors []ConfigDescriptor
+
+	ExcludeAppIcon            bool
+	SuppressPodFileParseError bool
+
+	// GenerateProjects enables generating the Xcode projects of Tuist and XcodeGen manifests,
+	// if no Xcode project is found in the search dir.
+	GenerateProjects bool
+}
+
+// NewScanner ...
+func NewScanner() *Scanner {
+	return &Scanner{}
+}
+
+// Name ...
+func (scanner *Scanner) Name() string {
+	return string(XcodeProjectTypeIOS)
+}
+
+// DetectPlatform ...
+func (scanner *Scanner) DetectPlatform(searchDir string) (bool, error) {
+	result, err := ParseProjects(XcodeProjectTypeIOS, searchDir, scanner.ExcludeAppIcon, scanner.SuppressPodFileParseError)
+	if err != nil {
+		return false, err
+	}
+
+	if len(result.Projects) == 0 && scanner.GenerateProjects {
+		result, err = scanner.parseGeneratedProjects(searchDir)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if len(result.Projects) == 0 {
+		result, err = ParseSPMProject(XcodeProjectTypeIOS, searchDir)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	scanner.DetectResult = result
+	detected := len(result.Projects) > 0
+	return detected, nil
+}
+
+// parseGeneratedProjects generates the Xcode projects of the Tuist and XcodeGen manifests in a copy of the search dir,
+// and parses the generated projects.
+func (scanner *Scanner) parseGeneratedProjects(searchDir string) (DetectResult, error) {
+	manifests, err := findGeneratorManifests(searchDir)
+	if err != nil {
+		return DetectResult{}, err
+	}
+	if len(manifests) == 0 {
+		return DetectResult{}, nil
+	}
+
+	generatedDir, generated, err := generateProjects(searchDir, manifests)
+	if err != nil {
+		log.TWarnf("Failed to generate Xcode projects: %s", err)
+		return DetectResult{}, nil
+	}
+	if generatedDir == "" {
+		return DetectResult{}, nil
+	}
+	defer func() {
+		if err := os.RemoveAll(generatedDir); err != nil {
+			log.TWarnf("Failed to remove the generated Xcode projects: %s", err)
+		}
+	}()
+	if len(generated) == 0 {
+		return DetectResult{}, nil
+	}
+
+	result, err := ParseProjects(XcodeProjectTypeIOS, generatedDir, scanner.ExcludeAppIcon, scanner.SuppressPodFileParseError)
+	if err != nil {
+		return DetectResult{}, err
+	}
+	for i, project := range result.Projects {
+		result.Projects[i].Generation = projectGeneration(project.RelPath, generated)
+		for j, scheme := range project.Schemes {
+			result.Projects[i].Schemes[j].Icons = sourceIcons(scheme.Icons, generatedDir, searchDir)
+		}
+	}
+	return result, nil
+}
+
+// sourceIcons points the icons found in the generated copy to the same files in the search dir, as the copy is removed after the scan.
+// Icons created by the generation are dropped.
+func sourceIcons(icons models.Icons, generatedDir, searchDir string) models.Icons {
+	var sourceIcons models.Icons
+	for _, icon := range icons {
+		relPath, err := filepath.Rel(generatedDir, icon.Path)
+		if err != nil {
+			continue
+		}
+		icon.Path = filepath.Join(searchDir, relPath)
+		if !utility.FileExists(icon.Path) {
+			continue
+		}
+		sourceIcons = append(sourceIcons, icon)
+	}
+	return sourceIcons
+}
+
+// Evidence ...
+func (scanner *Scanner) Evidence() models.Evidences {
+	return scanner.DetectResult.Evidence()
+}
+
+// ProjectRoots ...
+func (scanner *Scanner) ProjectRoots() []models.ProjectRoot {
+	return scanner.DetectResult.ProjectRoots()
+}
+
+// ExcludedScannerNames ...
+func (scanner *Scanner) ExcludedScannerNames() []string {
+	return []string{}
+}
+
+// Options ...
+func (scanner *Scanner) Options() (models.OptionNode, models.Warnings, models.Icons, error) {
+	options, configDescriptors, icons, warnings, err := GenerateOptions(XcodeProjectTypeIOS, scanner.DetectResult)
+	if err != nil {
+		return models.OptionNode{}, warnings, nil, err
+	}
+
+	scanner.ConfigDescriptors = configDescriptors
+
+	return options, warnings, icons, nil
+}
+
+// DefaultOptions ...
+func (scanner *Scanner) DefaultOptions() models.OptionNode {
+	return GenerateDefaultOptions(XcodeProjectTypeIOS)
+}
+
+func (scanner *Scanner) Configs(sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	return GenerateConfig(XcodeProjectTypeIOS, scanner.ConfigDescriptors, sshKeyActivation)
+}
+
+// DefaultConfigs ...
+func (scanner *Scanner) DefaultConfigs() (models.BitriseConfigMap, error) {
+	return GenerateDefaultConfig(XcodeProjectTypeIOS)
+}
+
+// GetProjectType returns the project_type property used in a bitrise config
+func (scanner *Scanner) GetProjectType() string {
+	return string(XcodeProjectTypeIOS)
+}
@@ -0,0 +1,145 @@
+package java
+
+import (
+	"github.com/bitrise-io/bitrise-init/detectors/direntry"
+	"github.com/bitrise-io/bitrise-init/utility"
+	envmanModels "github.com/bitrise-io/envman/v2/models"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+const (
+	frameworkSpringBoot = "spring-boot"
+	frameworkQuarkus    = "quarkus"
+)
+
+// dependencyDetector is implemented by the Gradle and Maven projects, searching the build files for dependency references.
+type dependencyDetector interface {
+	FindFilesWithAnyDependencies(dependencies []string) ([]direntry.DirEntry, error)
+}
+
+// frameworkDependencies are the Gradle plugin IDs and Maven group IDs of the application frameworks.
+var frameworkDependencies = []struct {
+	framework    string
+	dependencies []string
+}{
+	{framework: frameworkSpringBoot, dependencies: []string{"org.springframework.boot"}},
+	{framework: frameworkQuarkus, dependencies: []string{"io.quarkus"}},
+}
+
+const testcontainersDependency = "org.testcontainers"
+
+// databaseDependencies are the JDBC drivers, database clients and Testcontainers modules of the databases.
+// Gradle references dependencies as group:artifact, Maven POMs in separate elements, so the Testcontainers modules are matched by both forms.
+var databaseDependencies = []struct {
+	database     string
+	dependencies []string
+}{
+	{
+		database: utility.ServiceDatabasePostgres,
+		dependencies: []string{"org.postgresql", "testcontainers:postgresql", "<artifactId>postgresql</artifactId>",
+			"quarkus-jdbc-postgresql", "quarkus-reactive-pg-client"},
+	},
+	{
+		database: utility.ServiceDatabaseMySQL,
+		dependencies: []string{"com.mysql", "mysql-connector-java", "org.mariadb.jdbc", "testcontainers:mysql", "testcontainers:mariadb",
+			"<artifactId>mysql</artifactId>", "<artifactId>mariadb</artifactId>", "quarkus-jdbc-mysql", "quarkus-jdbc-mariadb"},
+	},
+	{
+		database:     utility.ServiceDatabaseRedis,
+		dependencies: []string{"spring-boot-starter-data-redis", "redis.clients", "io.lettuce", "org.redisson", "quarkus-redis-client"},
+	},
+	{
+		database: utility.ServiceDatabaseMongoDB,
+		dependencies: []string{"spring-boot-starter-data-mongodb", "org.mongodb", "testcontainers:mongodb", "<artifactId>mongodb</artifactId>",
+			"quarkus-mongodb-client"},
+	},
+}
+
+// detectedDependency is a framework or database, and the build files referencing it.
+type detectedDependency struct {
+	name  string
+	files []direntry.DirEntry
+}
+
+func detectFramework(project dependencyDetector) (*detectedDependency, error) {
+	log.TPrintf("Checking application frameworks")
+
+	for _, candidate := range frameworkDependencies {
+		files, err := project.FindFilesWithAnyDependencies(candidate.dependencies)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) > 0 {
+			log.TPrintf("- %s: found", candidate.framework)
+			return &detectedDependency{name: candidate.framework, files: files}, nil
+		}
+	}
+	return nil, nil
+}
+
+func detectTestcontainers(project dependencyDetector) ([]direntry.DirEntry, error) {
+	return project.FindFilesWithAnyDependencies([]string{testcontainersDependency})
+}
+
+func detectDatabases(project dependencyDetector) ([]detectedDependency, error) {
+	log.TPrintf("Checking database dependencies")
+
+	var databases []detectedDependency
+	for _, candidate := range databaseDependencies {
+		files, err := project.FindFilesWithAnyDependencies(candidate.dependencies)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) > 0 {
+			log.TPrintf("- %s: found", candidate.database)
+			databases = append(databases, detectedDependency{name: candidate.database, files: files})
+		}
+	}
+	return databases, nil
+}
+
+// frameworkAppEnvs returns the env vars configuring the framework's connections to the service containers.
+// Quarkus Dev Services would start the databases with Testcontainers, they are disabled in favour of the service containers.
+func frameworkAppEnvs(framework string, containers []utility.ServiceContainer) []envmanModels.EnvironmentItemModel {
+	if len(containers) == 0 {
+		return nil
+	}
+
+	var envs []envmanModels.EnvironmentItemModel
+	if framework == frameworkQuarkus {
+		envs = append(envs, envmanModels.EnvironmentItemModel{"QUARKUS_DEVSERVICES_ENABLED": "false"})
+	}
+
+	hasDatasource := false
+	for _, container := range containers {
+		switch {
+		case container.IsRelational && !hasDatasource:
+			hasDatasource = true
+			username, password := container.Credentials()
+			prefix := "SPRING_DATASOURCE_"
+			urlKey := prefix + "URL"
+			if framework == frameworkQuarkus {
+				prefix = "QUARKUS_DATASOURCE_"
+				urlKey = prefix + "JDBC_URL"
+			}
+			envs = append(envs,
+				envmanModels.EnvironmentItemModel{urlKey: container.JDBCURL()},
+				envmanModels.EnvironmentItemModel{prefix + "USERNAME": username},
+				envmanModels.EnvironmentItemModel{prefix + "PASSWORD": password},
+			)
+		case container.Name == utility.ServiceDatabaseRedis:
+			key := "SPRING_DATA_REDIS_URL"
+			if framework == frameworkQuarkus {
+				key = "QUARKUS_REDIS_HOSTS"
+			}
+			envs = append(envs, envmanModels.EnvironmentItemModel{key: container.URL()})
+		case container.Name == utility.ServiceDatabaseMongoDB:
+			key := "SPRING_DATA_MONGODB_URI"
+			if framework == frameworkQuarkus {
+				key = "QUARKUS_MONGODB_CONNECTION_STRING"
+			}
+			envs = append(envs, envmanModels.EnvironmentItemModel{key: container.URL()})
+		}
+	}
+	return envs
+}
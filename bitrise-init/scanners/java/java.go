@@ -0,0 +1,467 @@
+package java
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/bitrise-io/bitrise-init/detectors/direntry"
+	"github.com/bitrise-io/bitrise-init/detectors/gradle"
+	"github.com/bitrise-io/bitrise-init/detectors/maven"
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/steps"
+	"github.com/bitrise-io/bitrise-init/utility"
+	envmanModels "github.com/bitrise-io/envman/v2/models"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+const (
+	ProjectType = "java"
+
+	buildToolInputTitle   = "Build tool"
+	buildToolInputSummary = "The build tool used in the project. Supported options: Gradle, Maven."
+	buildToolGradle       = "Gradle"
+	buildToolMaven        = "Maven"
+
+	testWorkflowID = "run_tests"
+
+	gradleConfigName                 = "java-gradle-config"
+	defaultGradleConfigName          = "default-java-gradle-config"
+	gradleProjectRootDirInputEnvKey  = "PROJECT_ROOT_DIR"
+	gradleProjectRootDirInputTitle   = "The root directory of the Gradle project."
+	gradleProjectRootDirInputSummary = "The root directory of the Gradle project, which contains all source files from your project, as well as Gradle files, including the Gradle Wrapper (`gradlew`) file."
+
+	mavenConfigName                 = "java-maven-config"
+	defaultMavenConfigName          = "default-java-maven-config"
+	mavenProjectRootDirInputEnvKey  = "PROJECT_ROOT_DIR"
+	mavenProjectRootDirInputTitle   = "The root directory of the Maven project."
+	mavenProjectRootDirInputSummary = "The root directory of the Maven project, which contains all source files from your project, as well as Maven files, including the Maven Wrapper (`mvn`) file."
+	mavenTestScriptTitle            = `Run Maven tests`
+	mavenTestScriptContent          = `#!/usr/bin/env bash
+set -euxo pipefail
+
+./mvnw test
+`
+	// --fail-at-end runs the tests of the modules not depending on a failed module, and reports all failures
+	mavenMultiModuleTestScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+./mvnw --batch-mode --fail-at-end test
+`
+)
+
+type Scanner struct {
+	gradleProject *gradle.Project
+	mavenProject  *maven.Project
+
+	// populated by Options()
+	javaVersion         string
+	javaVersionSource   *direntry.DirEntry
+	framework           *detectedDependency
+	testcontainersFiles []direntry.DirEntry
+	databases           []detectedDependency
+	serviceContainers   []utility.ServiceContainer
+	serviceSources      []string
+}
+
+func NewScanner() *Scanner {
+	return &Scanner{}
+}
+
+func (s *Scanner) Name() string {
+	return ProjectType
+}
+
+func (s *Scanner) DetectPlatform(searchDir string) (bool, error) {
+	log.TInfof("Searching for Gradle project files...")
+
+	rootEntry, err := direntry.WalkDir(searchDir, 6)
+	if err != nil {
+		return false, err
+	}
+
+	gradleWrapperScripts := rootEntry.FindAllEntriesByName("gradlew", false)
+	log.TDonef("%d Gradle wrapper script(s) found", len(gradleWrapperScripts))
+
+	if len(gradleWrapperScripts) > 0 {
+		gradleWrapperScript := gradleWrapperScripts[0]
+
+		log.TInfof("Scanning project with Gradle wrapper script: %s", gradleWrapperScript.AbsPath)
+
+		projectRootDir := gradleWrapperScript.Parent()
+		if projectRootDir == nil {
+			return false, fmt.Errorf("failed to get parent directory of %s", gradleWrapperScript.AbsPath)
+		}
+		gradleProject, err := gradle.ScanProject(*projectRootDir)
+		if err != nil {
+			return false, err
+		}
+
+		if gradleProject != nil {
+			s.gradleProject = gradleProject
+			printGradleProject(*gradleProject)
+			return true, nil
+		} else {
+			log.TWarnf("No Gradle project found in %s", projectRootDir.AbsPath)
+		}
+	}
+
+	log.TInfof("Searching for Maven project files...")
+
+	projectObjectModels := rootEntry.FindAllEntriesByName("pom.xml", false)
+	log.TDonef("%d POM file(s) found", len(projectObjectModels))
+
+	if len(projectObjectModels) > 0 {
+		projectObjectModel := projectObjectModels[0]
+
+		log.TInfof("Scanning project with POM file: %s", projectObjectModel.AbsPath)
+
+		projectRootDir := projectObjectModel.Parent()
+		if projectRootDir == nil {
+			return false, fmt.Errorf("failed to get parent directory of %s", projectObjectModel.AbsPath)
+		}
+		mavenProject, err := maven.ScanProject(*projectRootDir)
+		if err != nil {
+			return false, err
+		}
+
+		if mavenProject != nil {
+			s.mavenProject = mavenProject
+			printMavenProject(*mavenProject)
+			return true, nil
+		} else {
+			log.Warnf("No Maven project found in %s", projectRootDir.AbsPath)
+		}
+	}
+
+	return false, nil
+}
+
+func (s *Scanner) Evidence() models.Evidences {
+	var evidences models.Evidences
+	if s.gradleProject != nil {
+		evidences.Add(s.gradleProject.GradlewFileEntry.RelPath, "Gradle wrapper script")
+		if s.gradleProject.SettingsGradleFileEntry != nil {
+			evidences.Add(s.gradleProject.SettingsGradleFileEntry.RelPath, "Gradle settings file")
+		}
+	}
+	if s.mavenProject != nil {
+		evidences.Add(s.mavenProject.ProjectObjectModelFileEntry.RelPath, "Maven project object model")
+		evidences.Add(s.mavenProject.MavenWrapperFileEntry.RelPath, "Maven wrapper script")
+		for _, module := range s.mavenProject.Modules {
+			evidences.Add(module.ProjectObjectModelFileEntry.RelPath, "Maven module")
+		}
+	}
+	if s.javaVersionSource != nil {
+		evidences.Add(s.javaVersionSource.RelPath, fmt.Sprintf("Java %s", s.javaVersion))
+	}
+	if s.framework != nil {
+		for _, file := range s.framework.files {
+			evidences.Add(file.RelPath, fmt.Sprintf("%s framework", s.framework.name))
+		}
+	}
+	for _, file := range s.testcontainersFiles {
+		evidences.Add(file.RelPath, "Testcontainers")
+	}
+	for _, db := range s.databases {
+		for _, file := range db.files {
+			evidences.Add(file.RelPath, fmt.Sprintf("%s database dependency", db.name))
+		}
+	}
+	for _, source := range s.serviceSources {
+		evidences.Add(filepath.Join(s.rootDirEntry().RelPath, source), "service container configuration")
+	}
+	return evidences
+}
+
+// ProjectRoots returns the Gradle or Maven project root, keyed by the project root dir option value.
+func (s *Scanner) ProjectRoots() []models.ProjectRoot {
+	if s.gradleProject == nil && s.mavenProject == nil {
+		return nil
+	}
+	rootDir := s.rootDirEntry().RelPath
+	return []models.ProjectRoot{{Dir: rootDir, OptionValue: rootDir}}
+}
+
+func (s *Scanner) ExcludedScannerNames() []string {
+	return []string{}
+}
+
+func (s *Scanner) Options() (models.OptionNode, models.Warnings, models.Icons, error) {
+	if err := s.analyzeProject(); err != nil {
+		return models.OptionNode{}, nil, nil, err
+	}
+
+	if s.gradleProject != nil {
+		gradleProjectRootDirOption := models.NewOption(gradleProjectRootDirInputTitle, gradleProjectRootDirInputSummary, gradleProjectRootDirInputEnvKey, models.TypeSelector)
+		configOption := models.NewConfigOption(s.configName(gradleConfigName), nil)
+		gradleProjectRootDirOption.AddConfig(s.rootDirEntry().RelPath, configOption)
+		return *gradleProjectRootDirOption, s.gradleProject.WrapperIssues, nil, nil
+	}
+
+	if s.mavenProject != nil {
+		mavenProjectRootDirOption := models.NewOption(mavenProjectRootDirInputTitle, mavenProjectRootDirInputSummary, mavenProjectRootDirInputEnvKey, models.TypeSelector)
+		configOption := models.NewConfigOption(s.configName(mavenConfigName), nil)
+		mavenProjectRootDirOption.AddConfig(s.rootDirEntry().RelPath, configOption)
+		return *mavenProjectRootDirOption, nil, nil, nil
+	}
+
+	return models.OptionNode{}, nil, nil, nil
+}
+
+func (s *Scanner) DefaultOptions() models.OptionNode {
+	buildToolOption := models.NewOption(buildToolInputTitle, buildToolInputSummary, "", models.TypeSelector)
+
+	gradleProjectRootDirOption := models.NewOption(gradleProjectRootDirInputTitle, gradleProjectRootDirInputSummary, gradleProjectRootDirInputEnvKey, models.TypeUserInput)
+	buildToolOption.AddOption(buildToolGradle, gradleProjectRootDirOption)
+
+	gradleConfigOption := models.NewConfigOption(defaultGradleConfigName, nil)
+	gradleProjectRootDirOption.AddConfig(models.UserInputOptionDefaultValue, gradleConfigOption)
+
+	mavenProjectRootDirOption := models.NewOption(mavenProjectRootDirInputTitle, mavenProjectRootDirInputSummary, mavenProjectRootDirInputEnvKey, models.TypeUserInput)
+	buildToolOption.AddOption(buildToolMaven, mavenProjectRootDirOption)
+
+	mavenConfigOption := models.NewConfigOption(defaultMavenConfigName, nil)
+	mavenProjectRootDirOption.AddConfig(models.UserInputOptionDefaultValue, mavenConfigOption)
+
+	return *buildToolOption
+}
+
+func (s *Scanner) Configs(sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	configBuilder := models.NewDefaultConfigBuilder()
+	bitriseDataMap := models.BitriseConfigMap{}
+
+	serviceContainerRefs := utility.ServiceContainerReferences(s.serviceContainers)
+	if len(s.serviceContainers) > 0 {
+		configBuilder.SetContainerDefinitions(utility.ServiceContainerDefinitions(s.serviceContainers))
+	}
+
+	if s.gradleProject != nil {
+		gradleProjectRootDir := "$" + gradleProjectRootDirInputEnvKey
+		configBuilder.AppendStepListItemsTo(testWorkflowID,
+			steps.DefaultPrepareStepList(steps.PrepareListParams{SSHKeyActivation: sshKeyActivation})...,
+		)
+		if s.javaVersion != "" {
+			configBuilder.AppendStepListItemsTo(testWorkflowID, steps.SetJavaVersionStepListItem(s.javaVersion))
+		}
+		configBuilder.AppendStepListItemsTo(testWorkflowID,
+			steps.WithServiceContainers(steps.GradleUnitTestStepListItem(gradleProjectRootDir), serviceContainerRefs),
+		)
+		configBuilder.AppendStepListItemsTo(testWorkflowID,
+			steps.DefaultDeployStepList()...,
+		)
+
+		config, err := configBuilder.Generate(ProjectType, s.appEnvs()...)
+		if err != nil {
+			return models.BitriseConfigMap{}, err
+		}
+
+		data, err := yaml.Marshal(config)
+		if err != nil {
+			return models.BitriseConfigMap{}, err
+		}
+
+		bitriseDataMap[s.configName(gradleConfigName)] = string(data)
+	}
+
+	if s.mavenProject != nil {
+		mavenProjectRootDir := "$" + mavenProjectRootDirInputEnvKey
+		mavenTestScript := mavenTestScriptContent
+		if len(s.mavenProject.Modules) > 0 {
+			mavenTestScript = mavenMultiModuleTestScriptContent
+		}
+		configBuilder.AppendStepListItemsTo(testWorkflowID,
+			steps.DefaultPrepareStepList(steps.PrepareListParams{SSHKeyActivation: sshKeyActivation})...,
+		)
+		if s.javaVersion != "" {
+			configBuilder.AppendStepListItemsTo(testWorkflowID, steps.SetJavaVersionStepListItem(s.javaVersion))
+		}
+		configBuilder.AppendStepListItemsTo(testWorkflowID,
+			steps.WithServiceContainers(steps.ScriptStepListItem(mavenTestScriptTitle, mavenTestScript, envmanModels.EnvironmentItemModel{
+				"working_dir": mavenProjectRootDir,
+			}), serviceContainerRefs),
+		)
+		configBuilder.AppendStepListItemsTo(testWorkflowID,
+			steps.DefaultDeployStepList()...,
+		)
+		config, err := configBuilder.Generate(ProjectType, s.appEnvs()...)
+		if err != nil {
+			return models.BitriseConfigMap{}, err
+		}
+		data, err := yaml.Marshal(config)
+		if err != nil {
+			return models.BitriseConfigMap{}, err
+		}
+
+		bitriseDataMap[s.configName(mavenConfigName)] = string(data)
+	}
+
+	return bitriseDataMap, nil
+}
+
+func (s *Scanner) DefaultConfigs() (models.BitriseConfigMap, error) {
+	bitriseDataMap := models.BitriseConfigMap{}
+
+	{
+		configBuilder := models.NewDefaultConfigBuilder()
+
+		gradleProjectRootDir := "$" + gradleProjectRootDirInputEnvKey
+		configBuilder.AppendStepListItemsTo(testWorkflowID,
+			steps.DefaultPrepareStepList(steps.PrepareListParams{SSHKeyActivation: models.SSHKeyActivationConditional})...,
+		)
+		configBuilder.AppendStepListItemsTo(testWorkflowID,
+			steps.GradleUnitTestStepListItem(gradleProjectRootDir),
+		)
+		configBuilder.AppendStepListItemsTo(testWorkflowID,
+			steps.DefaultDeployStepList()...,
+		)
+
+		config, err := configBuilder.Generate(ProjectType)
+		if err != nil {
+			return models.BitriseConfigMap{}, err
+		}
+		data, err := yaml.Marshal(config)
+		if err != nil {
+			return models.BitriseConfigMap{}, err
+		}
+		bitriseDataMap[defaultGradleConfigName] = string(data)
+	}
+
+	{
+		configBuilder := models.NewDefaultConfigBuilder()
+
+		mavenProjectRootDir := "$" + mavenProjectRootDirInputEnvKey
+		configBuilder.AppendStepListItemsTo(testWorkflowID,
+			steps.DefaultPrepareStepList(steps.PrepareListParams{SSHKeyActivation: models.SSHKeyActivationConditional})...,
+		)
+		configBuilder.AppendStepListItemsTo(testWorkflowID,
+			steps.ScriptStepListItem(mavenTestScriptTitle, mavenTestScriptContent, envmanModels.EnvironmentItemModel{
+				"working_dir": mavenProjectRootDir,
+			}),
+		)
+		configBuilder.AppendStepListItemsTo(testWorkflowID,
+			steps.DefaultDeployStepList()...,
+		)
+
+		config, err := configBuilder.Generate(ProjectType)
+		if err != nil {
+			return models.BitriseConfigMap{}, err
+		}
+		data, err := yaml.Marshal(config)
+		if err != nil {
+			return models.BitriseConfigMap{}, err
+		}
+		bitriseDataMap[defaultMavenConfigName] = string(data)
+	}
+
+	return bitriseDataMap, nil
+}
+
+// analyzeProject detects the Java version, the application framework and the databases of the tests.
+func (s *Scanner) analyzeProject() error {
+	var project dependencyDetector
+	var err error
+	if s.gradleProject != nil {
+		project = s.gradleProject
+		s.javaVersion, s.javaVersionSource, err = s.gradleProject.DetectJavaToolchainVersion()
+	} else if s.mavenProject != nil {
+		project = s.mavenProject
+		s.javaVersion, s.javaVersionSource, err = s.mavenProject.DetectJavaVersion()
+	} else {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if s.javaVersion != "" {
+		log.TPrintf("Java version: %s (%s)", s.javaVersion, s.javaVersionSource.RelPath)
+	}
+
+	if s.framework, err = detectFramework(project); err != nil {
+		return err
+	}
+	if s.testcontainersFiles, err = detectTestcontainers(project); err != nil {
+		return err
+	}
+	if len(s.testcontainersFiles) > 0 {
+		log.TPrintf("Testcontainers: found")
+	}
+	if s.databases, err = detectDatabases(project); err != nil {
+		return err
+	}
+
+	var databases []string
+	for _, db := range s.databases {
+		databases = append(databases, db.name)
+	}
+	s.serviceContainers, s.serviceSources = utility.ServiceContainers(s.rootDirEntry().AbsPath, databases)
+
+	return nil
+}
+
+func (s *Scanner) rootDirEntry() direntry.DirEntry {
+	if s.gradleProject != nil {
+		return s.gradleProject.RootDirEntry
+	}
+	return s.mavenProject.RootDirEntry
+}
+
+// configName extends the base config name with the detected framework, Java version, modules and service containers.
+func (s *Scanner) configName(baseName string) string {
+	name := strings.TrimSuffix(baseName, "-config")
+	if s.framework != nil {
+		name += "-" + s.framework.name
+	}
+	if s.javaVersion != "" {
+		name += "-jdk" + s.javaVersion
+	}
+	if s.mavenProject != nil && len(s.mavenProject.Modules) > 0 {
+		name += "-multimodule"
+	}
+	for _, container := range s.serviceContainers {
+		name += "-" + container.Name
+	}
+	return name + "-config"
+}
+
+// appEnvs returns the connection env vars of the service containers, in the framework's configuration format.
+func (s *Scanner) appEnvs() []envmanModels.EnvironmentItemModel {
+	if s.framework != nil {
+		return frameworkAppEnvs(s.framework.name, s.serviceContainers)
+	}
+	return utility.ServiceContainerAppEnvs(s.serviceContainers)
+}
+
+func printGradleProject(gradleProject gradle.Project) {
+	log.TPrintf("Project root dir: %s", gradleProject.RootDirEntry.RelPath)
+	log.TPrintf("Gradle wrapper script: %s", gradleProject.GradlewFileEntry.RelPath)
+	if gradleProject.ConfigDirEntry != nil {
+		log.TPrintf("Gradle config dir: %s", gradleProject.ConfigDirEntry.RelPath)
+	}
+	if gradleProject.VersionCatalogFileEntry != nil {
+		log.TPrintf("Version catalog file: %s", gradleProject.VersionCatalogFileEntry.RelPath)
+	}
+	if gradleProject.SettingsGradleFileEntry != nil {
+		log.TPrintf("Gradle settings file: %s", gradleProject.SettingsGradleFileEntry.RelPath)
+	}
+	if len(gradleProject.IncludedProjects) > 0 {
+		log.TPrintf("Included projects:")
+		for _, includedProject := range gradleProject.IncludedProjects {
+			log.TPrintf("- %s: %s", includedProject.Name, includedProject.BuildScriptFileEntry.RelPath)
+		}
+	}
+}
+
+func printMavenProject(mavenProject maven.Project) {
+	log.TPrintf("Project root dir: %s", mavenProject.RootDirEntry.RelPath)
+	log.TPrintf("Maven POM file: %s", mavenProject.ProjectObjectModelFileEntry.RelPath)
+	log.TPrintf("Maven wrapper file: %s", mavenProject.MavenWrapperFileEntry.RelPath)
+	if len(mavenProject.Modules) > 0 {
+		log.TPrintf("Modules:")
+		for _, module := range mavenProject.Modules {
+			log.TPrintf("- %s", module.ProjectObjectModelFileEntry.RelPath)
+		}
+	}
+}
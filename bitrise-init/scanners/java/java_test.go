@@ -0,0 +1,67 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanner_ProjectRoots(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantDir string
+	}{
+		{
+			name: "Gradle project in the search dir",
+			files: map[string]string{
+				"gradlew":         "",
+				"settings.gradle": "rootProject.name = 'app'",
+				"build.gradle":    "plugins { id 'java' }",
+			},
+			wantDir: ".",
+		},
+		{
+			name: "Gradle project in a sub dir",
+			files: map[string]string{
+				"backend/gradlew":             "",
+				"backend/settings.gradle.kts": `rootProject.name = "app"`,
+				"backend/build.gradle.kts":    "plugins { java }",
+			},
+			wantDir: "backend",
+		},
+		{
+			name: "Maven project in a sub dir",
+			files: map[string]string{
+				"service/mvnw":    "",
+				"service/pom.xml": "<project><modelVersion>4.0.0</modelVersion><artifactId>service</artifactId></project>",
+			},
+			wantDir: "service",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchDir := t.TempDir()
+			for pth, content := range tt.files {
+				pth = filepath.Join(searchDir, pth)
+				require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+				require.NoError(t, os.WriteFile(pth, []byte(content), 0755))
+			}
+
+			scanner := NewScanner()
+			detected, err := scanner.DetectPlatform(searchDir)
+			require.NoError(t, err)
+			require.True(t, detected)
+
+			options, _, _, err := scanner.Options()
+			require.NoError(t, err)
+
+			roots := scanner.ProjectRoots()
+			require.Len(t, roots, 1)
+			require.Equal(t, tt.wantDir, filepath.Clean(roots[0].Dir))
+			require.Contains(t, options.ChildOptionMap, roots[0].OptionValue)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package nodejs
+
+import (
+	"github.com/bitrise-io/bitrise-init/utility"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+// databasePackage represents the driver packages of a database, the database runs in a service container.
+type databasePackage struct {
+	packageNames []string // any of the driver packages selects the database
+	database     string   // one of the utility.ServiceDatabase constants
+}
+
+var knownDatabasePackages = []databasePackage{
+	{
+		packageNames: []string{"pg", "pg-promise", "postgres", "@vercel/postgres"},
+		database:     utility.ServiceDatabasePostgres,
+	},
+	{
+		packageNames: []string{"mysql", "mysql2", "mariadb"},
+		database:     utility.ServiceDatabaseMySQL,
+	},
+	{
+		packageNames: []string{"ioredis", "redis", "@redis/client", "bullmq"},
+		database:     utility.ServiceDatabaseRedis,
+	},
+	{
+		packageNames: []string{"mongoose", "mongodb"},
+		database:     utility.ServiceDatabaseMongoDB,
+	},
+}
+
+// detectDatabases returns the databases whose driver packages are dependencies of the package.json files:
+// of the project, and of its packages if the project is a workspace root.
+func detectDatabases(searchDir string, packageJSONPths []string) []utility.DetectedDatabase {
+	log.TPrintf("Checking database drivers")
+
+	var detected []utility.DetectedDatabase
+	for _, db := range knownDatabasePackages {
+		if found, ok := findDatabasePackage(db, packageJSONPths); ok {
+			if relSource, err := utility.RelPath(searchDir, found.DriverSource); err == nil {
+				found.DriverSource = relSource
+			}
+			log.TPrintf("- %s: %s", found.DriverPackage, found.Database)
+			detected = append(detected, found)
+		}
+	}
+	return detected
+}
+
+func findDatabasePackage(db databasePackage, packageJSONPths []string) (utility.DetectedDatabase, bool) {
+	for _, packageJSONPth := range packageJSONPths {
+		packages, err := utility.ParsePackagesJSON(packageJSONPth)
+		if err != nil {
+			continue
+		}
+		for _, name := range db.packageNames {
+			_, isDependency := packages.Dependencies[name]
+			_, isDevDependency := packages.DevDependencies[name]
+			if isDependency || isDevDependency {
+				return utility.DetectedDatabase{Database: db.database, DriverPackage: name, DriverSource: packageJSONPth}, true
+			}
+		}
+	}
+	return utility.DetectedDatabase{}, false
+}
@@ -0,0 +1,261 @@
+package nodejs
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/utility"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+// Options
+const (
+	ScannerName = "node-js"
+
+	runTestsWorkflowID = models.WorkflowID("run_tests")
+
+	projectDirInputTitle   = "Project Directory"
+	projectDirInputSummary = "The directory containing the package.json file"
+	projectDirInputEnvKey  = "NODEJS_PROJECT_DIR"
+
+	packageManagerInputTitle   = "Package Manager"
+	packageManagerInputSummary = "The package manager used in the project"
+
+	nodeVersionInputTitle           = "Node.js version"
+	nodeVersionInputSummary         = "The Node.js version to be used for the project. Use exact (20.10.0) or partial (22:latest, 20:installed) versions."
+	nodeVersionEnvKey               = "NODEJS_VERSION"
+	nodeVersionInstallScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+bitrise tools install nodejs $NODEJS_VERSION
+`
+)
+
+var pkgManagers = []string{
+	utility.NodePackageManagerNpm,
+	utility.NodePackageManagerYarn,
+	utility.NodePackageManagerPnpm,
+	utility.NodePackageManagerBun,
+}
+
+type project struct {
+	projectRelDir  string
+	packageManager string
+	// packageManagerVersion is pinned by the packageManager field of package.json
+	packageManagerVersion string
+	lockFile              string
+	scripts               []string
+	hasTest               bool
+	hasLint               bool
+	framework             string
+	nodeVersion           string
+	workspace             *workspace // set if the project is the root of a JS monorepo
+	// databases used by the tests, running in service containers
+	databases         []utility.DetectedDatabase
+	serviceContainers []utility.ServiceContainer
+	serviceSources    []string // files configuring the service containers, relative to the project dir
+}
+
+// workspace holds the packages of a monorepo root project, and the scripts to run in them.
+type workspace struct {
+	taskRunner   string
+	sources      []string // files defining the workspace, relative to the search dir
+	packageDirs  []string // relative to the project dir
+	lintPackages []string // packages with lint script, relative to the project dir
+	testPackages []string // packages with test script, relative to the project dir
+}
+
+// Scanner implements the Scanner interface for Node.js projects
+type Scanner struct {
+	projects []project
+}
+
+// NewScanner creates a new scanner instance.
+func NewScanner() *Scanner {
+	return &Scanner{}
+}
+
+// Name returns the name of the scanner
+func (scanner *Scanner) Name() string {
+	return ScannerName
+}
+
+// DetectPlatform checks if the given search directory contains a Node.js project
+func (scanner *Scanner) DetectPlatform(searchDir string) (bool, error) {
+	pkgJsonPaths, err := utility.CollectPackageJSONFiles(searchDir)
+	if err != nil {
+		log.TWarnf("%s", err)
+		log.TPrintf("Platform not detected")
+		return false, nil
+	}
+
+	workspaces, err := utility.DetectNodeWorkspaces(pkgJsonPaths)
+	if err != nil {
+		log.TWarnf("Failed to detect workspaces: %s", err)
+	}
+
+	for _, packageJsonPath := range pkgJsonPaths {
+		nodeWorkspace := utility.FindNodeWorkspace(workspaces, packageJsonPath)
+		if nodeWorkspace != nil && filepath.Dir(packageJsonPath) != nodeWorkspace.RootDir {
+			// Workspace packages are installed and tested through their workspace root
+			continue
+		}
+
+		log.TPrintf("Checking: %s", packageJsonPath)
+
+		// determine workdir
+		pkgJsonDir := filepath.Dir(packageJsonPath)
+
+		pkgMgr := checkPackageManager(pkgJsonDir)
+		results, err := checkPackageScripts(packageJsonPath)
+		if err != nil {
+			log.TWarnf("Failed to check package scripts: %s", err)
+			continue
+		}
+		framework := detectFramework(packageJsonPath)
+		nodeVersion := detectNodeVersion(pkgJsonDir, packageJsonPath)
+
+		projectRelDir, err := utility.RelPath(searchDir, pkgJsonDir)
+		if err != nil {
+			log.TWarnf("failed to get relative package.json dir path: %s", err)
+			continue
+		}
+
+		project := project{
+			projectRelDir:         projectRelDir,
+			packageManager:        pkgMgr.Name,
+			packageManagerVersion: pkgMgr.Version,
+			lockFile:              pkgMgr.LockFile,
+			scripts:               results.scripts,
+			hasTest:               results.hasTest,
+			hasLint:               results.hasLint,
+			framework:             framework,
+			nodeVersion:           nodeVersion,
+		}
+
+		if nodeWorkspace != nil {
+			project.workspace = checkWorkspace(searchDir, *nodeWorkspace)
+			if nodeWorkspace.TaskRunner == utility.NodeTaskRunnerTurbo {
+				// Turborepo runs the tasks of its pipeline, not the package.json scripts
+				project.hasLint = slices.Contains(nodeWorkspace.TaskRunnerTasks, "lint")
+				project.hasTest = slices.Contains(nodeWorkspace.TaskRunnerTasks, "test")
+			} else {
+				project.hasLint = project.hasLint || len(project.workspace.lintPackages) > 0
+				project.hasTest = project.hasTest || len(project.workspace.testPackages) > 0
+			}
+		}
+
+		if project.hasTest {
+			packageJSONPths := []string{packageJsonPath}
+			if nodeWorkspace != nil {
+				packageJSONPths = append(packageJSONPths, nodeWorkspace.Packages...)
+			}
+			project.databases = detectDatabases(searchDir, packageJSONPths)
+			project.serviceContainers, project.serviceSources = utility.ServiceContainers(pkgJsonDir, utility.DatabaseNames(project.databases))
+		}
+
+		scanner.projects = append(scanner.projects, project)
+	}
+
+	if len(scanner.projects) == 0 {
+		log.TPrintf("Platform not detected")
+		return false, nil
+	}
+
+	log.TSuccessf("Platform detected")
+	return true, nil
+}
+
+// Evidence returns the package.json files, lock files and scripts which made the projects detected
+func (scanner *Scanner) Evidence() models.Evidences {
+	var evidences models.Evidences
+	for _, project := range scanner.projects {
+		packageJSONPth := filepath.Join(project.projectRelDir, "package.json")
+		evidences.Add(packageJSONPth, "package.json")
+		if project.lockFile != "" {
+			evidences.Add(filepath.Join(project.projectRelDir, project.lockFile), fmt.Sprintf("%s lock file", project.packageManager))
+		}
+		if project.packageManagerVersion != "" {
+			evidences.Add(packageJSONPth, fmt.Sprintf("packageManager field: %s@%s", project.packageManager, project.packageManagerVersion))
+		}
+		if project.hasTest {
+			evidences.Add(packageJSONPth, "test script")
+		}
+		if project.hasLint {
+			evidences.Add(packageJSONPth, "lint script")
+		}
+		if project.framework != "" {
+			evidences.Add(packageJSONPth, fmt.Sprintf("%s framework", project.framework))
+		}
+		for _, db := range project.databases {
+			evidences.Add(db.DriverSource, fmt.Sprintf("%s database driver", db.DriverPackage))
+		}
+		for _, source := range project.serviceSources {
+			evidences.Add(filepath.Join(project.projectRelDir, source), "service container configuration")
+		}
+		if project.workspace != nil {
+			for _, source := range project.workspace.sources {
+				evidences.Add(source, fmt.Sprintf("workspace of %d packages", len(project.workspace.packageDirs)))
+			}
+		}
+	}
+	return evidences
+}
+
+// ProjectRoots returns the directories of the detected package.json files
+func (scanner *Scanner) ProjectRoots() []models.ProjectRoot {
+	var roots []models.ProjectRoot
+	for _, project := range scanner.projects {
+		roots = append(roots, models.ProjectRoot{Dir: project.projectRelDir, OptionValue: project.projectRelDir})
+	}
+	return roots
+}
+
+func (scanner *Scanner) ExcludedScannerNames() []string {
+	return []string{}
+}
+
+// Options returns the options for the scanner
+func (scanner *Scanner) Options() (models.OptionNode, models.Warnings, models.Icons, error) {
+	return generateOptions(scanner.projects)
+}
+
+// Configs returns the default configurations for the scanner
+func (scanner *Scanner) Configs(sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	return generateConfigs(scanner.projects, sshKeyActivation)
+}
+
+// DefaultOptions returns the default options for the scanner
+func (scanner *Scanner) DefaultOptions() models.OptionNode {
+	projectRootOption := models.NewOption(projectDirInputTitle, projectDirInputSummary, projectDirInputEnvKey, models.TypeUserInput)
+	packageManagerOption := models.NewOption(packageManagerInputTitle, packageManagerInputSummary, "", models.TypeSelector)
+	nodeVersionOption := models.NewOption(nodeVersionInputTitle, nodeVersionInputSummary, nodeVersionEnvKey, models.TypeUserInput)
+
+	projectRootOption.AddOption(models.UserInputOptionDefaultValue, nodeVersionOption)
+	nodeVersionOption.AddOption(models.UserInputOptionDefaultValue, packageManagerOption)
+
+	for _, pkgMgr := range pkgManagers {
+		defaultDescriptor := createDefaultConfigDescriptor(pkgMgr)
+		configOption := models.NewConfigOption(configName(defaultDescriptor), nil)
+		packageManagerOption.AddConfig(pkgMgr, configOption)
+	}
+
+	return *projectRootOption
+}
+
+func (scanner *Scanner) DefaultConfigs() (models.BitriseConfigMap, error) {
+	configs := models.BitriseConfigMap{}
+
+	for _, pkgMgr := range pkgManagers {
+		defaultDescriptor := createDefaultConfigDescriptor(pkgMgr)
+		config, err := generateConfigBasedOn(defaultDescriptor, models.SSHKeyActivationConditional)
+		if err != nil {
+			return nil, err
+		}
+		configs[configName(defaultDescriptor)] = config
+	}
+
+	return configs, nil
+}
@@ -0,0 +1,541 @@
+package nodejs
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/bitrise-io/bitrise-init/metrics"
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/steps"
+	"github.com/bitrise-io/bitrise-init/utility"
+	bitriseModels "github.com/bitrise-io/bitrise/v2/models"
+	envmanModels "github.com/bitrise-io/envman/v2/models"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+type checkScriptResult struct {
+	scripts          []string
+	hasLint, hasTest bool
+}
+
+func checkPackageManager(searchDir string) utility.NodePackageManager {
+	log.TPrintf("Checking package manager")
+	pkgMgr := utility.DetectNodePackageManager(searchDir)
+	if pkgMgr.Version != "" {
+		log.TPrintf("- packageManager field: %s@%s", pkgMgr.Name, pkgMgr.Version)
+	}
+	if pkgMgr.LockFile != "" {
+		log.TPrintf("- %s - found", pkgMgr.LockFile)
+	}
+	if pkgMgr.Name == "" {
+		log.TPrintf("- lock file - not found")
+		return pkgMgr
+	}
+
+	log.TPrintf("Package manager: %s", pkgMgr.Name)
+	return pkgMgr
+}
+
+func checkPackageScripts(packageJsonPath string) (checkScriptResult, error) {
+	log.TPrintf("Checking package scripts")
+
+	result := checkScriptResult{
+		scripts: make([]string, 0),
+		hasLint: false,
+		hasTest: false,
+	}
+
+	packages, err := utility.ParsePackagesJSON(packageJsonPath)
+	if err != nil {
+		return result, err
+	}
+
+	for name := range packages.Scripts {
+		log.TDebugf("- %s", name)
+		result.scripts = append(result.scripts, name)
+	}
+
+	if slices.Contains(result.scripts, "lint") {
+		log.TPrintf("- lint - found")
+		result.hasLint = true
+	} else {
+		log.TPrintf("- lint - not found")
+	}
+
+	if slices.Contains(result.scripts, "test") {
+		log.TPrintf("- test - found")
+		result.hasTest = true
+	} else {
+		log.TPrintf("- test - not found")
+	}
+
+	return result, nil
+}
+
+// checkWorkspace collects the packages of a workspace root, and the packages having lint and test scripts.
+func checkWorkspace(searchDir string, nodeWorkspace utility.NodeWorkspace) *workspace {
+	log.TPrintf("Checking workspace packages")
+
+	result := &workspace{taskRunner: nodeWorkspace.TaskRunner}
+	for _, source := range nodeWorkspace.Sources {
+		if relSource, err := utility.RelPath(searchDir, source); err == nil {
+			result.sources = append(result.sources, relSource)
+		}
+	}
+	if result.taskRunner != "" {
+		log.TPrintf("- task runner: %s", result.taskRunner)
+	}
+
+	for _, packageJSONPth := range nodeWorkspace.Packages {
+		packageDir, err := filepath.Rel(nodeWorkspace.RootDir, filepath.Dir(packageJSONPth))
+		if err != nil {
+			continue
+		}
+		result.packageDirs = append(result.packageDirs, packageDir)
+
+		packages, err := utility.ParsePackagesJSON(packageJSONPth)
+		if err != nil {
+			log.TWarnf("Failed to parse %s: %s", packageJSONPth, err)
+			continue
+		}
+		if _, ok := packages.Scripts["lint"]; ok {
+			result.lintPackages = append(result.lintPackages, packageDir)
+		}
+		if _, ok := packages.Scripts["test"]; ok {
+			result.testPackages = append(result.testPackages, packageDir)
+		}
+	}
+	log.TPrintf("- %d packages, %d with lint script, %d with test script", len(result.packageDirs), len(result.lintPackages), len(result.testPackages))
+
+	return result
+}
+
+// detectFramework returns the JS framework detected from package.json dependencies.
+// Returns "nextjs", "nestjs", or "" if none is detected.
+func detectFramework(packageJsonPath string) string {
+	log.TPrintf("Checking framework")
+
+	packages, err := utility.ParsePackagesJSON(packageJsonPath)
+	if err != nil {
+		log.TPrintf("- framework - failed to parse package.json: %s", err)
+		return ""
+	}
+
+	allDeps := make(map[string]string)
+	for k, v := range packages.Dependencies {
+		allDeps[k] = v
+	}
+	for k, v := range packages.DevDependencies {
+		allDeps[k] = v
+	}
+
+	if _, ok := allDeps["next"]; ok {
+		log.TPrintf("- framework: nextjs")
+		return "nextjs"
+	}
+	if _, ok := allDeps["@nestjs/core"]; ok {
+		log.TPrintf("- framework: nestjs")
+		return "nestjs"
+	}
+
+	log.TPrintf("- framework - not detected")
+	return ""
+}
+
+// detectNodeVersion returns the Node.js version declared in version files or package.json engines.
+// Sources checked in order: .nvmrc, .node-version, .tool-versions, engines.node in package.json.
+// Returns an empty string if no version is found.
+func detectNodeVersion(projectDir, packageJsonPath string) string {
+	log.TPrintf("Checking Node.js version")
+
+	// .nvmrc — single line containing the version (e.g. "22" or "22.14.0")
+	if content, err := metrics.ReadStringFromFile(filepath.Join(projectDir, ".nvmrc")); err == nil {
+		version := strings.TrimSpace(content)
+		if version != "" {
+			log.TPrintf("- .nvmrc - found (%s)", version)
+			return version
+		}
+	}
+
+	// .node-version — same format as .nvmrc
+	if content, err := metrics.ReadStringFromFile(filepath.Join(projectDir, ".node-version")); err == nil {
+		version := strings.TrimSpace(content)
+		if version != "" {
+			log.TPrintf("- .node-version - found (%s)", version)
+			return version
+		}
+	}
+
+	// .tool-versions — asdf/mise format: "nodejs <version>"
+	if content, err := metrics.ReadStringFromFile(filepath.Join(projectDir, ".tool-versions")); err == nil {
+		for _, line := range strings.Split(content, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) >= 2 && fields[0] == "nodejs" {
+				log.TPrintf("- .tool-versions - found nodejs %s", fields[1])
+				return fields[1]
+			}
+		}
+	}
+
+	// engines.node in package.json — semver range, e.g. ">=22.0.0"
+	packages, err := utility.ParsePackagesJSON(packageJsonPath)
+	if err == nil {
+		if constraint, ok := packages.Engines["node"]; ok && constraint != "" {
+			version := parseEnginesNodeVersion(constraint)
+			if version != "" {
+				log.TPrintf("- engines.node - found (%s → %s)", constraint, version)
+				return version
+			}
+		}
+	}
+
+	log.TPrintf("- Node.js version - not found")
+	return ""
+}
+
+// parseEnginesNodeVersion strips leading semver operators and returns the first version string.
+// e.g. ">=22.0.0" → "22.0.0", "^18.17.0" → "18.17.0"
+func parseEnginesNodeVersion(constraint string) string {
+	constraint = strings.TrimSpace(constraint)
+	i := 0
+	for i < len(constraint) {
+		c := constraint[i]
+		if c == '>' || c == '<' || c == '=' || c == '^' || c == '~' || c == 'v' || c == ' ' {
+			i++
+		} else {
+			break
+		}
+	}
+	version := strings.TrimSpace(constraint[i:])
+	// Take only the first token in case of compound ranges like ">=18.0.0 <20"
+	if parts := strings.Fields(version); len(parts) > 0 {
+		return parts[0]
+	}
+	return ""
+}
+
+// Options & Configs
+type configDescriptor struct {
+	workdir           string
+	pkgManager        string
+	pkgManagerVersion string
+	lockFile          string
+	hasLint           bool
+	hasTest           bool
+	isDefault         bool
+	nodeVersion       string
+
+	// workspace roots run the tasks through the task runner, or in the packages if the root has no such script
+	isWorkspace  bool
+	taskRunner   string
+	lintPackages []string
+	testPackages []string
+
+	serviceContainers []utility.ServiceContainer
+}
+
+func createConfigDescriptor(project project, isDefault bool) configDescriptor {
+	descriptor := configDescriptor{
+		workdir:           "$" + projectDirInputEnvKey,
+		pkgManager:        project.packageManager,
+		pkgManagerVersion: project.packageManagerVersion,
+		lockFile:          project.lockFile,
+		serviceContainers: project.serviceContainers,
+		hasLint:           project.hasLint,
+		hasTest:           project.hasTest,
+		isDefault:         isDefault,
+		nodeVersion:       project.nodeVersion,
+	}
+
+	if project.workspace != nil {
+		descriptor.isWorkspace = true
+		descriptor.taskRunner = project.workspace.taskRunner
+		if !slices.Contains(project.scripts, "lint") {
+			descriptor.lintPackages = project.workspace.lintPackages
+		}
+		if !slices.Contains(project.scripts, "test") {
+			descriptor.testPackages = project.workspace.testPackages
+		}
+	}
+
+	// package.json placed in the search dir, no need to change-dir
+	if project.projectRelDir == "." {
+		descriptor.workdir = ""
+	}
+
+	return descriptor
+}
+
+func createDefaultConfigDescriptor(packageManager string) configDescriptor {
+	return createConfigDescriptor(project{
+		projectRelDir:  "$" + projectDirInputEnvKey,
+		packageManager: packageManager,
+		lockFile:       defaultLockFile(packageManager),
+		hasLint:        true,
+		hasTest:        true,
+	}, true)
+}
+
+func configName(params configDescriptor) string {
+	name := "node-js"
+
+	if params.pkgManager != "" {
+		name = name + "-" + params.pkgManager
+	}
+	if params.pkgManagerVersion != "" {
+		name = name + "-" + params.pkgManagerVersion
+	}
+
+	if params.isDefault {
+		return "default-" + name + "-config"
+	}
+
+	if params.workdir == "" {
+		name = name + "-root"
+	}
+
+	if params.isWorkspace {
+		name = name + "-workspace"
+		if params.taskRunner != "" {
+			name = name + "-" + params.taskRunner
+		}
+	}
+
+	if params.hasLint {
+		name = name + "-lint"
+	}
+	if params.hasTest {
+		name = name + "-test"
+	}
+	for _, container := range params.serviceContainers {
+		name = name + "-" + container.Name
+	}
+
+	return name + "-config"
+}
+
+func generateOptions(projects []project) (models.OptionNode, models.Warnings, models.Icons, error) {
+	if len(projects) == 0 {
+		return models.OptionNode{}, nil, nil, fmt.Errorf("no package.json files found")
+	}
+
+	projectRootOption := models.NewOption(projectDirInputTitle, projectDirInputSummary, projectDirInputEnvKey, models.TypeSelector)
+	for _, project := range projects {
+		options := generateProjectOption(project)
+		projectRootOption.AddOption(project.projectRelDir, &options)
+	}
+
+	return *projectRootOption, nil, nil, nil
+}
+
+func generateProjectOption(project project) models.OptionNode {
+	descriptor := createConfigDescriptor(project, false)
+
+	packageManagerOption := models.NewOption(packageManagerInputTitle, packageManagerInputSummary, "", models.TypeSelector)
+	if project.packageManager != "" {
+		configOption := models.NewConfigOption(configName(descriptor), nil)
+		packageManagerOption.AddConfig(project.packageManager, configOption)
+	} else {
+		for _, pkgMgr := range pkgManagers {
+			descriptor.pkgManager = pkgMgr
+			configOption := models.NewConfigOption(configName(descriptor), nil)
+			packageManagerOption.AddConfig(pkgMgr, configOption)
+		}
+	}
+
+	return *packageManagerOption
+}
+
+func generateConfigs(projects []project, sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	configs := models.BitriseConfigMap{}
+
+	if len(projects) == 0 {
+		return models.BitriseConfigMap{}, fmt.Errorf("no package.json files found")
+	}
+
+	for _, project := range projects {
+		descriptor := createConfigDescriptor(project, false)
+
+		if project.packageManager != "" {
+			config, err := generateConfigBasedOn(descriptor, sshKeyActivation)
+			if err != nil {
+				return nil, err
+			}
+			configs[configName(descriptor)] = config
+		} else {
+			for _, pkgMgr := range pkgManagers {
+				descriptor.pkgManager = pkgMgr
+				config, err := generateConfigBasedOn(descriptor, sshKeyActivation)
+				if err != nil {
+					return nil, err
+				}
+				configs[configName(descriptor)] = config
+			}
+		}
+	}
+
+	return configs, nil
+}
+
+func generateConfigBasedOn(descriptor configDescriptor, sshKey models.SSHKeyActivation) (string, error) {
+	configBuilder := models.NewDefaultConfigBuilder()
+	if descriptor.nodeVersion != "" {
+		configBuilder.AddTool("node", descriptor.nodeVersion)
+	}
+
+	prepareSteps := steps.DefaultPrepareStepList(steps.PrepareListParams{SSHKeyActivation: sshKey})
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, prepareSteps...)
+
+	if descriptor.isDefault {
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Install Node.js", nodeVersionInstallScriptContent))
+	}
+
+	if setupStep, ok := steps.SetupNodePackageManagerStepListItem(descriptor.pkgManager, descriptor.pkgManagerVersion, descriptor.workdir); ok {
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, setupStep)
+	}
+
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.RestoreNodePackageManagerCache(descriptor.pkgManager, descriptor.lockFile))
+
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.NodePackageManagerStepListItem(descriptor.pkgManager, installCommand(descriptor), descriptor.workdir))
+	serviceContainerRefs := utility.ServiceContainerReferences(descriptor.serviceContainers)
+	if descriptor.isWorkspace && descriptor.taskRunner != "" {
+		if tasks := taskRunnerTasks(descriptor); len(tasks) > 0 {
+			runTasksStep := steps.ScriptStepListItem("Run tasks", taskRunnerScript(descriptor, tasks), workdirInputs(descriptor.workdir)...)
+			configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.WithServiceContainers(runTasksStep, serviceContainerRefs))
+		}
+	} else {
+		if descriptor.hasLint {
+			configBuilder.AppendStepListItemsTo(runTestsWorkflowID, scriptStep(descriptor, "lint", descriptor.lintPackages))
+		}
+		if descriptor.hasTest {
+			configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.WithServiceContainers(scriptStep(descriptor, "test", descriptor.testPackages), serviceContainerRefs))
+		}
+	}
+
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.SaveNodePackageManagerCache(descriptor.pkgManager, descriptor.lockFile))
+
+	if len(descriptor.serviceContainers) > 0 {
+		configBuilder.SetContainerDefinitions(utility.ServiceContainerDefinitions(descriptor.serviceContainers))
+	}
+
+	config, err := configBuilder.Generate(ScannerName, utility.ServiceContainerAppEnvs(descriptor.serviceContainers)...)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// installCommand returns the dependency install command, pnpm and Bun fail instead of updating the committed lock file.
+func installCommand(descriptor configDescriptor) string {
+	switch descriptor.pkgManager {
+	case utility.NodePackageManagerPnpm, utility.NodePackageManagerBun:
+		if descriptor.lockFile != "" {
+			return "install --frozen-lockfile"
+		}
+	}
+	return "install"
+}
+
+// defaultLockFile returns the lock file of the package manager, used by the configs of the manual configuration flow.
+func defaultLockFile(pkgManager string) string {
+	for _, lockFile := range utility.NodePackageManagerLockFiles {
+		if lockFile.PackageManager == pkgManager {
+			return lockFile.LockFile
+		}
+	}
+	return ""
+}
+
+// scriptStep runs the package.json script in the project dir, or in each of the workspace packages having the script.
+func scriptStep(descriptor configDescriptor, script string, packageDirs []string) bitriseModels.StepListItemModel {
+	if len(packageDirs) == 0 {
+		return steps.NodePackageManagerStepListItem(descriptor.pkgManager, "run "+script, descriptor.workdir)
+	}
+
+	pkgManager := descriptor.pkgManager
+	if pkgManager == "" {
+		pkgManager = "npm"
+	}
+	content := fmt.Sprintf(`#!/usr/bin/env bash
+set -euxo pipefail
+
+for package in %s; do
+  (cd "$package" && %s run %s)
+done
+`, strings.Join(shellQuoteAll(packageDirs), " "), pkgManager, script)
+	return steps.ScriptStepListItem(fmt.Sprintf("Run %s in workspace packages", script), content, workdirInputs(descriptor.workdir)...)
+}
+
+// taskRunnerTasks returns the lint and test tasks run by the task runner.
+func taskRunnerTasks(descriptor configDescriptor) []string {
+	var tasks []string
+	if descriptor.hasLint {
+		tasks = append(tasks, "lint")
+	}
+	if descriptor.hasTest {
+		tasks = append(tasks, "test")
+	}
+	return tasks
+}
+
+// taskRunnerScript runs the tasks of the workspace packages affected by the change with Nx, or all of them with Turborepo,
+// which skips the packages with unchanged inputs by its cache.
+func taskRunnerScript(descriptor configDescriptor, tasks []string) string {
+	runner := "npx"
+	switch descriptor.pkgManager {
+	case utility.NodePackageManagerYarn:
+		runner = "yarn"
+	case utility.NodePackageManagerPnpm:
+		runner = "pnpm exec"
+	case utility.NodePackageManagerBun:
+		runner = "bunx"
+	}
+
+	script := "#!/usr/bin/env bash\nset -euxo pipefail\n\n"
+	if descriptor.taskRunner == utility.NodeTaskRunnerNx {
+		script += fmt.Sprintf(`# Nx compares the commit to the merge base with the base commit, the shallow clones lack the history to find it
+if [ "$(git rev-parse --is-shallow-repository)" = "true" ]; then
+  git fetch --unshallow origin
+fi
+
+# Pull request builds are compared to the target branch, other builds to the previous commit
+if [ -n "${BITRISEIO_GIT_BRANCH_DEST:-}" ]; then
+  git fetch origin "$BITRISEIO_GIT_BRANCH_DEST"
+  %[1]s nx affected -t %[2]s --base=FETCH_HEAD
+elif git rev-parse --verify --quiet HEAD~1 >/dev/null; then
+  %[1]s nx affected -t %[2]s --base=HEAD~1
+else
+  # the first commit affects every project
+  %[1]s nx run-many -t %[2]s
+fi
+`, runner, strings.Join(tasks, " "))
+		return script
+	}
+	return script + fmt.Sprintf("%s turbo run %s\n", runner, strings.Join(tasks, " "))
+}
+
+// shellQuoteAll quotes the values as single-quoted shell words.
+func shellQuoteAll(values []string) []string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, "'"+strings.ReplaceAll(value, "'", `'"'"'`)+"'")
+	}
+	return quoted
+}
+
+func workdirInputs(workdir string) []envmanModels.EnvironmentItemModel {
+	if workdir == "" {
+		return nil
+	}
+	return []envmanModels.EnvironmentItemModel{{"working_dir": workdir}}
+}
@@ -0,0 +1,80 @@
+package nodejs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bitrise-io/bitrise-init/utility"
+	stepmanModels "github.com/bitrise-io/stepman/models"
+)
+
+func TestScriptStepQuotesPackageDirs(t *testing.T) {
+	descriptor := configDescriptor{pkgManager: utility.NodePackageManagerPnpm}
+	step := scriptStep(descriptor, "test", []string{"packages/a", "apps/my app", "apps/it's"})
+
+	for _, value := range step {
+		stepModel, ok := value.(stepmanModels.StepModel)
+		require.True(t, ok)
+		require.Contains(t, stepModel.Inputs[0]["content"], `for package in 'packages/a' 'apps/my app' 'apps/it'"'"'s'; do
+  (cd "$package" && pnpm run test)
+done`)
+	}
+}
+
+func TestTaskRunnerScript(t *testing.T) {
+	tests := []struct {
+		name       string
+		descriptor configDescriptor
+		wantLines  []string
+	}{
+		{
+			name:       "Nx unshallows the clone and falls back to every project without a previous commit",
+			descriptor: configDescriptor{pkgManager: utility.NodePackageManagerYarn, taskRunner: utility.NodeTaskRunnerNx},
+			wantLines: []string{
+				"  git fetch --unshallow origin",
+				"  yarn nx affected -t lint test --base=FETCH_HEAD",
+				"elif git rev-parse --verify --quiet HEAD~1 >/dev/null; then",
+				"  yarn nx affected -t lint test --base=HEAD~1",
+				"  yarn nx run-many -t lint test",
+			},
+		},
+		{
+			name:       "Turborepo",
+			descriptor: configDescriptor{pkgManager: utility.NodePackageManagerPnpm, taskRunner: utility.NodeTaskRunnerTurbo},
+			wantLines:  []string{"pnpm exec turbo run lint test"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := taskRunnerScript(tt.descriptor, []string{"lint", "test"})
+			for _, line := range tt.wantLines {
+				require.Contains(t, script, line+"\n")
+			}
+		})
+	}
+}
+
+func TestConfigName(t *testing.T) {
+	tests := []struct {
+		name       string
+		descriptor configDescriptor
+		want       string
+	}{
+		{
+			name:       "pinned package manager version",
+			descriptor: configDescriptor{pkgManager: utility.NodePackageManagerBun, pkgManagerVersion: "1.1.30", hasTest: true},
+			want:       "node-js-bun-1.1.30-root-test-config",
+		},
+		{
+			name:       "workspace with a task runner",
+			descriptor: configDescriptor{workdir: "$" + projectDirInputEnvKey, pkgManager: utility.NodePackageManagerPnpm, isWorkspace: true, taskRunner: utility.NodeTaskRunnerTurbo, hasLint: true},
+			want:       "node-js-pnpm-workspace-turbo-lint-config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, configName(tt.descriptor))
+		})
+	}
+}
@@ -0,0 +1,454 @@
+package python
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/steps"
+	"github.com/bitrise-io/bitrise-init/utility"
+	bitriseModels "github.com/bitrise-io/bitrise/v2/models"
+	envmanModels "github.com/bitrise-io/envman/v2/models"
+	stepmanModels "github.com/bitrise-io/stepman/models"
+)
+
+const (
+	runTestsWorkflowID = models.WorkflowID("run_tests")
+
+	pipCachePaths    = "~/.cache/pip"
+	poetryCachePaths = "~/.cache/pypoetry"
+	uvCachePaths     = "~/.cache/uv"
+
+	pythonVersionInstallScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+bitrise tools install python $PYTHON_VERSION
+`
+
+	pipInstallScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+pip install --upgrade pip
+pip install -r requirements.txt
+`
+	pipInstallWithDevScriptTemplate = `#!/usr/bin/env bash
+set -euxo pipefail
+
+pip install --upgrade pip
+pip install -r requirements.txt
+pip install -r %s
+`
+
+	pytestRunScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+pytest
+`
+
+	poetryInstallScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+pip install poetry
+poetry install
+`
+
+	poetryInstallNoRootScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+pip install poetry
+poetry install --no-root
+`
+
+	poetryPytestRunScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+poetry run pytest
+`
+
+	uvSyncScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+pip install uv
+uv sync
+`
+
+	uvPytestRunScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+uv run pytest
+`
+
+	systemDepsInstallScriptStepTitle = "Install system dependencies"
+
+	preCommitCachePaths = "~/.cache/pre-commit"
+
+	preCommitRunScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+pip install pre-commit
+pre-commit run --all-files --show-diff-on-failure
+`
+)
+
+type configDescriptor struct {
+	workdir             string
+	packageManager      string
+	hasPytest           bool
+	pythonVersion       string
+	devRequirementsFile string
+	poetryNeedsNoRoot   bool
+	installsPytest      bool
+	framework           string
+	managePyPath        string
+	databases           []utility.DetectedDatabase
+	serviceContainers   []utility.ServiceContainer
+	sessionRunner       string
+	sessionSource       string
+	sessionPythons      []string
+	selectsSession      bool // the session to run is selected by the session input
+	hasPreCommit        bool
+	isDefault           bool
+}
+
+// packageManagerSetup holds the per-package-manager bits used by the run_tests
+// workflow: cache config (key prefix + lockfile + paths) and install/test
+// scripts. generateConfigBasedOn resolves one of these and then runs a uniform
+// restore-install-test-save sequence.
+type packageManagerSetup struct {
+	cacheKeyPrefix string
+	cacheLockFile  string
+	cachePaths     string
+	installScript  string
+	testScript     string
+	runPrefix      string // runs a command in the project's virtualenv
+}
+
+func createConfigDescriptor(proj project, isDefault bool) configDescriptor {
+	d := configDescriptor{
+		workdir:             "$" + projectDirInputEnvKey,
+		packageManager:      proj.packageManager,
+		hasPytest:           proj.hasPytest,
+		pythonVersion:       proj.pythonVersion,
+		devRequirementsFile: proj.devRequirementsFile,
+		poetryNeedsNoRoot:   proj.poetryNeedsNoRoot,
+		installsPytest:      proj.installsPytest,
+		framework:           proj.framework,
+		databases:           proj.databases,
+		serviceContainers:   proj.serviceContainers,
+		isDefault:           isDefault,
+	}
+	// pytest-django projects run the Django tests with pytest
+	if proj.framework == frameworkDjango && !proj.hasPytestDjango {
+		d.managePyPath = proj.managePyPath
+	}
+	if proj.sessions != nil {
+		d.sessionRunner = proj.sessions.runner
+		d.sessionSource = proj.sessions.source
+		d.sessionPythons = proj.sessions.pythons
+		d.selectsSession = len(proj.sessions.sessions) > 0
+	}
+	d.hasPreCommit = proj.hasPreCommit
+	if proj.projectRelDir == "." {
+		d.workdir = ""
+	}
+	return d
+}
+
+func createDefaultConfigDescriptor(packageManager string) configDescriptor {
+	return createConfigDescriptor(project{
+		projectRelDir:     "$" + projectDirInputEnvKey,
+		packageManager:    packageManager,
+		hasPytest:         true,
+		poetryNeedsNoRoot: true,
+	}, true)
+}
+
+func generateOptions(projects []project) (models.OptionNode, models.Warnings, models.Icons, error) {
+	if len(projects) == 0 {
+		return models.OptionNode{}, nil, nil, fmt.Errorf("no Python project files found")
+	}
+
+	projectRootOption := models.NewOption(projectDirInputTitle, projectDirInputSummary, projectDirInputEnvKey, models.TypeSelector)
+	for _, proj := range projects {
+		// tox and nox install the project into their own virtualenvs, the package manager is not used
+		if proj.packageManager != "" || proj.sessions != nil {
+			addConfigOption(projectRootOption, proj.projectRelDir, proj, createConfigDescriptor(proj, false))
+		} else {
+			pkgMgrOption := models.NewOption(packageManagerInputTitle, packageManagerInputSummary, "", models.TypeSelector)
+			for _, pm := range packageManagers {
+				descriptor := createConfigDescriptor(proj, false)
+				descriptor.packageManager = pm
+				addConfigOption(pkgMgrOption, pm, proj, descriptor)
+			}
+			projectRootOption.AddOption(proj.projectRelDir, pkgMgrOption)
+		}
+	}
+
+	return *projectRootOption, nil, nil, nil
+}
+
+// addConfigOption adds the config of the descriptor for the value, behind a session selector if the project's sessions are static.
+func addConfigOption(option *models.OptionNode, value string, proj project, descriptor configDescriptor) {
+	configOption := models.NewConfigOption(configName(descriptor), nil)
+	if !descriptor.selectsSession {
+		option.AddConfig(value, configOption)
+		return
+	}
+
+	sessionOption := models.NewOption(noxSessionInputTitle, noxSessionInputSummary, noxSessionInputEnvKey, models.TypeSelector)
+	if proj.sessions.runner == sessionRunnerTox {
+		sessionOption = models.NewOption(toxEnvInputTitle, toxEnvInputSummary, toxEnvInputEnvKey, models.TypeSelector)
+	}
+	for _, session := range proj.sessions.sessions {
+		sessionOption.AddConfig(session, configOption)
+	}
+	option.AddOption(value, sessionOption)
+}
+
+func generateConfigs(projects []project, sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	if len(projects) == 0 {
+		return models.BitriseConfigMap{}, fmt.Errorf("no Python project files found")
+	}
+
+	configs := models.BitriseConfigMap{}
+	for _, proj := range projects {
+		if proj.packageManager != "" || proj.sessions != nil {
+			descriptor := createConfigDescriptor(proj, false)
+			config, err := generateConfigBasedOn(descriptor, sshKeyActivation)
+			if err != nil {
+				return nil, err
+			}
+			configs[configName(descriptor)] = config
+		} else {
+			for _, pm := range packageManagers {
+				descriptor := createConfigDescriptor(proj, false)
+				descriptor.packageManager = pm
+				config, err := generateConfigBasedOn(descriptor, sshKeyActivation)
+				if err != nil {
+					return nil, err
+				}
+				configs[configName(descriptor)] = config
+			}
+		}
+	}
+	return configs, nil
+}
+
+func configName(d configDescriptor) string {
+	if d.isDefault {
+		return "default-python-" + d.packageManager + "-config"
+	}
+
+	name := "python"
+	if d.workdir == "" {
+		name += "-root"
+	}
+	if d.sessionRunner != "" {
+		name += "-" + d.sessionRunner
+	} else {
+		name += "-" + d.packageManager
+	}
+	if d.framework != "" {
+		name += "-" + d.framework
+	}
+	switch {
+	case d.sessionRunner != "":
+		// the tests run in the sessions
+	case d.managePyPath != "":
+		name += "-managepy"
+	case d.hasPytest:
+		name += "-pytest"
+	}
+	if d.hasPreCommit {
+		name += "-precommit"
+	}
+	for _, container := range d.serviceContainers {
+		name += "-" + container.Name
+	}
+	return name + "-config"
+}
+
+func generateConfigBasedOn(d configDescriptor, sshKey models.SSHKeyActivation) (string, error) {
+	configBuilder := models.NewDefaultConfigBuilder()
+
+	if d.pythonVersion != "" {
+		configBuilder.AddTool("python", d.pythonVersion)
+	}
+
+	prepareSteps := steps.DefaultPrepareStepList(steps.PrepareListParams{SSHKeyActivation: sshKey})
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, prepareSteps...)
+
+	if d.isDefault {
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Install Python", pythonVersionInstallScriptContent))
+	}
+
+	if d.hasPreCommit {
+		// pre-commit installs the hooks into their own environments, it runs before installing the project
+		preCommitKey := cacheKey("pre-commit", preCommitConfigFile)
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID,
+			steps.RestoreCache(preCommitKey),
+			steps.ScriptStepListItem("Run pre-commit", preCommitRunScriptContent, workdirInputs(d.workdir)...),
+			steps.SaveCache(preCommitKey, preCommitCachePaths),
+		)
+	}
+
+	serviceContainerRefs := utility.ServiceContainerReferences(d.serviceContainers)
+	if d.sessionRunner != "" {
+		key := cacheKey(d.sessionRunner, d.sessionSource)
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.RestoreCache(key))
+		if aptPackages := collectAptPackages(d.databases); len(aptPackages) > 0 {
+			configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem(systemDepsInstallScriptStepTitle, generateSystemDepsScript(aptPackages)))
+		}
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Install "+d.sessionRunner, sessionInstallScript(d)))
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, testStep(sessionRunScript(d), serviceContainerRefs, d.workdir))
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.SaveCache(key, pipCachePaths+"\n"+uvCachePaths))
+	} else {
+		setup := packageManagerSetupFor(d)
+		key := cacheKey(setup.cacheKeyPrefix, setup.cacheLockFile)
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.RestoreCache(key))
+		if aptPackages := collectAptPackages(d.databases); len(aptPackages) > 0 {
+			configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem(systemDepsInstallScriptStepTitle, generateSystemDepsScript(aptPackages)))
+		}
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Install dependencies", setup.installScript, workdirInputs(d.workdir)...))
+
+		switch {
+		case d.managePyPath != "":
+			configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Run Django checks", runScript(setup, "python "+d.managePyPath+" check --deploy"), workdirInputs(d.workdir)...))
+			configBuilder.AppendStepListItemsTo(runTestsWorkflowID, testStep(runScript(setup, "python "+d.managePyPath+" test"), serviceContainerRefs, d.workdir))
+		case d.hasPytest:
+			configBuilder.AppendStepListItemsTo(runTestsWorkflowID, testStep(pytestScript(d, setup), serviceContainerRefs, d.workdir))
+		}
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.SaveCache(key, setup.cachePaths))
+	}
+
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.DefaultDeployStepList()...)
+
+	if len(d.serviceContainers) > 0 {
+		configBuilder.SetContainerDefinitions(utility.ServiceContainerDefinitions(d.serviceContainers))
+	}
+
+	bitriseConfig, err := configBuilder.Generate(scannerName, utility.ServiceContainerAppEnvs(d.serviceContainers)...)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := yaml.Marshal(bitriseConfig)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// packageManagerSetupFor returns the cache config and scripts for the package
+// manager named in d. Pip and Poetry inject extra context (dev requirements
+// file, --no-root) from the descriptor; uv has no per-project variants.
+func packageManagerSetupFor(d configDescriptor) packageManagerSetup {
+	switch d.packageManager {
+	case "uv":
+		return packageManagerSetup{
+			cacheKeyPrefix: "uv",
+			cacheLockFile:  "uv.lock",
+			cachePaths:     uvCachePaths,
+			installScript:  uvSyncScriptContent,
+			testScript:     uvPytestRunScriptContent,
+			runPrefix:      "uv run ",
+		}
+	case "poetry":
+		install := poetryInstallScriptContent
+		if d.poetryNeedsNoRoot {
+			install = poetryInstallNoRootScriptContent
+		}
+		return packageManagerSetup{
+			cacheKeyPrefix: "poetry",
+			cacheLockFile:  "poetry.lock",
+			cachePaths:     poetryCachePaths,
+			installScript:  install,
+			testScript:     poetryPytestRunScriptContent,
+			runPrefix:      "poetry run ",
+		}
+	default: // pip
+		install := pipInstallScriptContent
+		if d.devRequirementsFile != "" {
+			install = fmt.Sprintf(pipInstallWithDevScriptTemplate, d.devRequirementsFile)
+		}
+		return packageManagerSetup{
+			cacheKeyPrefix: "pip",
+			cacheLockFile:  "requirements.txt",
+			cachePaths:     pipCachePaths,
+			installScript:  install,
+			testScript:     pytestRunScriptContent,
+		}
+	}
+}
+
+// runScript returns a script running the command in the project's virtualenv.
+func runScript(setup packageManagerSetup, command string) string {
+	return "#!/usr/bin/env bash\nset -euxo pipefail\n\n" + setup.runPrefix + command + "\n"
+}
+
+// pytestScript returns the pytest script of the package manager, installing pytest first if it is not a declared dependency.
+// FastAPI's TestClient needs httpx too.
+func pytestScript(d configDescriptor, setup packageManagerSetup) string {
+	if !d.installsPytest {
+		return setup.testScript
+	}
+	packages := []string{"pytest"}
+	if d.framework == frameworkFastAPI {
+		packages = append(packages, "httpx")
+	}
+	if d.packageManager == "uv" {
+		return runScript(setup, "--with "+strings.Join(packages, " --with ")+" pytest")
+	}
+	return "#!/usr/bin/env bash\nset -euxo pipefail\n\n" + setup.runPrefix + "pip install " + strings.Join(packages, " ") + "\n" + setup.runPrefix + "pytest\n"
+}
+
+// sessionInstallScript installs tox or nox with the uv backend, and the Python versions of the sessions with uv.
+func sessionInstallScript(d configDescriptor) string {
+	packages := "uv nox"
+	if d.sessionRunner == sessionRunnerTox {
+		packages = "uv tox tox-uv"
+	}
+	script := "#!/usr/bin/env bash\nset -euxo pipefail\n\npip install " + packages + "\n"
+	if len(d.sessionPythons) > 0 {
+		script += "uv python install " + strings.Join(d.sessionPythons, " ") + "\n"
+	}
+	return script
+}
+
+// sessionRunScript runs the selected session, or the default sessions if the sessions are not listed statically.
+func sessionRunScript(d configDescriptor) string {
+	command := "nox --default-venv-backend uv"
+	if d.selectsSession {
+		command += ` --session "$` + noxSessionInputEnvKey + `"`
+	}
+	if d.sessionRunner == sessionRunnerTox {
+		command = "tox"
+		if d.selectsSession {
+			command += ` -e "$` + toxEnvInputEnvKey + `"`
+		}
+	}
+	return "#!/usr/bin/env bash\nset -euxo pipefail\n\n" + command + "\n"
+}
+
+// testStep returns the test Script step, attached to the database service containers if any.
+func testStep(content string, serviceContainerRefs []stepmanModels.ContainerReference, workdir string) bitriseModels.StepListItemModel {
+	return steps.WithServiceContainers(steps.ScriptStepListItem("Run tests", content, workdirInputs(workdir)...), serviceContainerRefs)
+}
+
+func generateSystemDepsScript(packages []string) string {
+	return "#!/usr/bin/env bash\nset -euxo pipefail\n\napt-get update\napt-get install -y " + strings.Join(packages, " ") + "\n"
+}
+
+func cacheKey(prefix, lockFile string) string {
+	return fmt.Sprintf(`%s-{{ checksum "%s" }}`, prefix, lockFile)
+}
+
+func workdirInputs(workdir string) []envmanModels.EnvironmentItemModel {
+	if workdir == "" {
+		return nil
+	}
+	return []envmanModels.EnvironmentItemModel{{"working_dir": workdir}}
+}
@@ -0,0 +1,82 @@
+package python
+
+import (
+	"sort"
+
+	"github.com/bitrise-io/bitrise-init/utility"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+// databasePackage represents the driver packages of a database, the database runs in a service container.
+type databasePackage struct {
+	packageNames []string // any of the driver packages selects the database
+	database     string   // one of the utility.ServiceDatabase constants
+	// sourceDistributed drivers have no binary wheels, building them needs the aptPackages
+	sourceDistributed []string
+	aptPackages       []string
+}
+
+var knownDatabasePackages = []databasePackage{
+	{
+		packageNames:      []string{"psycopg", "psycopg2", "psycopg2-binary", "psycopg-binary", "asyncpg"},
+		database:          utility.ServiceDatabasePostgres,
+		sourceDistributed: []string{"psycopg2"},
+		aptPackages:       []string{"libpq-dev"},
+	},
+	{
+		packageNames:      []string{"mysqlclient", "pymysql", "mysql-connector-python", "aiomysql"},
+		database:          utility.ServiceDatabaseMySQL,
+		sourceDistributed: []string{"mysqlclient"},
+		aptPackages:       []string{"default-libmysqlclient-dev", "pkg-config"},
+	},
+	{
+		packageNames: []string{"redis", "valkey", "django-redis"},
+		database:     utility.ServiceDatabaseRedis,
+	},
+	{
+		packageNames: []string{"pymongo", "motor", "mongoengine", "beanie"},
+		database:     utility.ServiceDatabaseMongoDB,
+	},
+}
+
+// detectDatabases returns the databases whose driver packages are among the project dependencies.
+func detectDatabases(dependencies map[string]string) []utility.DetectedDatabase {
+	log.TPrintf("Checking database drivers")
+
+	var detected []utility.DetectedDatabase
+	for _, db := range knownDatabasePackages {
+		for _, name := range db.packageNames {
+			file, ok := dependencies[name]
+			if !ok {
+				continue
+			}
+			log.TPrintf("- %s: %s", name, db.database)
+
+			found := utility.DetectedDatabase{Database: db.database, DriverPackage: name, DriverSource: file}
+			for _, sourceDistributed := range db.sourceDistributed {
+				if name == sourceDistributed {
+					found.AptPackages = db.aptPackages
+				}
+			}
+			detected = append(detected, found)
+			break
+		}
+	}
+	return detected
+}
+
+// collectAptPackages returns the system packages of the source distributed drivers, binary wheels (like psycopg2-binary) need none.
+func collectAptPackages(databases []utility.DetectedDatabase) []string {
+	seen := map[string]bool{}
+	var packages []string
+	for _, db := range databases {
+		for _, pkg := range db.AptPackages {
+			if !seen[pkg] {
+				seen[pkg] = true
+				packages = append(packages, pkg)
+			}
+		}
+	}
+	sort.Strings(packages)
+	return packages
+}
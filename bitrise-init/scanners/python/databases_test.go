@@ -0,0 +1,47 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bitrise-io/bitrise-init/utility"
+)
+
+func TestDetectDatabases(t *testing.T) {
+	tests := []struct {
+		name            string
+		dependencies    map[string]string
+		wantDatabases   []utility.DetectedDatabase
+		wantAptPackages []string
+	}{
+		{
+			name:         "binary wheel needs no system packages",
+			dependencies: map[string]string{"psycopg2-binary": "requirements.txt", "redis": "requirements.txt"},
+			wantDatabases: []utility.DetectedDatabase{
+				{Database: utility.ServiceDatabasePostgres, DriverPackage: "psycopg2-binary", DriverSource: "requirements.txt"},
+				{Database: utility.ServiceDatabaseRedis, DriverPackage: "redis", DriverSource: "requirements.txt"},
+			},
+		},
+		{
+			name:         "source distributed drivers need the client library headers",
+			dependencies: map[string]string{"psycopg2": "pyproject.toml", "mysqlclient": "pyproject.toml"},
+			wantDatabases: []utility.DetectedDatabase{
+				{Database: utility.ServiceDatabasePostgres, DriverPackage: "psycopg2", DriverSource: "pyproject.toml", AptPackages: []string{"libpq-dev"}},
+				{Database: utility.ServiceDatabaseMySQL, DriverPackage: "mysqlclient", DriverSource: "pyproject.toml", AptPackages: []string{"default-libmysqlclient-dev", "pkg-config"}},
+			},
+			wantAptPackages: []string{"default-libmysqlclient-dev", "libpq-dev", "pkg-config"},
+		},
+		{
+			name:         "no database drivers",
+			dependencies: map[string]string{"django": "requirements.txt"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			databases := detectDatabases(tt.dependencies)
+			require.Equal(t, tt.wantDatabases, databases)
+			require.Equal(t, tt.wantAptPackages, collectAptPackages(databases))
+		})
+	}
+}
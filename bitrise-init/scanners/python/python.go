@@ -0,0 +1,239 @@
+package python
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/utility"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+const (
+	scannerName = "python"
+
+	projectDirInputTitle   = "Python Project Directory"
+	projectDirInputSummary = "The directory containing the Python project files (requirements.txt, pyproject.toml, etc.)"
+	projectDirInputEnvKey  = "PYTHON_PROJECT_DIR"
+
+	packageManagerInputTitle   = "Package Manager"
+	packageManagerInputSummary = "The package manager used in the project"
+
+	pythonVersionInputTitle   = "Python version"
+	pythonVersionInputSummary = "The Python version to be used for the project. Use exact (3.12.0) or partial (3.12:latest, 3:installed) versions."
+	pythonVersionEnvKey       = "PYTHON_VERSION"
+
+	toxEnvInputTitle   = "tox environment"
+	toxEnvInputSummary = "The tox environment to run, from the env list of the tox configuration"
+	toxEnvInputEnvKey  = "TOX_ENV"
+
+	noxSessionInputTitle   = "nox session"
+	noxSessionInputSummary = "The nox session to run, from the sessions of noxfile.py"
+	noxSessionInputEnvKey  = "NOX_SESSION"
+)
+
+var packageManagers = []string{"pip", "poetry", "uv"}
+
+type project struct {
+	projectRelDir       string
+	packageManager      string
+	hasPytest           bool
+	pythonVersion       string
+	devRequirementsFile string
+	poetryNeedsNoRoot   bool
+	installsPytest      bool // pytest is not a declared dependency
+	framework           string
+	frameworkSource     string // the file declaring the framework dependency
+	managePyPath        string // Django manage.py, relative to the project dir
+	hasPytestDjango     bool
+	databases           []utility.DetectedDatabase
+	serviceContainers   []utility.ServiceContainer
+	serviceSources      []string // files configuring the service containers
+	sessions            *sessionConfig
+	hasPreCommit        bool
+}
+
+// Scanner implements ScannerInterface for Python projects.
+type Scanner struct {
+	searchDir   string
+	projectDirs []string  // relative paths, populated by DetectPlatform
+	projects    []project // populated by Options()
+}
+
+// NewScanner creates a new Scanner instance.
+func NewScanner() *Scanner {
+	return &Scanner{}
+}
+
+// Name returns the scanner name.
+func (s *Scanner) Name() string {
+	return scannerName
+}
+
+// DetectPlatform checks whether searchDir contains a Python project.
+// It only confirms the platform is present; detailed analysis happens in Options().
+func (s *Scanner) DetectPlatform(searchDir string) (bool, error) {
+	s.searchDir = searchDir
+
+	dirs, err := collectPythonProjectDirs(searchDir)
+	if err != nil {
+		log.TWarnf("%s", err)
+		log.TPrintf("Platform not detected")
+		return false, nil
+	}
+
+	for _, dir := range dirs {
+		relDir, err := utility.RelPath(searchDir, dir)
+		if err != nil {
+			log.TWarnf("failed to get relative project dir path: %s", err)
+			continue
+		}
+		log.TPrintf("Python project found: %s", relDir)
+		s.projectDirs = append(s.projectDirs, relDir)
+	}
+
+	if len(s.projectDirs) == 0 {
+		log.TPrintf("Platform not detected")
+		return false, nil
+	}
+
+	log.TSuccessf("Platform detected")
+	return true, nil
+}
+
+// Evidence returns the marker files of the detected project dirs and the tools found in them.
+func (s *Scanner) Evidence() models.Evidences {
+	var evidences models.Evidences
+	for _, relDir := range s.projectDirs {
+		for _, markerFile := range markerFiles {
+			if utility.FileExists(filepath.Join(s.searchDir, relDir, markerFile)) {
+				evidences.Add(filepath.Join(relDir, markerFile), "Python project file")
+			}
+		}
+	}
+	for _, proj := range s.projects {
+		if proj.packageManager != "" {
+			lockFile := packageManagerSetupFor(configDescriptor{packageManager: proj.packageManager}).cacheLockFile
+			evidences.Add(filepath.Join(proj.projectRelDir, lockFile), fmt.Sprintf("%s package manager", proj.packageManager))
+		}
+		if proj.hasPytest {
+			evidences.Add(proj.projectRelDir, "pytest test runner")
+		}
+		if proj.framework != "" {
+			evidences.Add(filepath.Join(proj.projectRelDir, proj.frameworkSource), fmt.Sprintf("%s framework", proj.framework))
+		}
+		if proj.managePyPath != "" {
+			evidences.Add(filepath.Join(proj.projectRelDir, proj.managePyPath), "Django manage.py")
+		}
+		for _, db := range proj.databases {
+			evidences.Add(filepath.Join(proj.projectRelDir, db.DriverSource), fmt.Sprintf("%s database driver", db.DriverPackage))
+		}
+		if proj.sessions != nil {
+			evidences.Add(filepath.Join(proj.projectRelDir, proj.sessions.source), fmt.Sprintf("%s test automation", proj.sessions.runner))
+		}
+		if proj.hasPreCommit {
+			evidences.Add(filepath.Join(proj.projectRelDir, preCommitConfigFile), "pre-commit hooks")
+		}
+		for _, source := range proj.serviceSources {
+			evidences.Add(filepath.Join(proj.projectRelDir, source), "service container configuration")
+		}
+	}
+	return evidences
+}
+
+// ExcludedScannerNames returns scanners to skip when this scanner detects.
+func (s *Scanner) ExcludedScannerNames() []string {
+	return []string{}
+}
+
+// Options performs detailed analysis for each detected project dir and builds the option tree.
+func (s *Scanner) Options() (models.OptionNode, models.Warnings, models.Icons, error) {
+	for _, relDir := range s.projectDirs {
+		absDir := filepath.Join(s.searchDir, relDir)
+		log.TPrintf("Checking: %s", relDir)
+
+		pkgMgr := detectPackageManager(absDir)
+		pythonVersion := detectPythonVersion(absDir)
+		hasPytest := detectTestRunner(absDir)
+		devReqFile := detectDevRequirementsFile(absDir)
+		dependencies := collectDependencies(absDir)
+		framework, frameworkSource := detectFramework(dependencies)
+
+		needsNoRoot := false
+		if pkgMgr == "poetry" {
+			needsNoRoot = detectPoetryNeedsNoRoot(absDir)
+		}
+
+		proj := project{
+			projectRelDir:       relDir,
+			packageManager:      pkgMgr,
+			pythonVersion:       pythonVersion,
+			hasPytest:           hasPytest,
+			devRequirementsFile: devReqFile,
+			poetryNeedsNoRoot:   needsNoRoot,
+			framework:           framework,
+			frameworkSource:     frameworkSource,
+		}
+
+		switch framework {
+		case frameworkDjango:
+			proj.managePyPath = detectManagePy(absDir)
+			_, proj.hasPytestDjango = dependencies["pytest-django"]
+		case frameworkFastAPI, frameworkFlask:
+			// API tests are written for pytest (with the framework's test client), even if pytest is only installed by the CI
+			if !proj.hasPytest && hasTestsDir(absDir) {
+				log.TPrintf("- tests dir - found, running it with pytest")
+				proj.hasPytest = true
+				proj.installsPytest = true
+			}
+		}
+
+		proj.databases = detectDatabases(dependencies)
+		proj.serviceContainers, proj.serviceSources = utility.ServiceContainers(absDir, utility.DatabaseNames(proj.databases))
+		proj.sessions = detectSessions(absDir)
+		proj.hasPreCommit = detectPreCommit(absDir)
+
+		s.projects = append(s.projects, proj)
+	}
+
+	return generateOptions(s.projects)
+}
+
+// Configs generates the pre-made bitrise.yml templates for each detected project.
+func (s *Scanner) Configs(sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	return generateConfigs(s.projects, sshKeyActivation)
+}
+
+// DefaultOptions returns the option tree for the manual configuration flow.
+func (s *Scanner) DefaultOptions() models.OptionNode {
+	projectDirOption := models.NewOption(projectDirInputTitle, projectDirInputSummary, projectDirInputEnvKey, models.TypeUserInput)
+	versionOption := models.NewOption(pythonVersionInputTitle, pythonVersionInputSummary, pythonVersionEnvKey, models.TypeUserInput)
+	pkgMgrOption := models.NewOption(packageManagerInputTitle, packageManagerInputSummary, "", models.TypeSelector)
+
+	projectDirOption.AddOption(models.UserInputOptionDefaultValue, versionOption)
+	versionOption.AddOption(models.UserInputOptionDefaultValue, pkgMgrOption)
+
+	for _, pm := range packageManagers {
+		descriptor := createDefaultConfigDescriptor(pm)
+		configOption := models.NewConfigOption(configName(descriptor), nil)
+		pkgMgrOption.AddConfig(pm, configOption)
+	}
+
+	return *projectDirOption
+}
+
+// DefaultConfigs generates the static bitrise.yml templates for the manual configuration flow.
+func (s *Scanner) DefaultConfigs() (models.BitriseConfigMap, error) {
+	configs := models.BitriseConfigMap{}
+
+	for _, pm := range packageManagers {
+		descriptor := createDefaultConfigDescriptor(pm)
+		config, err := generateConfigBasedOn(descriptor, models.SSHKeyActivationConditional)
+		if err != nil {
+			return nil, err
+		}
+		configs[configName(descriptor)] = config
+	}
+
+	return configs, nil
+}
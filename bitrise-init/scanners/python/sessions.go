@@ -0,0 +1,313 @@
+package python
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/metrics"
+	"github.com/bitrise-io/bitrise-init/utility"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+// Test automation tools running the tests in their own virtualenvs, for a matrix of Python versions.
+const (
+	sessionRunnerTox = "tox"
+	sessionRunnerNox = "nox"
+
+	preCommitConfigFile = ".pre-commit-config.yaml"
+	noxFile             = "noxfile.py"
+)
+
+var (
+	iniSectionPattern = regexp.MustCompile(`^\[([^\]]+)\]\s*$`)
+	// nox sessions are functions decorated with @nox.session or @session (from nox import session)
+	noxSessionPattern     = regexp.MustCompile(`(?s)@(?:nox\.)?session\b(\s*\((.*?)\))?\s*\n\s*def\s+(\w+)\s*\(`)
+	noxNamePattern        = regexp.MustCompile(`\bname\s*=\s*["']([^"']+)["']`)
+	noxPythonPattern      = regexp.MustCompile(`\b(?:python|py)\s*=\s*(\[[^\]]*\]|\([^)]*\)|["'][^"']*["']|\w+)`)
+	stringLiteralPattern  = regexp.MustCompile(`["']([^"']+)["']`)
+	moduleListPattern     = regexp.MustCompile(`(?m)^(\w+)\s*=\s*(\[[^\]]*\]|\([^)]*\))`)
+	braceGroupPattern     = regexp.MustCompile(`\{([^{}]*)\}`)
+	numericRangePattern   = regexp.MustCompile(`^(\d+)-(\d+)$`)
+	toxPyFactorPattern    = regexp.MustCompile(`^py(\d)(\d+)$`)
+	toxDotFactorPattern   = regexp.MustCompile(`^(?:py)?(\d)\.(\d+)$`)
+	pythonVersionPattern  = regexp.MustCompile(`^\d+\.\d+$`)
+	pyprojectLegacyTOXINI = "legacy_tox_ini"
+)
+
+// sessionConfig holds the tox environments or nox sessions of the project, and the Python versions they run on.
+type sessionConfig struct {
+	runner   string // sessionRunnerTox or sessionRunnerNox
+	source   string // the file defining the sessions, relative to the project dir
+	sessions []string
+	pythons  []string // sorted X.Y versions
+}
+
+// detectSessions returns the tox environments, or if the project has no tox config, the nox sessions of the project.
+// Sessions are only listed if they are defined statically; computed lists leave sessions empty, and the default sessions run.
+func detectSessions(projectDir string) *sessionConfig {
+	log.TPrintf("Checking tox and nox")
+
+	if config := detectTox(projectDir); config != nil {
+		log.TPrintf("- %s - found, environments: %s", config.source, strings.Join(config.sessions, ", "))
+		return config
+	}
+	if config := detectNox(projectDir); config != nil {
+		log.TPrintf("- %s - found, sessions: %s", config.source, strings.Join(config.sessions, ", "))
+		return config
+	}
+
+	log.TPrintf("- tox and nox - not found")
+	return nil
+}
+
+func detectPreCommit(projectDir string) bool {
+	return utility.FileExists(filepath.Join(projectDir, preCommitConfigFile))
+}
+
+// detectTox reads the env list of tox.ini, setup.cfg, tox.toml or pyproject.toml, in the order of tox's config discovery.
+func detectTox(projectDir string) *sessionConfig {
+	if content, err := metrics.ReadStringFromFile(filepath.Join(projectDir, "tox.ini")); err == nil {
+		return newToxConfig("tox.ini", parseToxINIEnvList(content, "tox"))
+	}
+
+	if content, err := metrics.ReadStringFromFile(filepath.Join(projectDir, "setup.cfg")); err == nil && strings.Contains(content, "[tox:tox]") {
+		return newToxConfig("setup.cfg", parseToxINIEnvList(content, "tox:tox"))
+	}
+
+	if content, ok := readTOML(filepath.Join(projectDir, "tox.toml")); ok {
+		return newToxConfig("tox.toml", tomlStrings(content["env_list"]))
+	}
+
+	if content, ok := readTOML(filepath.Join(projectDir, "pyproject.toml")); ok {
+		toxTable := tomlTable(tomlTable(content, "tool"), "tox")
+		if toxTable == nil {
+			return nil
+		}
+		if legacyINI, ok := toxTable[pyprojectLegacyTOXINI].(string); ok {
+			return newToxConfig("pyproject.toml", parseToxINIEnvList(legacyINI, "tox"))
+		}
+		return newToxConfig("pyproject.toml", tomlStrings(toxTable["env_list"]))
+	}
+
+	return nil
+}
+
+func newToxConfig(source string, envs []string) *sessionConfig {
+	config := &sessionConfig{runner: sessionRunnerTox, source: source, sessions: envs}
+	for _, env := range envs {
+		for _, factor := range strings.Split(env, "-") {
+			if version := toxFactorPythonVersion(factor); version != "" {
+				config.pythons = appendIfMissing(config.pythons, version)
+			}
+		}
+	}
+	sortVersions(config.pythons)
+	return config
+}
+
+// parseToxINIEnvList returns the expanded envlist (or env_list) of the section of a tox INI config.
+func parseToxINIEnvList(content, section string) []string {
+	var (
+		currentSection string
+		value          []string
+		inEnvList      bool
+	)
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if match := iniSectionPattern.FindStringSubmatch(trimmed); match != nil {
+			currentSection = match[1]
+			inEnvList = false
+			continue
+		}
+		if currentSection != section || trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") {
+			continue
+		}
+
+		// Continuation lines of a multi-line value are indented
+		if inEnvList && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			value = append(value, trimmed)
+			continue
+		}
+		inEnvList = false
+
+		key, rest, found := strings.Cut(trimmed, "=")
+		if !found {
+			continue
+		}
+		if key = strings.TrimSpace(key); key == "envlist" || key == "env_list" {
+			inEnvList = true
+			value = append(value, strings.TrimSpace(rest))
+		}
+	}
+
+	var envs []string
+	for _, item := range splitEnvList(strings.Join(value, ",")) {
+		// Substitutions (like {env:TOXENV}) are resolved by tox at runtime
+		if strings.Contains(item, ":") {
+			return nil
+		}
+		for _, env := range expandBraces(item) {
+			envs = appendIfMissing(envs, env)
+		}
+	}
+	return envs
+}
+
+// splitEnvList splits an env list at the commas outside of the brace groups.
+func splitEnvList(value string) []string {
+	var (
+		items []string
+		depth int
+		start int
+	)
+	for i, r := range value {
+		switch r {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				items = append(items, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+	items = append(items, value[start:])
+
+	var trimmed []string
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			trimmed = append(trimmed, item)
+		}
+	}
+	return trimmed
+}
+
+// expandBraces expands the tox factor groups of an env name, like py{311,312}-django{42,50} or py3{10-12}.
+func expandBraces(env string) []string {
+	match := braceGroupPattern.FindStringSubmatchIndex(env)
+	if match == nil {
+		return []string{env}
+	}
+
+	var alternatives []string
+	for _, alternative := range strings.Split(env[match[2]:match[3]], ",") {
+		alternative = strings.TrimSpace(alternative)
+		if rangeMatch := numericRangePattern.FindStringSubmatch(alternative); rangeMatch != nil {
+			from, _ := strconv.Atoi(rangeMatch[1])
+			to, _ := strconv.Atoi(rangeMatch[2])
+			for i := from; i <= to; i++ {
+				alternatives = append(alternatives, strconv.Itoa(i))
+			}
+			continue
+		}
+		alternatives = append(alternatives, alternative)
+	}
+
+	var envs []string
+	for _, alternative := range alternatives {
+		envs = append(envs, expandBraces(env[:match[0]]+alternative+env[match[1]:])...)
+	}
+	return envs
+}
+
+// toxFactorPythonVersion returns the Python version of a tox factor, like 3.11 for py311, py3.11 or 3.11.
+func toxFactorPythonVersion(factor string) string {
+	if match := toxPyFactorPattern.FindStringSubmatch(factor); match != nil {
+		return match[1] + "." + match[2]
+	}
+	if match := toxDotFactorPattern.FindStringSubmatch(factor); match != nil {
+		return match[1] + "." + match[2]
+	}
+	return ""
+}
+
+// detectNox reads the sessions of noxfile.py and their Python versions, resolving the module level version lists.
+func detectNox(projectDir string) *sessionConfig {
+	content, err := metrics.ReadStringFromFile(filepath.Join(projectDir, noxFile))
+	if err != nil {
+		return nil
+	}
+
+	moduleLists := map[string][]string{}
+	for _, match := range moduleListPattern.FindAllStringSubmatch(content, -1) {
+		moduleLists[match[1]] = stringLiterals(match[2])
+	}
+
+	config := &sessionConfig{runner: sessionRunnerNox, source: noxFile}
+	for _, match := range noxSessionPattern.FindAllStringSubmatch(content, -1) {
+		args, name := match[2], match[3]
+		if nameMatch := noxNamePattern.FindStringSubmatch(args); nameMatch != nil {
+			name = nameMatch[1]
+		}
+		config.sessions = appendIfMissing(config.sessions, name)
+
+		pythonMatch := noxPythonPattern.FindStringSubmatch(args)
+		if pythonMatch == nil {
+			continue
+		}
+		versions := stringLiterals(pythonMatch[1])
+		if list, ok := moduleLists[pythonMatch[1]]; ok {
+			versions = list
+		}
+		for _, version := range versions {
+			if pythonVersionPattern.MatchString(version) {
+				config.pythons = appendIfMissing(config.pythons, version)
+			}
+		}
+	}
+	sortVersions(config.pythons)
+	return config
+}
+
+func stringLiterals(value string) []string {
+	var literals []string
+	for _, match := range stringLiteralPattern.FindAllStringSubmatch(value, -1) {
+		literals = append(literals, match[1])
+	}
+	return literals
+}
+
+func tomlStrings(value interface{}) []string {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	var strs []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
+// sortVersions sorts X.Y versions numerically, so that 3.9 comes before 3.10.
+func sortVersions(versions []string) {
+	sort.Slice(versions, func(i, j int) bool {
+		iMajor, iMinor, _ := strings.Cut(versions[i], ".")
+		jMajor, jMinor, _ := strings.Cut(versions[j], ".")
+		if iMajor != jMajor {
+			return atoi(iMajor) < atoi(jMajor)
+		}
+		return atoi(iMinor) < atoi(jMinor)
+	})
+}
+
+func atoi(s string) int {
+	i, _ := strconv.Atoi(s)
+	return i
+}
+
+func appendIfMissing(items []string, item string) []string {
+	for _, existing := range items {
+		if existing == item {
+			return items
+		}
+	}
+	return append(items, item)
+}
@@ -0,0 +1,162 @@
+package python
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectSessions(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  *sessionConfig
+	}{
+		{
+			name: "tox.ini with factor groups",
+			files: map[string]string{"tox.ini": `[tox]
+envlist =
+    py3{10-12}-django{42,50}
+    lint
+
+[testenv]
+deps = pytest
+`},
+			want: &sessionConfig{
+				runner: sessionRunnerTox,
+				source: "tox.ini",
+				sessions: []string{
+					"py310-django42", "py310-django50", "py311-django42", "py311-django50", "py312-django42", "py312-django50", "lint",
+				},
+				pythons: []string{"3.10", "3.11", "3.12"},
+			},
+		},
+		{
+			name: "setup.cfg",
+			files: map[string]string{"setup.cfg": `[metadata]
+name = my-lib
+
+[tox:tox]
+envlist = py39, py3.13
+`},
+			want: &sessionConfig{runner: sessionRunnerTox, source: "setup.cfg", sessions: []string{"py39", "py3.13"}, pythons: []string{"3.9", "3.13"}},
+		},
+		{
+			name: "tox.toml",
+			files: map[string]string{"tox.toml": `env_list = ["3.12", "type"]
+`},
+			want: &sessionConfig{runner: sessionRunnerTox, source: "tox.toml", sessions: []string{"3.12", "type"}, pythons: []string{"3.12"}},
+		},
+		{
+			name: "pyproject.toml with legacy tox ini",
+			files: map[string]string{"pyproject.toml": `[tool.tox]
+legacy_tox_ini = """
+[tox]
+envlist = py311
+"""
+`},
+			want: &sessionConfig{runner: sessionRunnerTox, source: "pyproject.toml", sessions: []string{"py311"}, pythons: []string{"3.11"}},
+		},
+		{
+			name: "substituted env list",
+			files: map[string]string{"tox.ini": `[tox]
+envlist = {env:TOXENV:py312}
+`},
+			want: &sessionConfig{runner: sessionRunnerTox, source: "tox.ini"},
+		},
+		{
+			name: "tox takes precedence over nox",
+			files: map[string]string{
+				"tox.ini":    "[tox]\nenvlist = py312\n",
+				"noxfile.py": "@nox.session\ndef tests(session):\n    pass\n",
+			},
+			want: &sessionConfig{runner: sessionRunnerTox, source: "tox.ini", sessions: []string{"py312"}, pythons: []string{"3.12"}},
+		},
+		{
+			name: "noxfile.py",
+			files: map[string]string{"noxfile.py": `import nox
+
+PYTHON_VERSIONS = ["3.10", "3.11", "3.9"]
+
+
+@nox.session(python=PYTHON_VERSIONS)
+def tests(session):
+    session.run("pytest")
+
+
+@nox.session(name="type-check", python="3.12")
+def mypy(session):
+    session.run("mypy")
+
+
+@nox.session
+def lint(session):
+    session.run("ruff", "check")
+`},
+			want: &sessionConfig{
+				runner:   sessionRunnerNox,
+				source:   noxFile,
+				sessions: []string{"tests", "type-check", "lint"},
+				pythons:  []string{"3.9", "3.10", "3.11", "3.12"},
+			},
+		},
+		{
+			name:  "no session runner",
+			files: map[string]string{"pyproject.toml": "[project]\nname = \"my-app\"\n"},
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			require.Equal(t, tt.want, detectSessions(dir))
+		})
+	}
+}
+
+func TestExpandBraces(t *testing.T) {
+	tests := []struct {
+		env  string
+		want []string
+	}{
+		{env: "lint", want: []string{"lint"}},
+		{env: "py{311,312}", want: []string{"py311", "py312"}},
+		{env: "py3{8-10}", want: []string{"py38", "py39", "py310"}},
+		{env: "py{311,312}-django{42,50}", want: []string{"py311-django42", "py311-django50", "py312-django42", "py312-django50"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			require.Equal(t, tt.want, expandBraces(tt.env))
+		})
+	}
+}
+
+func TestToxFactorPythonVersion(t *testing.T) {
+	tests := []struct {
+		factor string
+		want   string
+	}{
+		{factor: "py311", want: "3.11"},
+		{factor: "py3.11", want: "3.11"},
+		{factor: "3.11", want: "3.11"},
+		{factor: "py3", want: ""},
+		{factor: "django42", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.factor, func(t *testing.T) {
+			require.Equal(t, tt.want, toxFactorPythonVersion(tt.factor))
+		})
+	}
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		pth := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+		require.NoError(t, os.WriteFile(pth, []byte(content), 0644))
+	}
+}
@@ -0,0 +1,303 @@
+package reactnative
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/bitrise-io/bitrise-init/detectors/gradle"
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/scanners/android"
+	"github.com/bitrise-io/bitrise-init/scanners/crossplatform"
+	"github.com/bitrise-io/bitrise-init/scanners/ios"
+	"github.com/bitrise-io/bitrise-init/scanners/java"
+	"github.com/bitrise-io/bitrise-init/scanners/nodejs"
+	"github.com/bitrise-io/bitrise-init/utility"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+)
+
+const scannerName = "react-native"
+
+const (
+	projectDirInputTitle   = "React Native project directory"
+	projectDirInputSummary = "Path of the directory containing the project's `package.json` file."
+	projectDirInputEnvKey  = "WORKDIR"
+
+	isExpoBasedProjectInputTitle   = "Is this an [Expo](https://expo.dev)-based React Native project?"
+	isExpoBasedProjectInputSummary = "Default deploy workflow runs builds on Expo Application Services (EAS) for Expo-based React Native projects.\nOtherwise native iOS and Android build steps will be used."
+)
+
+type project struct {
+	projectRelDir string
+
+	hasTest        bool
+	packageManager utility.NodePackageManager
+	// lockFileRelDir is the workspace root for workspace packages, the project dir otherwise
+	lockFileRelDir string
+
+	// non-Expo; native projects
+	iosProjects    ios.DetectResult
+	androidProject *gradle.Project
+}
+
+// Scanner implements the project scanner for plain React Native and Expo based projects.
+type Scanner struct {
+	isExpoBased bool
+	projects    []project
+
+	configDescriptors []configDescriptor
+}
+
+// NewScanner creates a new scanner instance.
+func NewScanner() *Scanner {
+	return &Scanner{}
+}
+
+// Name implements ScannerInterface.Name function.
+func (Scanner) Name() string {
+	return scannerName
+}
+
+func isExpoBasedProject(packageJSONPth string) (bool, error) {
+	packages, err := utility.ParsePackagesJSON(packageJSONPth)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse package json file (%s): %w", packageJSONPth, err)
+	}
+
+	if _, found := packages.Dependencies["expo"]; !found {
+		return false, nil
+	}
+
+	expoAppConfigFiles := []string{"app.json", "app.config.js", "app.config.ts"}
+	for _, base := range expoAppConfigFiles {
+		expoAppConfigPth := filepath.Join(filepath.Dir(packageJSONPth), base)
+		exist, err := pathutil.IsPathExists(expoAppConfigPth)
+		if err != nil {
+			return false, fmt.Errorf("failed to check if Expo app config exists at: %s: %w", expoAppConfigPth, err)
+		}
+		if exist {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// DetectPlatform implements ScannerInterface.DetectPlatform function.
+func (scanner *Scanner) DetectPlatform(searchDir string) (bool, error) {
+	log.TInfof("Collecting package.json files")
+
+	packageJSONPths, workspaces, err := CollectPackageJSONFiles(searchDir)
+	if err != nil {
+		return false, err
+	}
+
+	log.TPrintf("%d package.json file detected", len(packageJSONPths))
+	for _, path := range packageJSONPths {
+		log.TPrintf("- %s", path)
+	}
+
+	log.TPrintf("Filtering relevant package.json files")
+	for _, packageJSONPth := range packageJSONPths {
+		log.TPrintf("Checking: %s", packageJSONPth)
+
+		isExpoBased, err := isExpoBasedProject(packageJSONPth)
+		if err != nil {
+			log.TWarnf("failed to determine if project is Expo based: %s", err)
+		}
+
+		log.TPrintf("Project uses expo: %v", isExpoBased)
+
+		// determine workdir
+		packageJSONDir := filepath.Dir(packageJSONPth)
+		relPackageJSONDir, err := utility.RelPath(searchDir, packageJSONDir)
+		if err != nil {
+			return false, fmt.Errorf("failed to get relative package.json dir path: %w", err)
+		}
+
+		var (
+			iosProjects    ios.DetectResult
+			androidProject *gradle.Project
+		)
+		if !isExpoBased {
+			iosProjects, androidProject = crossplatform.DetectNativeProjects(filepath.Dir(packageJSONPth), relPackageJSONDir)
+			if len(iosProjects.Projects) == 0 && androidProject == nil {
+				continue
+			}
+		}
+
+		// determine Js dependency manager
+		lockDir := lockFileDir(workspaces, packageJSONPth)
+		packageManager := utility.DetectNodePackageManager(lockDir)
+		if packageManager.Name == "" {
+			packageManager.Name = utility.NodePackageManagerNpm
+		}
+		log.TPrintf("Js dependency manager for %s: %s", packageJSONPth, packageManager.Name)
+
+		relLockDir, err := utility.RelPath(searchDir, lockDir)
+		if err != nil {
+			return false, fmt.Errorf("failed to get relative lock file dir path: %w", err)
+		}
+		if relLockDir != relPackageJSONDir {
+			log.TPrintf("Workspace root: %s", relLockDir)
+		}
+
+		packages, err := utility.ParsePackagesJSON(packageJSONPth)
+		if err != nil {
+			return false, err
+		}
+
+		_, hasTests := packages.Scripts["test"]
+		log.TPrintf("Test script found in package.json: %v", hasTests)
+
+		result := project{
+			projectRelDir:  relPackageJSONDir,
+			hasTest:        hasTests,
+			packageManager: packageManager,
+			lockFileRelDir: relLockDir,
+			iosProjects:    iosProjects,
+			androidProject: androidProject,
+		}
+
+		if isExpoBased {
+			scanner.projects = []project{result}
+			scanner.isExpoBased = true
+
+			break
+		}
+
+		scanner.projects = append(scanner.projects, result)
+	}
+
+	if len(scanner.projects) == 0 {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// Evidence implements EvidenceProvider.Evidence function.
+func (scanner *Scanner) Evidence() models.Evidences {
+	var evidences models.Evidences
+	for _, project := range scanner.projects {
+		packageJSONPth := filepath.Join(project.projectRelDir, "package.json")
+		evidences.Add(packageJSONPth, "react-native dependency")
+		if scanner.isExpoBased {
+			evidences.Add(packageJSONPth, "expo dependency")
+		}
+		if project.packageManager.LockFile != "" {
+			evidences.Add(filepath.Join(project.lockFileRelDir, project.packageManager.LockFile), fmt.Sprintf("%s lock file", project.packageManager.Name))
+		}
+
+		evidences = append(evidences, project.iosProjects.Evidence()...)
+		if project.androidProject != nil {
+			evidences.Add(filepath.Join(project.androidProject.RootDirEntry.RelPath, "gradlew"), "Gradle wrapper script")
+		}
+	}
+	return evidences
+}
+
+// ProjectRoots implements ProjectRootProvider.ProjectRoots function.
+func (scanner *Scanner) ProjectRoots() []models.ProjectRoot {
+	var roots []models.ProjectRoot
+	for _, project := range scanner.projects {
+		root := models.ProjectRoot{Dir: project.projectRelDir}
+		if scanner.isExpoBased {
+			// Expo generates the native projects into the ios and android dirs (expo prebuild)
+			root.NativeDirs = []string{filepath.Join(project.projectRelDir, "ios"), filepath.Join(project.projectRelDir, "android")}
+		} else {
+			root.OptionValue = project.projectRelDir
+			root.NativeDirs = project.nativeDirs()
+		}
+		roots = append(roots, root)
+	}
+	return roots
+}
+
+// nativeDirs returns the dirs of the native iOS projects and the native Android project.
+func (p project) nativeDirs() []string {
+	dirs := []string{}
+	for _, iosProject := range p.iosProjects.Projects {
+		dirs = append(dirs, filepath.Dir(iosProject.RelPath))
+	}
+	if p.androidProject != nil {
+		dirs = append(dirs, p.androidProject.RootDirEntry.RelPath)
+	}
+	return dirs
+}
+
+// Options implements ScannerInterface.Options function.
+func (scanner *Scanner) Options() (options models.OptionNode, allWarnings models.Warnings, icons models.Icons, err error) {
+	if scanner.isExpoBased {
+		options = scanner.expoOptions()
+	} else {
+		projectRootOption := models.NewOption(projectDirInputTitle, projectDirInputSummary, projectDirInputEnvKey, models.TypeSelector)
+		options = *projectRootOption
+
+		for _, project := range scanner.projects {
+			options, warnings := scanner.options(project)
+			allWarnings = append(allWarnings, warnings...)
+
+			projectRootOption.AddOption(project.projectRelDir, &options)
+		}
+	}
+
+	return
+}
+
+func (scanner *Scanner) Configs(sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	if scanner.isExpoBased {
+		return scanner.expoConfigs(scanner.projects[0], sshKeyActivation)
+	}
+
+	return scanner.configs(sshKeyActivation)
+}
+
+// DefaultOptions implements ScannerInterface.DefaultOptions function.
+func (scanner *Scanner) DefaultOptions() models.OptionNode {
+	expoOption := models.NewOption(isExpoBasedProjectInputTitle, isExpoBasedProjectInputSummary, "", models.TypeSelector)
+
+	expoDefaultOptions := scanner.expoDefaultOptions()
+	expoOption.AddOption("yes", &expoDefaultOptions)
+
+	defaultOptions := scanner.defaultOptions()
+	expoOption.AddOption("no", &defaultOptions)
+
+	return *expoOption
+}
+
+// DefaultConfigs implements ScannerInterface.DefaultConfigs function.
+func (scanner *Scanner) DefaultConfigs() (models.BitriseConfigMap, error) {
+	configMap := models.BitriseConfigMap{}
+
+	configs, err := scanner.defaultConfigs()
+	if err != nil {
+		return models.BitriseConfigMap{}, err
+	}
+
+	for k, v := range configs {
+		configMap[k] = v
+	}
+
+	expoConfigs, err := scanner.expoDefaultConfigs()
+	if err != nil {
+		return models.BitriseConfigMap{}, err
+	}
+
+	for k, v := range expoConfigs {
+		configMap[k] = v
+	}
+
+	return configMap, nil
+}
+
+// ExcludedScannerNames implements ScannerInterface.ExcludedScannerNames function.
+func (Scanner) ExcludedScannerNames() []string {
+	return []string{
+		string(ios.XcodeProjectTypeIOS),
+		string(ios.XcodeProjectTypeMacOS),
+		android.ScannerName,
+		nodejs.ScannerName,
+		java.ProjectType,
+	}
+}
@@ -0,0 +1,89 @@
+package reactnative
+
+import (
+	"path/filepath"
+
+	"github.com/bitrise-io/bitrise-init/utility"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+)
+
+// CollectPackageJSONFiles collects package.json files, with react-native dependency, and the JS workspaces containing them.
+// Workspace roots are skipped if the react-native app is one of the workspace packages.
+func CollectPackageJSONFiles(searchDir string) ([]string, []utility.NodeWorkspace, error) {
+	fileList, err := pathutil.ListPathInDirSortedByComponents(searchDir, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filters := []pathutil.FilterFunc{
+		pathutil.BaseFilter("package.json", true),
+		pathutil.ComponentFilter("node_modules", false),
+	}
+	packageFileList, err := pathutil.FilterPaths(fileList, filters...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workspaces, err := utility.DetectNodeWorkspaces(packageFileList)
+	if err != nil {
+		log.TWarnf("Failed to detect workspaces: %s", err)
+	}
+
+	relevantPackageFileList := []string{}
+	for _, packageFile := range packageFileList {
+		packages, err := utility.ParsePackagesJSON(packageFile)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		_, found := packages.Dependencies["react-native"]
+		if found {
+			relevantPackageFileList = append(relevantPackageFileList, packageFile)
+		}
+	}
+
+	var packageFiles []string
+	for _, packageFile := range relevantPackageFileList {
+		if isWorkspaceRootOfApp(workspaces, packageFile, relevantPackageFileList) {
+			continue
+		}
+		packageFiles = append(packageFiles, packageFile)
+	}
+
+	var relevantWorkspaces []utility.NodeWorkspace
+	for _, workspace := range workspaces {
+		for _, packageFile := range packageFiles {
+			if workspace.Contains(packageFile) {
+				relevantWorkspaces = append(relevantWorkspaces, workspace)
+				break
+			}
+		}
+	}
+
+	return packageFiles, relevantWorkspaces, nil
+}
+
+// isWorkspaceRootOfApp reports whether the package.json is the root of a workspace, having a react-native app among its packages.
+func isWorkspaceRootOfApp(workspaces []utility.NodeWorkspace, packageJSONPth string, appPackageJSONPths []string) bool {
+	for _, workspace := range workspaces {
+		if filepath.Dir(packageJSONPth) != workspace.RootDir {
+			continue
+		}
+		for _, appPackageJSONPth := range appPackageJSONPths {
+			if appPackageJSONPth != packageJSONPth && workspace.Contains(appPackageJSONPth) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lockFileDir returns the dir of the JS dependency lock file: the workspace root for workspace packages,
+// as dependencies are installed once for the whole workspace.
+func lockFileDir(workspaces []utility.NodeWorkspace, packageJSONPth string) string {
+	if workspace := utility.FindNodeWorkspace(workspaces, packageJSONPth); workspace != nil {
+		return workspace.RootDir
+	}
+	return filepath.Dir(packageJSONPth)
+}
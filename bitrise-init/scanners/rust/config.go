@@ -0,0 +1,263 @@
+package rust
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/steps"
+	envmanModels "github.com/bitrise-io/envman/v2/models"
+)
+
+const (
+	runTestsWorkflowID = models.WorkflowID("run_tests")
+
+	cargoCachePaths = "~/.cargo/registry\n~/.cargo/git"
+
+	rustupInstallScript = `#!/usr/bin/env bash
+set -euxo pipefail
+
+if ! command -v rustup >/dev/null; then
+  curl --proto '=https' --tlsv1.2 -sSf https://sh.rustup.rs | sh -s -- -y --profile minimal
+  export PATH="$HOME/.cargo/bin:$PATH"
+  envman add --key PATH --value "$PATH"
+fi
+
+`
+
+	// rustup installs the toolchain of the rust-toolchain file of the working directory, with the components listed in the file
+	toolchainFileInstallCommand   = "rustup toolchain install\n"
+	toolchainInputInstallCommands = `rustup toolchain install $RUST_TOOLCHAIN --component clippy,rustfmt
+rustup override set $RUST_TOOLCHAIN
+`
+	componentAddCommandTemplate = "rustup component add %s\n"
+
+	rustfmtComponent = "rustfmt"
+	clippyComponent  = "clippy"
+
+	fmtCheckScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+cargo fmt --all -- --check
+`
+	buildScriptTemplate = `#!/usr/bin/env bash
+set -euxo pipefail
+
+cargo build --workspace --all-targets%s
+`
+	clippyScriptTemplate = `#!/usr/bin/env bash
+set -euxo pipefail
+
+cargo clippy --workspace --all-targets%s%s
+`
+	testScriptTemplate = `#!/usr/bin/env bash
+set -euxo pipefail
+
+cargo test --workspace%s
+`
+)
+
+type configDescriptor struct {
+	workdir          string
+	hasToolchainFile bool
+	// toolchainComponents are the components installed by the rust-toolchain file
+	toolchainComponents []string
+	hasLockFile         bool
+	hasTests            bool
+	// The formatting and the lints are only checked if the project sets up rustfmt or clippy,
+	// by a config file or by a component of the rust-toolchain file
+	checkFormatting bool
+	runClippy       bool
+	hasClippyConfig bool
+	isDefault       bool
+}
+
+func createConfigDescriptor(proj project, isDefault bool) configDescriptor {
+	d := configDescriptor{
+		workdir:             "$" + projectDirInputEnvKey,
+		hasToolchainFile:    proj.toolchain != "",
+		toolchainComponents: proj.toolchainComponents,
+		hasLockFile:         proj.hasLockFile,
+		hasTests:            proj.hasTests,
+		checkFormatting:     proj.rustfmtConfigFile != "" || slices.Contains(proj.toolchainComponents, rustfmtComponent),
+		runClippy:           proj.clippyConfigFile != "" || slices.Contains(proj.toolchainComponents, clippyComponent),
+		hasClippyConfig:     proj.clippyConfigFile != "",
+		isDefault:           isDefault,
+	}
+	if proj.projectRelDir == "." {
+		d.workdir = ""
+	}
+	return d
+}
+
+func createDefaultConfigDescriptor() configDescriptor {
+	d := createConfigDescriptor(project{
+		projectRelDir: "$" + projectDirInputEnvKey,
+		hasLockFile:   true,
+		hasTests:      true,
+	}, true)
+	d.checkFormatting = true
+	d.runClippy = true
+	return d
+}
+
+func generateOptions(projects []project) (models.OptionNode, models.Warnings, models.Icons, error) {
+	if len(projects) == 0 {
+		return models.OptionNode{}, nil, nil, fmt.Errorf("no Cargo.toml files found")
+	}
+
+	projectRootOption := models.NewOption(projectDirInputTitle, projectDirInputSummary, projectDirInputEnvKey, models.TypeSelector)
+	for _, proj := range projects {
+		descriptor := createConfigDescriptor(proj, false)
+		projectRootOption.AddConfig(proj.projectRelDir, models.NewConfigOption(configName(descriptor), nil))
+	}
+
+	return *projectRootOption, nil, nil, nil
+}
+
+func generateConfigs(projects []project, sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	if len(projects) == 0 {
+		return models.BitriseConfigMap{}, fmt.Errorf("no Cargo.toml files found")
+	}
+
+	configs := models.BitriseConfigMap{}
+	for _, proj := range projects {
+		descriptor := createConfigDescriptor(proj, false)
+		config, err := generateConfigBasedOn(descriptor, sshKeyActivation)
+		if err != nil {
+			return nil, err
+		}
+		configs[configName(descriptor)] = config
+	}
+	return configs, nil
+}
+
+func configName(d configDescriptor) string {
+	if d.isDefault {
+		return "default-rust-config"
+	}
+
+	name := "rust"
+	if d.workdir == "" {
+		name += "-root"
+	}
+	if d.hasToolchainFile {
+		name += "-toolchain"
+	}
+	if d.hasLockFile {
+		name += "-locked"
+	}
+	if d.hasTests {
+		name += "-test"
+	}
+	if d.checkFormatting {
+		name += "-fmt"
+	}
+	if d.runClippy {
+		name += "-clippy"
+	}
+	if d.hasClippyConfig {
+		name += "-strict"
+	}
+	return name + "-config"
+}
+
+func generateConfigBasedOn(d configDescriptor, sshKey models.SSHKeyActivation) (string, error) {
+	configBuilder := models.NewDefaultConfigBuilder()
+
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.DefaultPrepareStepList(steps.PrepareListParams{SSHKeyActivation: sshKey})...)
+
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Install Rust toolchain", installScript(d), workdirInputs(d.workdir)...))
+
+	key, paths := cacheKeyAndPaths(d)
+	// Lint warnings are only denied if the project configures its clippy lints
+	var denyWarnings string
+	if d.hasClippyConfig {
+		denyWarnings = " -- -D warnings"
+	}
+
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.RestoreCache(key))
+	if d.checkFormatting {
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Check formatting", fmtCheckScriptContent, workdirInputs(d.workdir)...))
+	}
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Build", fmt.Sprintf(buildScriptTemplate, lockedFlag(d)), workdirInputs(d.workdir)...))
+	if d.runClippy {
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Run clippy", fmt.Sprintf(clippyScriptTemplate, lockedFlag(d), denyWarnings), workdirInputs(d.workdir)...))
+	}
+	if d.hasTests {
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.ScriptStepListItem("Run tests", fmt.Sprintf(testScriptTemplate, lockedFlag(d)), workdirInputs(d.workdir)...))
+	}
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.SaveCache(key, paths))
+
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.DefaultDeployStepList()...)
+
+	bitriseConfig, err := configBuilder.Generate(scannerName)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := yaml.Marshal(bitriseConfig)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// installScript installs rustup if missing, then the toolchain and the components of the checks.
+// The components listed in the rust-toolchain file are installed with the toolchain.
+func installScript(d configDescriptor) string {
+	script := rustupInstallScript
+	if d.isDefault {
+		return script + toolchainInputInstallCommands
+	}
+	if d.hasToolchainFile {
+		script += toolchainFileInstallCommand
+	}
+
+	var components []string
+	if d.checkFormatting && !slices.Contains(d.toolchainComponents, rustfmtComponent) {
+		components = append(components, rustfmtComponent)
+	}
+	if d.runClippy && !slices.Contains(d.toolchainComponents, clippyComponent) {
+		components = append(components, clippyComponent)
+	}
+	if len(components) > 0 {
+		script += fmt.Sprintf(componentAddCommandTemplate, strings.Join(components, " "))
+	}
+	return script
+}
+
+// cacheKeyAndPaths returns the key of the Cargo cache, keyed on the lock file or on the manifests if there is no lock file,
+// and the cached paths: the registry, the git dependencies and the target dir of the project.
+func cacheKeyAndPaths(d configDescriptor) (string, string) {
+	keyFile := lockFile
+	if !d.hasLockFile {
+		keyFile = manifestFile
+	}
+	key := fmt.Sprintf(`cargo-{{ checksum "**/%s" }}`, keyFile)
+
+	targetDir := "target"
+	if d.workdir != "" {
+		targetDir = d.workdir + "/target"
+	}
+	return key, cargoCachePaths + "\n" + targetDir
+}
+
+// lockedFlag makes cargo fail instead of updating the committed lock file.
+func lockedFlag(d configDescriptor) string {
+	if d.hasLockFile {
+		return " --locked"
+	}
+	return ""
+}
+
+func workdirInputs(workdir string) []envmanModels.EnvironmentItemModel {
+	if workdir == "" {
+		return nil
+	}
+	return []envmanModels.EnvironmentItemModel{{"working_dir": workdir}}
+}
@@ -0,0 +1,79 @@
+package rust
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateConfigDescriptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		proj     project
+		wantName string
+		wantFmt  bool
+		wantLint bool
+	}{
+		{
+			name:     "no rustfmt or clippy setup skips the checks",
+			proj:     project{projectRelDir: ".", hasLockFile: true, hasTests: true},
+			wantName: "rust-root-locked-test-config",
+		},
+		{
+			name:     "config files enable the checks and deny the lint warnings",
+			proj:     project{projectRelDir: "app", clippyConfigFile: "clippy.toml", rustfmtConfigFile: "rustfmt.toml"},
+			wantName: "rust-fmt-clippy-strict-config",
+			wantFmt:  true,
+			wantLint: true,
+		},
+		{
+			name:     "toolchain components enable the checks",
+			proj:     project{projectRelDir: ".", toolchain: "1.78", toolchainComponents: []string{"clippy", "rustfmt"}},
+			wantName: "rust-root-toolchain-fmt-clippy-config",
+			wantFmt:  true,
+			wantLint: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := createConfigDescriptor(tt.proj, false)
+			require.Equal(t, tt.wantName, configName(d))
+			require.Equal(t, tt.wantFmt, d.checkFormatting)
+			require.Equal(t, tt.wantLint, d.runClippy)
+		})
+	}
+}
+
+func TestInstallScript(t *testing.T) {
+	tests := []struct {
+		name string
+		d    configDescriptor
+		want string
+	}{
+		{
+			name: "default config installs the toolchain input",
+			d:    createDefaultConfigDescriptor(),
+			want: rustupInstallScript + toolchainInputInstallCommands,
+		},
+		{
+			name: "stable toolchain without checks",
+			d:    configDescriptor{},
+			want: rustupInstallScript,
+		},
+		{
+			name: "stable toolchain with the checks",
+			d:    configDescriptor{checkFormatting: true, runClippy: true},
+			want: rustupInstallScript + "rustup component add rustfmt clippy\n",
+		},
+		{
+			name: "toolchain file installs its components",
+			d:    configDescriptor{hasToolchainFile: true, toolchainComponents: []string{"rustfmt"}, checkFormatting: true, runClippy: true},
+			want: rustupInstallScript + "rustup toolchain install\nrustup component add clippy\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, installScript(tt.d))
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package rust
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/bitrise-io/bitrise-init/metrics"
+	"github.com/bitrise-io/bitrise-init/utility"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+)
+
+const (
+	manifestFile        = "Cargo.toml"
+	lockFile            = "Cargo.lock"
+	toolchainFile       = "rust-toolchain.toml"
+	legacyToolchainFile = "rust-toolchain"
+)
+
+var (
+	clippyConfigFiles  = []string{"clippy.toml", ".clippy.toml"}
+	rustfmtConfigFiles = []string{"rustfmt.toml", ".rustfmt.toml"}
+)
+
+var excludeFilters = []pathutil.FilterFunc{
+	pathutil.ComponentFilter(".git", false),
+	pathutil.ComponentFilter("node_modules", false),
+	pathutil.ComponentFilter("target", false),
+	pathutil.ComponentFilter("vendor", false),
+}
+
+type project struct {
+	projectRelDir       string
+	isWorkspace         bool
+	workspaceMembers    []string // member crate dirs, relative to the project dir
+	toolchain           string   // the channel of the rust-toolchain file
+	toolchainComponents []string // the components of the rust-toolchain file, like rustfmt and clippy
+	hasLockFile         bool
+	hasTests            bool
+	clippyConfigFile    string
+	rustfmtConfigFile   string
+}
+
+type cargoManifest struct {
+	Package *struct {
+		Name string `toml:"name"`
+	} `toml:"package"`
+	Workspace *struct {
+		Members []string `toml:"members"`
+		Exclude []string `toml:"exclude"`
+	} `toml:"workspace"`
+}
+
+type toolchainConfig struct {
+	Toolchain struct {
+		Channel    string   `toml:"channel"`
+		Components []string `toml:"components"`
+	} `toml:"toolchain"`
+}
+
+// collectManifestDirs returns the dirs of the top level Cargo.toml files, relative to the searchDir.
+// Manifests nested in a project dir belong to a workspace member or a path dependency of that project.
+func collectManifestDirs(searchDir string) ([]string, error) {
+	fileList, err := pathutil.ListPathInDirSortedByComponents(searchDir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := pathutil.FilterPaths(fileList, append(excludeFilters, pathutil.BaseFilter(manifestFile, true))...)
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	for _, pth := range paths {
+		dir := filepath.Dir(pth)
+		if isInAnyDir(dirs, dir) {
+			continue
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs, nil
+}
+
+func isInAnyDir(dirs []string, pth string) bool {
+	for _, dir := range dirs {
+		if dir == "." || pth == dir || strings.HasPrefix(pth, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseProject reads the workspace, the toolchain, the tests and the linter configs of a project.
+func parseProject(searchDir, relDir string) project {
+	projectDir := filepath.Join(searchDir, relDir)
+	proj := project{projectRelDir: relDir}
+
+	content, err := metrics.ReadStringFromFile(filepath.Join(projectDir, manifestFile))
+	if err != nil {
+		log.TWarnf("Failed to read %s: %s", filepath.Join(relDir, manifestFile), err)
+	} else {
+		var manifest cargoManifest
+		if _, err := toml.Decode(content, &manifest); err != nil {
+			log.TWarnf("Failed to parse %s: %s", filepath.Join(relDir, manifestFile), err)
+		} else if manifest.Workspace != nil {
+			proj.isWorkspace = true
+			proj.workspaceMembers = expandWorkspaceMembers(projectDir, manifest.Workspace.Members, manifest.Workspace.Exclude)
+		}
+	}
+
+	proj.toolchain, proj.toolchainComponents = detectToolchain(projectDir)
+	proj.hasLockFile = utility.FileExists(filepath.Join(projectDir, lockFile))
+	proj.hasTests = detectTests(projectDir, append([]string{"."}, proj.workspaceMembers...))
+	proj.clippyConfigFile = findFile(projectDir, clippyConfigFiles)
+	proj.rustfmtConfigFile = findFile(projectDir, rustfmtConfigFiles)
+
+	return proj
+}
+
+// expandWorkspaceMembers resolves the glob patterns of the workspace members to the crate dirs.
+func expandWorkspaceMembers(projectDir string, members, exclude []string) []string {
+	var dirs []string
+	for _, member := range members {
+		matches, err := filepath.Glob(filepath.Join(projectDir, member))
+		if err != nil {
+			log.TWarnf("Invalid workspace member pattern %s: %s", member, err)
+			continue
+		}
+		for _, match := range matches {
+			dir, err := filepath.Rel(projectDir, match)
+			if err != nil || isExcludedMember(dir, exclude) || !utility.FileExists(filepath.Join(match, manifestFile)) {
+				continue
+			}
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+func isExcludedMember(dir string, exclude []string) bool {
+	for _, excluded := range exclude {
+		if filepath.Clean(excluded) == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// detectToolchain returns the channel and the components of the rust-toolchain.toml,
+// or the channel of the legacy rust-toolchain file.
+func detectToolchain(projectDir string) (string, []string) {
+	log.TPrintf("Checking Rust toolchain")
+
+	if content, err := metrics.ReadStringFromFile(filepath.Join(projectDir, toolchainFile)); err == nil {
+		var config toolchainConfig
+		if _, err := toml.Decode(content, &config); err != nil {
+			log.TWarnf("Failed to parse %s: %s", toolchainFile, err)
+			return "", nil
+		}
+		log.TPrintf("- %s - found (%s)", toolchainFile, config.Toolchain.Channel)
+		return config.Toolchain.Channel, config.Toolchain.Components
+	}
+
+	if content, err := metrics.ReadStringFromFile(filepath.Join(projectDir, legacyToolchainFile)); err == nil {
+		content = strings.TrimSpace(content)
+		// The legacy file is either a single channel line, or the same TOML as rust-toolchain.toml
+		if strings.Contains(content, "[toolchain]") {
+			var config toolchainConfig
+			if _, err := toml.Decode(content, &config); err == nil {
+				log.TPrintf("- %s - found (%s)", legacyToolchainFile, config.Toolchain.Channel)
+				return config.Toolchain.Channel, config.Toolchain.Components
+			}
+		} else if content != "" {
+			log.TPrintf("- %s - found (%s)", legacyToolchainFile, content)
+			return content, nil
+		}
+	}
+
+	log.TPrintf("- toolchain file - not found")
+	return "", nil
+}
+
+// detectTests reports whether any of the crates has integration tests (a tests dir) or unit tests (#[test] in the src dir).
+func detectTests(projectDir string, crateDirs []string) bool {
+	for _, crateDir := range crateDirs {
+		if exists, err := pathutil.IsDirExists(filepath.Join(projectDir, crateDir, "tests")); err == nil && exists {
+			return true
+		}
+		if hasUnitTests(filepath.Join(projectDir, crateDir, "src")) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasUnitTests(srcDir string) bool {
+	found := false
+	err := filepath.Walk(srcDir, func(pth string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(pth) != ".rs" {
+			return nil
+		}
+		content, err := metrics.ReadStringFromFile(pth)
+		if err != nil {
+			return err
+		}
+		if strings.Contains(content, "#[test]") || strings.Contains(content, "#[cfg(test)]") {
+			found = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		log.TWarnf("Failed to search for unit tests in %s: %s", srcDir, err)
+	}
+	return found
+}
+
+func findFile(dir string, names []string) string {
+	for _, name := range names {
+		if utility.FileExists(filepath.Join(dir, name)) {
+			return name
+		}
+	}
+	return ""
+}
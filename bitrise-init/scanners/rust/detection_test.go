@@ -0,0 +1,126 @@
+package rust
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for pth, content := range files {
+		pth = filepath.Join(dir, pth)
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+		require.NoError(t, os.WriteFile(pth, []byte(content), 0644))
+	}
+}
+
+func TestParseProject(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  project
+	}{
+		{
+			name: "workspace with a toolchain file, unit tests and linter configs",
+			files: map[string]string{
+				"Cargo.toml":                "[workspace]\nmembers = [\"crates/*\"]\nexclude = [\"crates/skipped\"]\n",
+				"Cargo.lock":                "",
+				"rust-toolchain.toml":       "[toolchain]\nchannel = \"1.78\"\ncomponents = [\"rustfmt\", \"clippy\"]\n",
+				"clippy.toml":               "",
+				".rustfmt.toml":             "",
+				"crates/core/Cargo.toml":    "[package]\nname = \"core\"\n",
+				"crates/core/src/lib.rs":    "#[cfg(test)]\nmod tests {}\n",
+				"crates/skipped/Cargo.toml": "[package]\nname = \"skipped\"\n",
+			},
+			want: project{
+				projectRelDir:       ".",
+				isWorkspace:         true,
+				workspaceMembers:    []string{"crates/core"},
+				toolchain:           "1.78",
+				toolchainComponents: []string{"rustfmt", "clippy"},
+				hasLockFile:         true,
+				hasTests:            true,
+				clippyConfigFile:    "clippy.toml",
+				rustfmtConfigFile:   ".rustfmt.toml",
+			},
+		},
+		{
+			name: "package with the legacy toolchain file and integration tests",
+			files: map[string]string{
+				"Cargo.toml":     "[package]\nname = \"app\"\n",
+				"rust-toolchain": "nightly\n",
+				"src/main.rs":    "fn main() {}\n",
+				"tests/it.rs":    "",
+			},
+			want: project{
+				projectRelDir: ".",
+				toolchain:     "nightly",
+				hasTests:      true,
+			},
+		},
+		{
+			name: "package without tests",
+			files: map[string]string{
+				"Cargo.toml":  "[package]\nname = \"app\"\n",
+				"src/main.rs": "fn main() {}\n",
+			},
+			want: project{projectRelDir: "."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchDir := t.TempDir()
+			writeFiles(t, searchDir, tt.files)
+
+			require.Equal(t, tt.want, parseProject(searchDir, "."))
+		})
+	}
+}
+
+func TestHasUnitTests(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  bool
+	}{
+		{
+			name:  "test attribute in a nested module",
+			files: map[string]string{"src/lib.rs": "pub mod a;\n", "src/a/mod.rs": "#[test]\nfn it_works() {}\n", "src/z.rs": "\n"},
+			want:  true,
+		},
+		{
+			name:  "no test attribute",
+			files: map[string]string{"src/lib.rs": "pub fn f() {}\n", "src/notes.txt": "#[test]\n"},
+			want:  false,
+		},
+		{
+			name:  "no src dir",
+			files: map[string]string{"Cargo.toml": ""},
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			require.Equal(t, tt.want, hasUnitTests(filepath.Join(dir, "src")))
+		})
+	}
+}
+
+func TestCollectManifestDirs(t *testing.T) {
+	searchDir := t.TempDir()
+	writeFiles(t, searchDir, map[string]string{
+		"app/Cargo.toml":            "",
+		"app/crates/lib/Cargo.toml": "",
+		"tool/Cargo.toml":           "",
+		"tool/target/Cargo.toml":    "",
+	})
+
+	got, err := collectManifestDirs(searchDir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"app", "tool"}, got)
+}
@@ -0,0 +1,138 @@
+package rust
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+const (
+	scannerName = "rust"
+
+	projectDirInputTitle   = "Rust project directory"
+	projectDirInputSummary = "The directory containing the Cargo.toml file of the crate or the workspace"
+	projectDirInputEnvKey  = "RUST_PROJECT_DIR"
+
+	rustVersionInputTitle   = "Rust toolchain"
+	rustVersionInputSummary = "The Rust toolchain to be used for the project. Use a release channel (stable, beta, nightly) or an exact version (1.78.0)."
+	rustVersionEnvKey       = "RUST_TOOLCHAIN"
+)
+
+// Scanner implements ScannerInterface for Rust projects built with Cargo.
+type Scanner struct {
+	projects []project // populated by DetectPlatform
+}
+
+// NewScanner creates a new Scanner instance.
+func NewScanner() *Scanner {
+	return &Scanner{}
+}
+
+// Name returns the scanner name.
+func (s *Scanner) Name() string {
+	return scannerName
+}
+
+// DetectPlatform checks whether searchDir contains a Cargo project and reads its workspace, toolchain and linter configs.
+func (s *Scanner) DetectPlatform(searchDir string) (bool, error) {
+	dirs, err := collectManifestDirs(searchDir)
+	if err != nil {
+		log.TWarnf("%s", err)
+		log.TPrintf("Platform not detected")
+		return false, nil
+	}
+
+	for _, dir := range dirs {
+		log.TPrintf("Rust project found: %s", dir)
+		proj := parseProject(searchDir, dir)
+		if proj.isWorkspace {
+			log.TPrintf("- workspace members: %s", strings.Join(proj.workspaceMembers, ", "))
+		}
+		log.TPrintf("- tests: %t", proj.hasTests)
+		s.projects = append(s.projects, proj)
+	}
+
+	if len(s.projects) == 0 {
+		log.TPrintf("Platform not detected")
+		return false, nil
+	}
+
+	log.TSuccessf("Platform detected")
+	return true, nil
+}
+
+// Evidence returns the manifests of the detected projects and the tools configured in them.
+func (s *Scanner) Evidence() models.Evidences {
+	var evidences models.Evidences
+	for _, proj := range s.projects {
+		manifestPth := filepath.Join(proj.projectRelDir, manifestFile)
+		if proj.isWorkspace {
+			evidences.Add(manifestPth, fmt.Sprintf("Cargo workspace of %d members", len(proj.workspaceMembers)))
+		} else {
+			evidences.Add(manifestPth, "Cargo manifest")
+		}
+		if proj.hasLockFile {
+			evidences.Add(filepath.Join(proj.projectRelDir, lockFile), "Cargo lock file")
+		}
+		if proj.toolchain != "" {
+			evidences.Add(proj.projectRelDir, fmt.Sprintf("Rust %s toolchain", proj.toolchain))
+		}
+		if proj.clippyConfigFile != "" {
+			evidences.Add(filepath.Join(proj.projectRelDir, proj.clippyConfigFile), "clippy config")
+		}
+		if proj.rustfmtConfigFile != "" {
+			evidences.Add(filepath.Join(proj.projectRelDir, proj.rustfmtConfigFile), "rustfmt config")
+		}
+	}
+	return evidences
+}
+
+// ProjectRoots returns the project dirs, keyed by the project dir option value.
+func (s *Scanner) ProjectRoots() []models.ProjectRoot {
+	var roots []models.ProjectRoot
+	for _, proj := range s.projects {
+		roots = append(roots, models.ProjectRoot{Dir: proj.projectRelDir, OptionValue: proj.projectRelDir})
+	}
+	return roots
+}
+
+// ExcludedScannerNames returns scanners to skip when this scanner detects.
+func (s *Scanner) ExcludedScannerNames() []string {
+	return []string{}
+}
+
+// Options builds the option tree of the detected projects.
+func (s *Scanner) Options() (models.OptionNode, models.Warnings, models.Icons, error) {
+	return generateOptions(s.projects)
+}
+
+// Configs generates the pre-made bitrise.yml templates for each detected project.
+func (s *Scanner) Configs(sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	return generateConfigs(s.projects, sshKeyActivation)
+}
+
+// DefaultOptions returns the option tree for the manual configuration flow.
+func (s *Scanner) DefaultOptions() models.OptionNode {
+	projectDirOption := models.NewOption(projectDirInputTitle, projectDirInputSummary, projectDirInputEnvKey, models.TypeUserInput)
+	versionOption := models.NewOption(rustVersionInputTitle, rustVersionInputSummary, rustVersionEnvKey, models.TypeUserInput)
+
+	projectDirOption.AddOption(models.UserInputOptionDefaultValue, versionOption)
+
+	defaultDescriptor := createDefaultConfigDescriptor()
+	versionOption.AddConfig(models.UserInputOptionDefaultValue, models.NewConfigOption(configName(defaultDescriptor), nil))
+
+	return *projectDirOption
+}
+
+// DefaultConfigs generates the static bitrise.yml templates for the manual configuration flow.
+func (s *Scanner) DefaultConfigs() (models.BitriseConfigMap, error) {
+	defaultDescriptor := createDefaultConfigDescriptor()
+	config, err := generateConfigBasedOn(defaultDescriptor, models.SSHKeyActivationConditional)
+	if err != nil {
+		return nil, err
+	}
+	return models.BitriseConfigMap{configName(defaultDescriptor): config}, nil
+}
@@ -0,0 +1,264 @@
+package swiftpackage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/steps"
+	bitriseModels "github.com/bitrise-io/bitrise/v2/models"
+	envmanModels "github.com/bitrise-io/envman/v2/models"
+	"github.com/bitrise-io/go-utils/pointers"
+	stepmanModels "github.com/bitrise-io/stepman/models"
+)
+
+const (
+	platformLinux = "linux"
+	platformMacOS = "macos"
+)
+
+var platforms = []string{platformLinux, platformMacOS}
+
+// supportedSwiftImageTags are the maintained tags of the official Swift image, oldest first.
+var supportedSwiftImageTags = []string{"5.9", "5.10", "6.0", "6.1", "6.2"}
+
+const (
+	runTestsWorkflowID = models.WorkflowID("run_tests")
+
+	// linuxStack runs the steps of the Linux configs, the Swift steps run in the official Swift image on it
+	linuxStack           = "ubuntu-jammy-22.04-bitrise-2024"
+	swiftContainerID     = "swift"
+	swiftImage           = "swift"
+	defaultSwiftImageTag = "latest"
+
+	linuxWorkflowSummary = "Builds and tests the Swift package on Linux, in the official Swift Docker image."
+	linuxWorkflowDescr   = `The package does not import Apple-only frameworks, so it is built on a Linux stack.
+
+Next steps:
+- Check the Swift image tag of the execution container, it is the oldest maintained Swift release supporting the swift-tools-version of the package.`
+	macOSWorkflowSummary = "Builds and tests the Swift package on macOS."
+	macOSWorkflowDescr   = `The package imports Apple-only frameworks, so it is built on a macOS stack with Xcode.
+
+Next steps:
+- Wrap the Apple-only imports into #if canImport(...) blocks to build the package on Linux.`
+
+	buildScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+swift build
+`
+	testScriptContent = `#!/usr/bin/env bash
+set -euxo pipefail
+
+swift test
+`
+)
+
+type configDescriptor struct {
+	workdir         string
+	platform        string
+	imageTag        string
+	hasTests        bool
+	hasResolvedFile bool
+	isDefault       bool
+}
+
+func createConfigDescriptor(proj project) configDescriptor {
+	d := configDescriptor{
+		workdir:         "$" + projectDirInputEnvKey,
+		platform:        platformMacOS,
+		hasTests:        len(proj.testTargets) > 0,
+		hasResolvedFile: proj.hasResolvedFile,
+	}
+	if proj.isLinuxCompatible() {
+		d.platform = platformLinux
+		d.imageTag = swiftImageTag(proj.toolsVersion)
+	}
+	if proj.relDir == "." {
+		d.workdir = ""
+	}
+	return d
+}
+
+// swiftImageTag returns the oldest supported Swift image tag building the package:
+// the swift-tools-version is the minimum Swift version of the package, not the version it is developed with.
+// Tools versions older than the supported tags get the oldest supported tag, unknown and newer ones the latest tag.
+func swiftImageTag(toolsVersion string) string {
+	if toolsVersion == "" {
+		return defaultSwiftImageTag
+	}
+	for _, tag := range supportedSwiftImageTags {
+		if compareVersions(tag, toolsVersion) >= 0 {
+			return tag
+		}
+	}
+	return defaultSwiftImageTag
+}
+
+// compareVersions compares the numeric components of the versions, the missing components count as 0.
+func compareVersions(a, b string) int {
+	componentsA, componentsB := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(componentsA), len(componentsB)); i++ {
+		var componentA, componentB int
+		if i < len(componentsA) {
+			componentA, _ = strconv.Atoi(componentsA[i])
+		}
+		if i < len(componentsB) {
+			componentB, _ = strconv.Atoi(componentsB[i])
+		}
+		if componentA != componentB {
+			return componentA - componentB
+		}
+	}
+	return 0
+}
+
+func createDefaultConfigDescriptor(platform string) configDescriptor {
+	d := configDescriptor{
+		workdir:         "$" + projectDirInputEnvKey,
+		platform:        platform,
+		hasTests:        true,
+		hasResolvedFile: true,
+		isDefault:       true,
+	}
+	if platform == platformLinux {
+		d.imageTag = defaultSwiftImageTag
+	}
+	return d
+}
+
+func generateOptions(projects []project) (models.OptionNode, models.Warnings, models.Icons, error) {
+	if len(projects) == 0 {
+		return models.OptionNode{}, nil, nil, fmt.Errorf("no Swift package found")
+	}
+
+	projectDirOption := models.NewOption(projectDirInputTitle, projectDirInputSummary, projectDirInputEnvKey, models.TypeSelector)
+	for _, proj := range projects {
+		descriptor := createConfigDescriptor(proj)
+		projectDirOption.AddConfig(proj.relDir, models.NewConfigOption(configName(descriptor), nil))
+	}
+
+	return *projectDirOption, nil, nil, nil
+}
+
+func generateConfigs(projects []project, sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	if len(projects) == 0 {
+		return models.BitriseConfigMap{}, fmt.Errorf("no Swift package found")
+	}
+
+	configs := models.BitriseConfigMap{}
+	for _, proj := range projects {
+		descriptor := createConfigDescriptor(proj)
+		config, err := generateConfigBasedOn(descriptor, sshKeyActivation)
+		if err != nil {
+			return nil, err
+		}
+		configs[configName(descriptor)] = config
+	}
+	return configs, nil
+}
+
+func configName(d configDescriptor) string {
+	if d.isDefault {
+		return "default-swift-package-" + d.platform + "-config"
+	}
+
+	name := "swift-package"
+	if d.workdir == "" {
+		name += "-root"
+	}
+	name += "-" + d.platform
+	if d.platform == platformLinux {
+		name += "-" + d.imageTag
+	}
+	if d.hasTests {
+		name += "-test"
+	}
+	return name + "-config"
+}
+
+func generateConfigBasedOn(d configDescriptor, sshKey models.SSHKeyActivation) (string, error) {
+	configBuilder := models.NewDefaultConfigBuilder()
+
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.DefaultPrepareStepList(steps.PrepareListParams{SSHKeyActivation: sshKey})...)
+
+	key, paths := cacheKeyAndPaths(d)
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.RestoreCache(key))
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, swiftStep(d, "Build", buildScriptContent))
+	if d.hasTests {
+		configBuilder.AppendStepListItemsTo(runTestsWorkflowID, swiftStep(d, "Run tests", testScriptContent))
+	}
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.SaveCache(key, paths))
+
+	configBuilder.AppendStepListItemsTo(runTestsWorkflowID, steps.DefaultDeployStepList()...)
+
+	if d.platform == platformLinux {
+		configBuilder.SetStack(linuxStack)
+		configBuilder.SetContainerDefinitions(map[string]bitriseModels.Container{
+			swiftContainerID: {
+				Type:  bitriseModels.ContainerTypeExecution,
+				Image: swiftImage + ":" + d.imageTag,
+			},
+		})
+		configBuilder.SetWorkflowSummaryTo(runTestsWorkflowID, linuxWorkflowSummary)
+		configBuilder.SetWorkflowDescriptionTo(runTestsWorkflowID, linuxWorkflowDescr)
+	} else {
+		configBuilder.SetWorkflowSummaryTo(runTestsWorkflowID, macOSWorkflowSummary)
+		configBuilder.SetWorkflowDescriptionTo(runTestsWorkflowID, macOSWorkflowDescr)
+	}
+
+	bitriseConfig, err := configBuilder.Generate(scannerName)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := yaml.Marshal(bitriseConfig)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// swiftStep returns a Script step running the swift command, in the Swift execution container on Linux.
+func swiftStep(d configDescriptor, title, content string) bitriseModels.StepListItemModel {
+	if d.platform != platformLinux {
+		return steps.ScriptStepListItem(title, content, workdirInputs(d.workdir)...)
+	}
+
+	stepID := steps.ScriptID + "@" + steps.ScriptVersion
+	inputs := []envmanModels.EnvironmentItemModel{{"content": content}}
+	inputs = append(inputs, workdirInputs(d.workdir)...)
+	step := stepmanModels.StepModel{
+		Title:              pointers.NewStringPtr(title),
+		Inputs:             inputs,
+		ExecutionContainer: swiftContainerID,
+	}
+	return bitriseModels.StepListItemModel{stepID: step}
+}
+
+// cacheKeyAndPaths returns the key of the dependency cache, keyed on the resolved dependency versions if committed,
+// and the build dir of the package, which contains the dependency checkouts too.
+func cacheKeyAndPaths(d configDescriptor) (string, string) {
+	keyFile := resolvedFile
+	if !d.hasResolvedFile {
+		keyFile = manifestFile
+	}
+	key := fmt.Sprintf(`spm-%s-{{ checksum "**/%s" }}`, d.platform, keyFile)
+
+	buildDir := ".build"
+	if d.workdir != "" {
+		buildDir = d.workdir + "/.build"
+	}
+	return key, buildDir
+}
+
+func workdirInputs(workdir string) []envmanModels.EnvironmentItemModel {
+	if workdir == "" {
+		return nil
+	}
+	return []envmanModels.EnvironmentItemModel{{"working_dir": workdir}}
+}
@@ -0,0 +1,51 @@
+package swiftpackage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwiftImageTag(t *testing.T) {
+	tests := []struct {
+		toolsVersion string
+		want         string
+	}{
+		{toolsVersion: "", want: defaultSwiftImageTag},
+		{toolsVersion: "5.5", want: "5.9"},
+		{toolsVersion: "5.9", want: "5.9"},
+		{toolsVersion: "5.10", want: "5.10"},
+		{toolsVersion: "5.11", want: "6.0"},
+		{toolsVersion: "6.1", want: "6.1"},
+		{toolsVersion: "9.0", want: defaultSwiftImageTag},
+	}
+	for _, tt := range tests {
+		t.Run(tt.toolsVersion, func(t *testing.T) {
+			require.Equal(t, tt.want, swiftImageTag(tt.toolsVersion))
+		})
+	}
+}
+
+func TestCreateConfigDescriptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		proj     project
+		wantName string
+	}{
+		{
+			name:     "Linux compatible package gets a supported image tag",
+			proj:     project{relDir: ".", toolsVersion: "5.7", testTargets: []string{"Tests"}},
+			wantName: "swift-package-root-linux-5.9-test-config",
+		},
+		{
+			name:     "package importing Apple-only frameworks is built on macOS",
+			proj:     project{relDir: "Lib", toolsVersion: "5.9", appleImports: map[string]string{"UIKit": "Sources/View.swift"}},
+			wantName: "swift-package-macos-config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantName, configName(createConfigDescriptor(tt.proj)))
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package swiftpackage
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/metrics"
+	"github.com/bitrise-io/bitrise-init/utility"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+)
+
+const (
+	manifestFile = "Package.swift"
+	resolvedFile = "Package.resolved"
+)
+
+// sourceDirs are the default target dirs of a Swift package.
+var sourceDirs = []string{"Sources", "Tests"}
+
+// iOSOnlyFrameworks can not be built by swift build on any platform, these packages are built by the ios scanner with xcodebuild.
+var iOSOnlyFrameworks = []string{"UIKit", "WatchKit"}
+
+var (
+	toolsVersionPattern = regexp.MustCompile(`^//\s*swift-tools-version\s*:\s*(\d+\.\d+)`)
+	productPattern      = regexp.MustCompile(`\.(executable|library|plugin)\s*\(\s*name\s*:\s*"([^"]+)"`)
+	testTargetPattern   = regexp.MustCompile(`\.testTarget\s*\(\s*name\s*:\s*"([^"]+)"`)
+	// Frameworks which are only available on Apple platforms
+	appleImportPattern = regexp.MustCompile(`^\s*(?:@\w+\s+)*import\s+(?:(?:struct|class|enum|protocol|typealias|func|var|let)\s+)?(UIKit|AppKit|Cocoa|SwiftUI|WatchKit)\b`)
+)
+
+var excludeFilters = []pathutil.FilterFunc{
+	pathutil.ComponentFilter(".git", false),
+	pathutil.ComponentFilter(".build", false),
+	pathutil.ComponentFilter("node_modules", false),
+	pathutil.ComponentFilter("Pods", false),
+	pathutil.ComponentFilter("Carthage", false),
+	pathutil.ComponentFilter("Tuist", false),
+}
+
+type product struct {
+	name string
+	kind string // executable, library or plugin
+}
+
+type project struct {
+	relDir          string
+	toolsVersion    string
+	products        []product
+	testTargets     []string
+	hasResolvedFile bool
+	// appleImports are the Apple-only frameworks imported by the package, mapped to the first importing file relative to the project dir
+	appleImports map[string]string
+}
+
+// isLinuxCompatible reports whether the package can be built by the Swift toolchain for Linux.
+func (p project) isLinuxCompatible() bool {
+	return len(p.appleImports) == 0
+}
+
+func (p project) importsIOSOnlyFramework() bool {
+	for _, framework := range iOSOnlyFrameworks {
+		if _, ok := p.appleImports[framework]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// collectPackageDirs returns the dirs of the standalone Swift packages, relative to the searchDir.
+// Packages nested in another package (like test fixtures) and the local packages of Xcode projects are skipped,
+// the latter are built by the ios and macos scanners.
+func collectPackageDirs(searchDir string) ([]string, error) {
+	fileList, err := pathutil.ListPathInDirSortedByComponents(searchDir, true)
+	if err != nil {
+		return nil, err
+	}
+	fileList, err = pathutil.FilterPaths(fileList, excludeFilters...)
+	if err != nil {
+		return nil, err
+	}
+
+	manifests, err := pathutil.FilterPaths(fileList, pathutil.BaseFilter(manifestFile, true))
+	if err != nil {
+		return nil, err
+	}
+	xcodeProjects, err := pathutil.FilterPaths(fileList, pathutil.ExtensionFilter(".xcodeproj", true))
+	if err != nil {
+		return nil, err
+	}
+	var xcodeProjectDirs []string
+	for _, xcodeProject := range xcodeProjects {
+		xcodeProjectDirs = append(xcodeProjectDirs, filepath.Dir(xcodeProject))
+	}
+
+	var dirs []string
+	for _, manifest := range manifests {
+		dir := filepath.Dir(manifest)
+		if isInAnyDir(dirs, dir) {
+			continue
+		}
+		if isInXcodeProjectDir(xcodeProjectDirs, dir) {
+			log.TPrintf("Skipping %s, it is part of an Xcode project", manifest)
+			continue
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs, nil
+}
+
+func isInAnyDir(dirs []string, pth string) bool {
+	for _, dir := range dirs {
+		if dir == "." || pth == dir || strings.HasPrefix(pth, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isInXcodeProjectDir reports whether the package dir is the dir of an Xcode project or nested in it.
+// An Xcode project in the search dir root only claims the package next to it, not every package of the repository.
+func isInXcodeProjectDir(xcodeProjectDirs []string, pth string) bool {
+	for _, dir := range xcodeProjectDirs {
+		if pth == dir || (dir != "." && strings.HasPrefix(pth, dir+string(filepath.Separator))) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseProject reads the products and the test targets of the package manifest, and the frameworks imported by its sources.
+func parseProject(searchDir, relDir string) (project, error) {
+	projectDir := filepath.Join(searchDir, relDir)
+	proj := project{
+		relDir:          relDir,
+		hasResolvedFile: utility.FileExists(filepath.Join(projectDir, resolvedFile)),
+		appleImports:    map[string]string{},
+	}
+
+	content, err := metrics.ReadStringFromFile(filepath.Join(projectDir, manifestFile))
+	if err != nil {
+		return project{}, err
+	}
+	proj.toolsVersion, proj.products, proj.testTargets = parseManifest(content)
+
+	for _, sourceDir := range sourceDirs {
+		if err := scanImports(projectDir, sourceDir, proj.appleImports); err != nil {
+			log.TWarnf("Failed to check the imports of %s: %s", filepath.Join(relDir, sourceDir), err)
+		}
+	}
+
+	return proj, nil
+}
+
+// parseManifest returns the tools version, the products and the test targets declared in a Package.swift.
+// The manifest is Swift code, the declarations are matched without evaluating it, so the host does not need a Swift toolchain.
+func parseManifest(content string) (string, []product, []string) {
+	var toolsVersion string
+	if match := toolsVersionPattern.FindStringSubmatch(content); match != nil {
+		toolsVersion = match[1]
+	}
+
+	var products []product
+	for _, match := range productPattern.FindAllStringSubmatch(content, -1) {
+		products = append(products, product{name: match[2], kind: match[1]})
+	}
+
+	var testTargets []string
+	for _, match := range testTargetPattern.FindAllStringSubmatch(content, -1) {
+		testTargets = append(testTargets, match[1])
+	}
+
+	return toolsVersion, products, testTargets
+}
+
+// scanImports collects the Apple-only framework imports of the Swift files in the source dir.
+func scanImports(projectDir, sourceDir string, imports map[string]string) error {
+	dir := filepath.Join(projectDir, sourceDir)
+	if exists, err := pathutil.IsDirExists(dir); err != nil || !exists {
+		return err
+	}
+
+	return filepath.Walk(dir, func(pth string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(pth) != ".swift" {
+			return nil
+		}
+
+		content, err := metrics.ReadStringFromFile(pth)
+		if err != nil {
+			return err
+		}
+		for _, framework := range parseAppleImports(content) {
+			if _, ok := imports[framework]; ok {
+				continue
+			}
+			relPth, err := filepath.Rel(projectDir, pth)
+			if err != nil {
+				return err
+			}
+			imports[framework] = relPth
+		}
+		return nil
+	})
+}
+
+// parseAppleImports returns the Apple-only frameworks imported by a Swift file.
+// Imports inside conditional compilation blocks (like #if canImport(UIKit)) are skipped, those do not break the Linux build.
+func parseAppleImports(content string) []string {
+	var frameworks []string
+	conditionalDepth := 0
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "#if"):
+			conditionalDepth++
+		case strings.HasPrefix(line, "#endif"):
+			if conditionalDepth > 0 {
+				conditionalDepth--
+			}
+		case conditionalDepth == 0:
+			if match := appleImportPattern.FindStringSubmatch(line); match != nil {
+				frameworks = append(frameworks, match[1])
+			}
+		}
+	}
+	return frameworks
+}
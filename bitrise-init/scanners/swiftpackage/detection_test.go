@@ -0,0 +1,103 @@
+package swiftpackage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for pth, content := range files {
+		pth = filepath.Join(dir, pth)
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+		require.NoError(t, os.WriteFile(pth, []byte(content), 0644))
+	}
+}
+
+func TestCollectPackageDirs(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name: "nested packages belong to the outer package",
+			files: map[string]string{
+				"Package.swift":                       "",
+				"Tests/Fixtures/Sample/Package.swift": "",
+			},
+			want: []string{"."},
+		},
+		{
+			name: "Xcode project in the root only claims the root package",
+			files: map[string]string{
+				"App.xcodeproj/project.pbxproj": "",
+				"Package.swift":                 "",
+				"Packages/Core/Package.swift":   "",
+				"Tools/Lint/Package.swift":      "",
+			},
+			want: []string{"Packages/Core", "Tools/Lint"},
+		},
+		{
+			name: "local packages of an Xcode project in a subdir are skipped",
+			files: map[string]string{
+				"App/App.xcodeproj/project.pbxproj":  "",
+				"App/Packages/Feature/Package.swift": "",
+				"Library/Package.swift":              "",
+			},
+			want: []string{"Library"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchDir := t.TempDir()
+			writeFiles(t, searchDir, tt.files)
+
+			got, err := collectPackageDirs(searchDir)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseManifest(t *testing.T) {
+	content := `// swift-tools-version:5.9
+import PackageDescription
+
+let package = Package(
+    name: "Tool",
+    products: [
+        .executable(name: "tool", targets: ["Tool"]),
+        .library(name: "ToolKit", targets: ["ToolKit"]),
+    ],
+    targets: [
+        .executableTarget(name: "Tool"),
+        .target(name: "ToolKit"),
+        .testTarget(name: "ToolKitTests", dependencies: ["ToolKit"]),
+    ]
+)
+`
+	toolsVersion, products, testTargets := parseManifest(content)
+	require.Equal(t, "5.9", toolsVersion)
+	require.Equal(t, []product{{name: "tool", kind: "executable"}, {name: "ToolKit", kind: "library"}}, products)
+	require.Equal(t, []string{"ToolKitTests"}, testTargets)
+}
+
+func TestParseAppleImports(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{name: "plain imports", content: "import Foundation\nimport UIKit\n@testable import SwiftUI\n", want: []string{"UIKit", "SwiftUI"}},
+		{name: "conditional import", content: "#if canImport(UIKit)\nimport UIKit\n#endif\n", want: nil},
+		{name: "declaration import", content: "import class AppKit.NSView\n", want: []string{"AppKit"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, parseAppleImports(tt.content))
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package swiftpackage
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+const (
+	scannerName = "swift-package"
+
+	projectDirInputTitle   = "Swift package directory"
+	projectDirInputSummary = "The directory containing the Package.swift file of the Swift package"
+	projectDirInputEnvKey  = "SWIFT_PACKAGE_DIR"
+
+	platformInputTitle   = "Platform"
+	platformInputSummary = "The platform to build the Swift package on. Packages without Apple-only framework imports can be built on Linux."
+)
+
+// Scanner implements ScannerInterface for standalone Swift packages, like server apps and command line tools.
+type Scanner struct {
+	projects []project // populated by DetectPlatform
+}
+
+// NewScanner creates a new Scanner instance.
+func NewScanner() *Scanner {
+	return &Scanner{}
+}
+
+// Name returns the scanner name.
+func (s *Scanner) Name() string {
+	return scannerName
+}
+
+// DetectPlatform checks whether searchDir contains standalone Swift packages, and whether they can be built on Linux.
+func (s *Scanner) DetectPlatform(searchDir string) (bool, error) {
+	dirs, err := collectPackageDirs(searchDir)
+	if err != nil {
+		log.TWarnf("%s", err)
+		log.TPrintf("Platform not detected")
+		return false, nil
+	}
+
+	for _, dir := range dirs {
+		proj, err := parseProject(searchDir, dir)
+		if err != nil {
+			log.TWarnf("Failed to parse %s: %s", filepath.Join(dir, manifestFile), err)
+			continue
+		}
+
+		log.TPrintf("Swift package found: %s", proj.relDir)
+		log.TPrintf("- tools version: %s", proj.toolsVersion)
+		log.TPrintf("- products: %d, test targets: %d", len(proj.products), len(proj.testTargets))
+		if proj.importsIOSOnlyFramework() {
+			log.TPrintf("- imports iOS frameworks (%s), skipping", strings.Join(importedFrameworks(proj), ", "))
+			continue
+		}
+		log.TPrintf("- Linux compatible: %t", proj.isLinuxCompatible())
+
+		s.projects = append(s.projects, proj)
+	}
+
+	if len(s.projects) == 0 {
+		log.TPrintf("Platform not detected")
+		return false, nil
+	}
+
+	log.TSuccessf("Platform detected")
+	return true, nil
+}
+
+// Evidence returns the manifests of the detected packages and the sources deciding their platform.
+func (s *Scanner) Evidence() models.Evidences {
+	var evidences models.Evidences
+	for _, proj := range s.projects {
+		evidences.Add(filepath.Join(proj.relDir, manifestFile), fmt.Sprintf("Swift package of %d products and %d test targets", len(proj.products), len(proj.testTargets)))
+		for _, framework := range importedFrameworks(proj) {
+			evidences.Add(filepath.Join(proj.relDir, proj.appleImports[framework]), fmt.Sprintf("%s import", framework))
+		}
+	}
+	return evidences
+}
+
+// ProjectRoots returns the package dirs, keyed by the project dir option value.
+func (s *Scanner) ProjectRoots() []models.ProjectRoot {
+	var roots []models.ProjectRoot
+	for _, proj := range s.projects {
+		roots = append(roots, models.ProjectRoot{Dir: proj.relDir, OptionValue: proj.relDir})
+	}
+	return roots
+}
+
+// ExcludedScannerNames returns scanners to skip when this scanner detects.
+func (s *Scanner) ExcludedScannerNames() []string {
+	return []string{}
+}
+
+// Options builds the option tree of the detected packages.
+func (s *Scanner) Options() (models.OptionNode, models.Warnings, models.Icons, error) {
+	return generateOptions(s.projects)
+}
+
+// Configs generates the pre-made bitrise.yml templates for each detected package.
+func (s *Scanner) Configs(sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	return generateConfigs(s.projects, sshKeyActivation)
+}
+
+// DefaultOptions returns the option tree for the manual configuration flow.
+func (s *Scanner) DefaultOptions() models.OptionNode {
+	projectDirOption := models.NewOption(projectDirInputTitle, projectDirInputSummary, projectDirInputEnvKey, models.TypeUserInput)
+	platformOption := models.NewOption(platformInputTitle, platformInputSummary, "", models.TypeSelector)
+
+	projectDirOption.AddOption(models.UserInputOptionDefaultValue, platformOption)
+	for _, platform := range platforms {
+		descriptor := createDefaultConfigDescriptor(platform)
+		platformOption.AddConfig(platform, models.NewConfigOption(configName(descriptor), nil))
+	}
+
+	return *projectDirOption
+}
+
+// DefaultConfigs generates the static bitrise.yml templates for the manual configuration flow.
+func (s *Scanner) DefaultConfigs() (models.BitriseConfigMap, error) {
+	configs := models.BitriseConfigMap{}
+	for _, platform := range platforms {
+		descriptor := createDefaultConfigDescriptor(platform)
+		config, err := generateConfigBasedOn(descriptor, models.SSHKeyActivationConditional)
+		if err != nil {
+			return nil, err
+		}
+		configs[configName(descriptor)] = config
+	}
+	return configs, nil
+}
+
+func importedFrameworks(proj project) []string {
+	var frameworks []string
+	for framework := range proj.appleImports {
+		frameworks = append(frameworks, framework)
+	}
+	sort.Strings(frameworks)
+	return frameworks
+}
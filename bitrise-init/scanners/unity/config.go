@@ -0,0 +1,211 @@
+package unity
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/scanners/android"
+	"github.com/bitrise-io/bitrise-init/scanners/ios"
+	"github.com/bitrise-io/bitrise-init/steps"
+	envmanModels "github.com/bitrise-io/envman/v2/models"
+)
+
+const (
+	buildWorkflowID = models.WorkflowID("build")
+
+	buildWorkflowSummary     = "Builds the Unity project with the Unity Editor in batch mode, then builds the exported native project."
+	buildWorkflowDescription = `The Unity Editor is activated with the UNITY_SERIAL, UNITY_USERNAME and UNITY_PASSWORD Secrets.
+The build method has to export the native project to the path in the UNITY_BUILD_PATH Environment Variable.
+
+Next steps:
+- Make sure the Unity Editor version of the project is installed on the stack, the build fails if it is missing.
+- Add the UNITY_SERIAL, UNITY_USERNAME and UNITY_PASSWORD Secrets.
+- Set up code signing for the native build.`
+
+	libraryCacheKey   = `unity-library-{{ checksum "**/Packages/packages-lock.json" }}`
+	libraryCachePaths = "$" + projectDirInputEnvKey + "/Library"
+
+	// Unity exports the Xcode project with these names
+	xcodeProjectName = "Unity-iPhone.xcodeproj"
+	xcodeSchemeName  = "Unity-iPhone"
+
+	// Unity exports the Android app as the launcher module of a Gradle project
+	gradleModuleName  = "launcher"
+	gradleVariantName = "release"
+
+	buildScriptTemplate = `#!/usr/bin/env bash
+set -euxo pipefail
+
+# The build method reads the export path from the UNITY_BUILD_PATH environment variable
+export UNITY_BUILD_PATH="$BITRISE_SOURCE_DIR/Build/%[1]s"
+envman add --key UNITY_BUILD_PATH --value "$UNITY_BUILD_PATH"
+
+unity="/Applications/Unity/Hub/Editor/$` + editorVersionInputEnvKey + `/Unity.app/Contents/MacOS/Unity"
+if [ ! -x "$unity" ]; then
+  echo "Unity Editor $` + editorVersionInputEnvKey + ` is not installed at $unity." >&2
+  echo "Install it with Unity Hub in a Script Step before this one, or use a stack with the Unity Editor preinstalled." >&2
+  exit 1
+fi
+
+# Return the license activated by the build, even if the build fails
+trap '"$unity" -batchmode -nographics -quit -returnlicense -username "$UNITY_USERNAME" -password "$UNITY_PASSWORD" -logFile -' EXIT
+
+"$unity" -batchmode -nographics -quit \
+  -projectPath "$` + projectDirInputEnvKey + `" \
+  -buildTarget %[1]s \
+  -executeMethod "$` + buildMethodInputEnvKey + `" \
+  -serial "$UNITY_SERIAL" -username "$UNITY_USERNAME" -password "$UNITY_PASSWORD" \
+  -logFile -
+`
+)
+
+// buildTargets are the -buildTarget command line argument values of the platforms.
+var buildTargets = map[string]string{
+	platformIOS:     "iOS",
+	platformAndroid: "Android",
+}
+
+type configDescriptor struct {
+	platform  string
+	isDefault bool
+}
+
+func generateOptions(projects []project, projectIcons map[string]models.Icons) (models.OptionNode, models.Warnings, models.Icons, error) {
+	if len(projects) == 0 {
+		return models.OptionNode{}, nil, nil, fmt.Errorf("no Unity project found")
+	}
+
+	var allIcons models.Icons
+	projectDirOption := models.NewOption(projectDirInputTitle, projectDirInputSummary, projectDirInputEnvKey, models.TypeSelector)
+	for _, proj := range projects {
+		icons := projectIcons[proj.relDir]
+		allIcons = append(allIcons, icons...)
+		iconIDs := make([]string, len(icons))
+		for i, icon := range icons {
+			iconIDs[i] = icon.Filename
+		}
+
+		var editorVersionOption *models.OptionNode
+		if proj.editorVersion != "" {
+			editorVersionOption = models.NewOption(editorVersionInputTitle, editorVersionInputSummary, editorVersionInputEnvKey, models.TypeSelector)
+		} else {
+			editorVersionOption = models.NewOption(editorVersionInputTitle, editorVersionInputSummary, editorVersionInputEnvKey, models.TypeUserInput)
+		}
+		projectDirOption.AddOption(proj.relDir, editorVersionOption)
+
+		var buildMethodOption *models.OptionNode
+		if len(proj.buildMethods) > 0 {
+			buildMethodOption = models.NewOption(buildMethodInputTitle, buildMethodInputSummary, buildMethodInputEnvKey, models.TypeSelector)
+		} else {
+			buildMethodOption = models.NewOption(buildMethodInputTitle, buildMethodInputSummary, buildMethodInputEnvKey, models.TypeUserInput)
+		}
+		editorVersionValue := proj.editorVersion
+		if editorVersionValue == "" {
+			editorVersionValue = models.UserInputOptionDefaultValue
+		}
+		editorVersionOption.AddOption(editorVersionValue, buildMethodOption)
+
+		platformOption := newPlatformOption(proj.platforms, iconIDs, false)
+		if len(proj.buildMethods) == 0 {
+			buildMethodOption.AddOption(models.UserInputOptionDefaultValue, platformOption)
+		}
+		for _, buildMethod := range proj.buildMethods {
+			buildMethodOption.AddOption(buildMethod, platformOption)
+		}
+	}
+
+	return *projectDirOption, nil, allIcons, nil
+}
+
+// newPlatformOption returns the platform selector, iOS builds need the distribution method of the archive.
+func newPlatformOption(platforms []string, iconIDs []string, isDefault bool) *models.OptionNode {
+	platformOption := models.NewOption(platformInputTitle, platformInputSummary, "", models.TypeSelector)
+	for _, platform := range platforms {
+		descriptor := configDescriptor{platform: platform, isDefault: isDefault}
+		configOption := models.NewConfigOption(configName(descriptor), iconIDs)
+
+		if platform != platformIOS {
+			platformOption.AddConfig(platform, configOption)
+			continue
+		}
+
+		distributionMethodOption := models.NewOption(ios.DistributionMethodInputTitle, ios.DistributionMethodInputSummary, ios.DistributionMethodEnvKey, models.TypeSelector)
+		platformOption.AddOption(platform, distributionMethodOption)
+		for _, exportMethod := range ios.IosExportMethods {
+			distributionMethodOption.AddConfig(exportMethod, configOption)
+		}
+	}
+	return platformOption
+}
+
+func generateConfigs(projects []project, sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	if len(projects) == 0 {
+		return models.BitriseConfigMap{}, fmt.Errorf("no Unity project found")
+	}
+
+	configs := models.BitriseConfigMap{}
+	for _, proj := range projects {
+		for _, platform := range proj.platforms {
+			descriptor := configDescriptor{platform: platform}
+			config, err := generateConfigBasedOn(descriptor, sshKeyActivation)
+			if err != nil {
+				return nil, err
+			}
+			configs[configName(descriptor)] = config
+		}
+	}
+	return configs, nil
+}
+
+func configName(d configDescriptor) string {
+	if d.isDefault {
+		return "default-unity-" + d.platform + "-config"
+	}
+	return "unity-" + d.platform + "-config"
+}
+
+func generateConfigBasedOn(d configDescriptor, sshKey models.SSHKeyActivation) (string, error) {
+	configBuilder := models.NewDefaultConfigBuilder()
+
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.DefaultPrepareStepList(steps.PrepareListParams{SSHKeyActivation: sshKey})...)
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.RestoreCache(libraryCacheKey))
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.ScriptStepListItem("Build with Unity", fmt.Sprintf(buildScriptTemplate, buildTargets[d.platform])))
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.SaveCache(libraryCacheKey, libraryCachePaths))
+
+	switch d.platform {
+	case platformIOS:
+		configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.XcodeArchiveStepListItem(
+			envmanModels.EnvironmentItemModel{ios.ProjectPathInputKey: "$UNITY_BUILD_PATH/" + xcodeProjectName},
+			envmanModels.EnvironmentItemModel{ios.SchemeInputKey: xcodeSchemeName},
+			envmanModels.EnvironmentItemModel{ios.DistributionMethodInputKey: "$" + ios.DistributionMethodEnvKey},
+			envmanModels.EnvironmentItemModel{ios.AutomaticCodeSigningKey: ios.AutomaticCodeSigningValue},
+		))
+	case platformAndroid:
+		configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.AndroidBuildStepListItem(
+			envmanModels.EnvironmentItemModel{android.ProjectLocationInputKey: "$UNITY_BUILD_PATH"},
+			envmanModels.EnvironmentItemModel{android.ModuleInputKey: gradleModuleName},
+			envmanModels.EnvironmentItemModel{android.VariantInputKey: gradleVariantName},
+			envmanModels.EnvironmentItemModel{android.CacheLevelInputKey: android.CacheLevelNone},
+		))
+		configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.SignAPKStepListItem())
+	}
+
+	configBuilder.AppendStepListItemsTo(buildWorkflowID, steps.DefaultDeployStepList()...)
+
+	configBuilder.SetWorkflowSummaryTo(buildWorkflowID, buildWorkflowSummary)
+	configBuilder.SetWorkflowDescriptionTo(buildWorkflowID, buildWorkflowDescription)
+
+	bitriseConfig, err := configBuilder.Generate(scannerName)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := yaml.Marshal(bitriseConfig)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,50 @@
+package unity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateConfigBasedOn(t *testing.T) {
+	tests := []struct {
+		name         string
+		descriptor   configDescriptor
+		wantContains []string
+	}{
+		{
+			name:       "iOS",
+			descriptor: configDescriptor{platform: platformIOS},
+			wantContains: []string{
+				"-buildTarget iOS",
+				`if [ ! -x "$unity" ]; then`,
+				"xcode-archive",
+			},
+		},
+		{
+			name:       "Android",
+			descriptor: configDescriptor{platform: platformAndroid},
+			wantContains: []string{
+				"-buildTarget Android",
+				`if [ ! -x "$unity" ]; then`,
+				"android-build",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := generateConfigBasedOn(tt.descriptor, models.SSHKeyActivationConditional)
+			require.NoError(t, err)
+			for _, want := range tt.wantContains {
+				require.True(t, strings.Contains(config, want), "config doesn't contain %q:\n%s", want, config)
+			}
+		})
+	}
+}
+
+func TestConfigName(t *testing.T) {
+	require.Equal(t, "unity-ios-config", configName(configDescriptor{platform: platformIOS}))
+	require.Equal(t, "default-unity-android-config", configName(configDescriptor{platform: platformAndroid, isDefault: true}))
+}
@@ -0,0 +1,269 @@
+package unity
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/metrics"
+	"github.com/bitrise-io/bitrise-init/utility"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-io/go-utils/sliceutil"
+)
+
+const (
+	projectVersionFile  = "ProjectSettings/ProjectVersion.txt"
+	projectSettingsFile = "ProjectSettings/ProjectSettings.asset"
+	assetsDir           = "Assets"
+
+	platformIOS     = "ios"
+	platformAndroid = "android"
+)
+
+var platforms = []string{platformIOS, platformAndroid}
+
+// buildTargetPlatforms map the build target names of the player settings to the platforms built by the configs.
+var buildTargetPlatforms = map[string]string{
+	"iPhone":  platformIOS,
+	"iOS":     platformIOS,
+	"Android": platformAndroid,
+}
+
+var (
+	editorVersionPattern = regexp.MustCompile(`(?m)^m_EditorVersion:\s*(\S+)`)
+	buildTargetPattern   = regexp.MustCompile(`m_BuildTarget:\s*(\w+)`)
+	iconGUIDPattern      = regexp.MustCompile(`m_Icon:\s*\{fileID:\s*\d+,\s*guid:\s*([0-9a-f]{32})`)
+	metaGUIDPattern      = regexp.MustCompile(`(?m)^guid:\s*([0-9a-f]{32})`)
+
+	namespacePattern   = regexp.MustCompile(`\bnamespace\s+([A-Za-z_][\w.]*)`)
+	classPattern       = regexp.MustCompile(`\bclass\s+([A-Za-z_]\w*)`)
+	buildMethodPattern = regexp.MustCompile(`\bstatic\s+void\s+([A-Za-z_]\w*)\s*\(\s*\)`)
+)
+
+type project struct {
+	relDir        string
+	editorVersion string
+	platforms     []string
+	buildMethods  []string
+	buildScripts  []string // editor scripts declaring the build methods, relative to the project dir
+	iconPath      string   // relative to the project dir
+}
+
+var excludeFilters = []pathutil.FilterFunc{
+	pathutil.ComponentFilter(".git", false),
+	pathutil.ComponentFilter("node_modules", false),
+	pathutil.ComponentFilter("Library", false),
+	pathutil.ComponentFilter("Temp", false),
+}
+
+// collectProjectDirs returns the dirs containing a ProjectSettings/ProjectVersion.txt file and an Assets dir.
+// Paths are relative to the searchDir.
+func collectProjectDirs(searchDir string) ([]string, error) {
+	fileList, err := pathutil.ListPathInDirSortedByComponents(searchDir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := pathutil.FilterPaths(fileList, append(excludeFilters, pathutil.BaseFilter(filepath.Base(projectVersionFile), true))...)
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	for _, pth := range paths {
+		settingsDir := filepath.Dir(pth)
+		if filepath.Base(settingsDir) != filepath.Dir(projectVersionFile) {
+			continue
+		}
+		dir := filepath.Dir(settingsDir)
+		if exists, err := pathutil.IsDirExists(filepath.Join(searchDir, dir, assetsDir)); err != nil || !exists {
+			continue
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs, nil
+}
+
+// parseProject reads the editor version, the build target platforms, the build methods and the app icon of a project.
+func parseProject(searchDir, relDir string) project {
+	projectDir := filepath.Join(searchDir, relDir)
+	proj := project{relDir: relDir}
+
+	versionContent, err := metrics.ReadStringFromFile(filepath.Join(projectDir, projectVersionFile))
+	if err != nil {
+		log.TWarnf("Failed to read %s: %s", projectVersionFile, err)
+	} else if match := editorVersionPattern.FindStringSubmatch(versionContent); match != nil {
+		proj.editorVersion = match[1]
+	}
+
+	var iconGUID string
+	if utility.FileExists(filepath.Join(projectDir, projectSettingsFile)) {
+		settingsContent, err := metrics.ReadStringFromFile(filepath.Join(projectDir, projectSettingsFile))
+		if err != nil {
+			log.TWarnf("Failed to read %s: %s", projectSettingsFile, err)
+		} else {
+			proj.platforms = parseBuildTargetPlatforms(settingsContent)
+			iconGUID = parseIconGUID(settingsContent)
+		}
+	}
+	if len(proj.platforms) == 0 {
+		// Player settings are only written for the platforms which were set up in the editor
+		proj.platforms = platforms
+	}
+
+	result, err := scanAssets(filepath.Join(projectDir, assetsDir), iconGUID)
+	if err != nil {
+		log.TWarnf("Failed to scan the %s dir: %s", assetsDir, err)
+	}
+	proj.buildMethods = result.buildMethods
+	for _, script := range result.buildScripts {
+		proj.buildScripts = append(proj.buildScripts, filepath.Join(assetsDir, script))
+	}
+	if result.iconPath != "" {
+		proj.iconPath = filepath.Join(assetsDir, result.iconPath)
+	}
+
+	return proj
+}
+
+// parseBuildTargetPlatforms returns the platforms which have player settings, like an application identifier or icons.
+func parseBuildTargetPlatforms(content string) []string {
+	var buildTargets []string
+	for _, match := range buildTargetPattern.FindAllStringSubmatch(content, -1) {
+		buildTargets = append(buildTargets, match[1])
+	}
+	buildTargets = append(buildTargets, parseApplicationIdentifierTargets(content)...)
+
+	var found []string
+	for _, buildTarget := range buildTargets {
+		platform, ok := buildTargetPlatforms[buildTarget]
+		if ok && !sliceutil.IsStringInSlice(platform, found) {
+			found = append(found, platform)
+		}
+	}
+	sort.Strings(found)
+	return found
+}
+
+// parseApplicationIdentifierTargets returns the build target keys of the applicationIdentifier map.
+func parseApplicationIdentifierTargets(content string) []string {
+	var targets []string
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	inMap := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "applicationIdentifier:" {
+			inMap = true
+			continue
+		}
+		if !inMap {
+			continue
+		}
+		// Map entries are indented deeper than the key
+		if !strings.HasPrefix(line, "    ") {
+			break
+		}
+		split := strings.SplitN(trimmed, ":", 2)
+		if len(split) == 2 && strings.TrimSpace(split[1]) != "" {
+			targets = append(targets, split[0])
+		}
+	}
+	return targets
+}
+
+// parseIconGUID returns the asset GUID of the first icon set in the player settings.
+func parseIconGUID(content string) string {
+	match := iconGUIDPattern.FindStringSubmatch(content)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// assetsScanResult holds the build methods and the icon found in the Assets dir, paths are relative to the Assets dir.
+type assetsScanResult struct {
+	buildMethods []string
+	buildScripts []string
+	iconPath     string
+}
+
+// scanAssets walks the Assets dir for build methods of the Editor scripts, and for the icon asset of the given GUID.
+func scanAssets(dir, iconGUID string) (assetsScanResult, error) {
+	var result assetsScanResult
+	err := filepath.Walk(dir, func(pth string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		switch {
+		case iconGUID != "" && result.iconPath == "" && strings.HasSuffix(pth, ".meta"):
+			content, err := metrics.ReadStringFromFile(pth)
+			if err != nil {
+				return err
+			}
+			if match := metaGUIDPattern.FindStringSubmatch(content); match != nil && match[1] == iconGUID {
+				result.iconPath, err = filepath.Rel(dir, strings.TrimSuffix(pth, ".meta"))
+				if err != nil {
+					return err
+				}
+			}
+		case filepath.Ext(pth) == ".cs" && isInEditorDir(dir, pth):
+			content, err := metrics.ReadStringFromFile(pth)
+			if err != nil {
+				return err
+			}
+			methods := parseBuildMethods(content)
+			if len(methods) == 0 {
+				return nil
+			}
+			script, err := filepath.Rel(dir, pth)
+			if err != nil {
+				return err
+			}
+			result.buildMethods = append(result.buildMethods, methods...)
+			result.buildScripts = append(result.buildScripts, script)
+		}
+		return nil
+	})
+	sort.Strings(result.buildMethods)
+	return result, err
+}
+
+// isInEditorDir reports whether a script is an editor script: only scripts in an Editor dir can call the BuildPipeline.
+func isInEditorDir(assetsDir, pth string) bool {
+	rel, err := filepath.Rel(assetsDir, pth)
+	if err != nil {
+		return false
+	}
+	return sliceutil.IsStringInSlice("Editor", strings.Split(filepath.Dir(rel), string(filepath.Separator)))
+}
+
+// parseBuildMethods returns the parameterless static methods of an editor script calling BuildPipeline.BuildPlayer,
+// in the form expected by the -executeMethod command line argument.
+func parseBuildMethods(content string) []string {
+	if !strings.Contains(content, "BuildPipeline.BuildPlayer") {
+		return nil
+	}
+	classMatch := classPattern.FindStringSubmatch(content)
+	if classMatch == nil {
+		return nil
+	}
+	className := classMatch[1]
+	if namespaceMatch := namespacePattern.FindStringSubmatch(content); namespaceMatch != nil {
+		className = namespaceMatch[1] + "." + className
+	}
+
+	var methods []string
+	for _, match := range buildMethodPattern.FindAllStringSubmatch(content, -1) {
+		methods = append(methods, className+"."+match[1])
+	}
+	return methods
+}
@@ -0,0 +1,107 @@
+package unity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for pth, content := range files {
+		pth = filepath.Join(dir, pth)
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+		require.NoError(t, os.WriteFile(pth, []byte(content), 0644))
+	}
+}
+
+const buildScript = `using UnityEditor;
+
+namespace Game.Build
+{
+    public static class Builder
+    {
+        public static void BuildIOS()
+        {
+            BuildPipeline.BuildPlayer(new BuildPlayerOptions());
+        }
+
+        private static void Helper(string path)
+        {
+        }
+    }
+}
+`
+
+const playerSettings = `PlayerSettings:
+  applicationIdentifier:
+    Android: com.example.game
+    iPhone: com.example.game
+  m_BuildTargetIcons:
+  - m_BuildTarget:
+    m_Icons:
+    - m_Icon: {fileID: 2800000, guid: 0123456789abcdef0123456789abcdef, type: 3}
+`
+
+func TestParseProject(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  project
+	}{
+		{
+			name: "project with player settings, build method and icon",
+			files: map[string]string{
+				"game/ProjectSettings/ProjectVersion.txt":    "m_EditorVersion: 2022.3.10f1\nm_EditorVersionWithRevision: 2022.3.10f1 (ff3792e53c62)\n",
+				"game/ProjectSettings/ProjectSettings.asset": playerSettings,
+				"game/Assets/Editor/Builder.cs":              buildScript,
+				"game/Assets/Scripts/Player.cs":              buildScript,
+				"game/Assets/Icons/icon.png":                 "",
+				"game/Assets/Icons/icon.png.meta":            "fileFormatVersion: 2\nguid: 0123456789abcdef0123456789abcdef\n",
+			},
+			want: project{
+				relDir:        "game",
+				editorVersion: "2022.3.10f1",
+				platforms:     []string{platformAndroid, platformIOS},
+				buildMethods:  []string{"Game.Build.Builder.BuildIOS"},
+				buildScripts:  []string{filepath.Join("Assets", "Editor", "Builder.cs")},
+				iconPath:      filepath.Join("Assets", "Icons", "icon.png"),
+			},
+		},
+		{
+			name: "project without player settings targets every platform",
+			files: map[string]string{
+				"game/ProjectSettings/ProjectVersion.txt": "m_EditorVersion: 6000.0.23f1\n",
+				"game/Assets/Scenes/Main.unity":           "",
+			},
+			want: project{
+				relDir:        "game",
+				editorVersion: "6000.0.23f1",
+				platforms:     platforms,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchDir := t.TempDir()
+			writeFiles(t, searchDir, tt.files)
+			t.Chdir(searchDir)
+
+			dirs, err := collectProjectDirs(searchDir)
+			require.NoError(t, err)
+			require.Equal(t, []string{tt.want.relDir}, dirs)
+			require.Equal(t, tt.want, parseProject(searchDir, dirs[0]))
+		})
+	}
+}
+
+func TestCollectProjectDirs_withoutAssets(t *testing.T) {
+	searchDir := t.TempDir()
+	writeFiles(t, searchDir, map[string]string{"ProjectSettings/ProjectVersion.txt": "m_EditorVersion: 2022.3.10f1\n"})
+	t.Chdir(searchDir)
+
+	dirs, err := collectProjectDirs(searchDir)
+	require.NoError(t, err)
+	require.Empty(t, dirs)
+}
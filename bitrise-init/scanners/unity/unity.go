@@ -0,0 +1,151 @@
+package unity
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/models"
+	"github.com/bitrise-io/bitrise-init/utility"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+const (
+	scannerName = "unity"
+
+	projectDirInputTitle   = "Unity project directory"
+	projectDirInputSummary = "The directory containing the Assets and ProjectSettings dirs of the Unity project"
+	projectDirInputEnvKey  = "UNITY_PROJECT_DIR"
+
+	editorVersionInputTitle   = "Unity Editor version"
+	editorVersionInputSummary = "The Unity Editor version of the project, as in ProjectSettings/ProjectVersion.txt"
+	editorVersionInputEnvKey  = "UNITY_EDITOR_VERSION"
+
+	buildMethodInputTitle   = "Build method"
+	buildMethodInputSummary = "The static method of an Editor script building the player, passed to Unity with -executeMethod, like BuildScript.Build"
+	buildMethodInputEnvKey  = "UNITY_BUILD_METHOD"
+
+	platformInputTitle   = "Platform"
+	platformInputSummary = "The platform to build the Unity project for"
+)
+
+// Scanner implements ScannerInterface for Unity projects.
+type Scanner struct {
+	searchDir string
+	projects  []project // populated by DetectPlatform
+}
+
+// NewScanner creates a new Scanner instance.
+func NewScanner() *Scanner {
+	return &Scanner{}
+}
+
+// Name returns the scanner name.
+func (s *Scanner) Name() string {
+	return scannerName
+}
+
+// DetectPlatform checks whether searchDir contains a Unity project and reads its settings.
+func (s *Scanner) DetectPlatform(searchDir string) (bool, error) {
+	s.searchDir = searchDir
+
+	dirs, err := collectProjectDirs(searchDir)
+	if err != nil {
+		log.TWarnf("%s", err)
+		log.TPrintf("Platform not detected")
+		return false, nil
+	}
+
+	for _, dir := range dirs {
+		proj := parseProject(searchDir, dir)
+		log.TPrintf("Unity project found: %s", proj.relDir)
+		log.TPrintf("- editor version: %s", proj.editorVersion)
+		log.TPrintf("- platforms: %s", strings.Join(proj.platforms, ", "))
+		log.TPrintf("- build methods: %s", strings.Join(proj.buildMethods, ", "))
+		s.projects = append(s.projects, proj)
+	}
+
+	if len(s.projects) == 0 {
+		log.TPrintf("Platform not detected")
+		return false, nil
+	}
+
+	log.TSuccessf("Platform detected")
+	return true, nil
+}
+
+// Evidence returns the project version files and the editor scripts declaring build methods.
+func (s *Scanner) Evidence() models.Evidences {
+	var evidences models.Evidences
+	for _, proj := range s.projects {
+		evidences.Add(filepath.Join(proj.relDir, projectVersionFile), fmt.Sprintf("Unity %s project", proj.editorVersion))
+		for _, script := range proj.buildScripts {
+			evidences.Add(filepath.Join(proj.relDir, script), "BuildPipeline.BuildPlayer call")
+		}
+	}
+	return evidences
+}
+
+// ProjectRoots returns the project dirs, keyed by the project dir option value.
+func (s *Scanner) ProjectRoots() []models.ProjectRoot {
+	var roots []models.ProjectRoot
+	for _, proj := range s.projects {
+		roots = append(roots, models.ProjectRoot{Dir: proj.relDir, OptionValue: proj.relDir})
+	}
+	return roots
+}
+
+// ExcludedScannerNames returns scanners to skip when this scanner detects.
+func (s *Scanner) ExcludedScannerNames() []string {
+	return []string{}
+}
+
+// Options builds the option tree of the projects, their editor versions, build methods and platforms.
+func (s *Scanner) Options() (models.OptionNode, models.Warnings, models.Icons, error) {
+	projectIcons := map[string]models.Icons{}
+	for _, proj := range s.projects {
+		if proj.iconPath == "" {
+			continue
+		}
+		icons, err := utility.CreateIconDescriptors([]string{filepath.Join(s.searchDir, proj.relDir, proj.iconPath)}, s.searchDir)
+		if err != nil {
+			log.TWarnf("Failed to create icon descriptor of %s: %s", proj.relDir, err)
+			continue
+		}
+		projectIcons[proj.relDir] = icons
+	}
+
+	return generateOptions(s.projects, projectIcons)
+}
+
+// Configs generates the pre-made bitrise.yml templates for each platform of the detected projects.
+func (s *Scanner) Configs(sshKeyActivation models.SSHKeyActivation) (models.BitriseConfigMap, error) {
+	return generateConfigs(s.projects, sshKeyActivation)
+}
+
+// DefaultOptions returns the option tree for the manual configuration flow.
+func (s *Scanner) DefaultOptions() models.OptionNode {
+	projectDirOption := models.NewOption(projectDirInputTitle, projectDirInputSummary, projectDirInputEnvKey, models.TypeUserInput)
+	editorVersionOption := models.NewOption(editorVersionInputTitle, editorVersionInputSummary, editorVersionInputEnvKey, models.TypeUserInput)
+	buildMethodOption := models.NewOption(buildMethodInputTitle, buildMethodInputSummary, buildMethodInputEnvKey, models.TypeUserInput)
+
+	projectDirOption.AddOption(models.UserInputOptionDefaultValue, editorVersionOption)
+	editorVersionOption.AddOption(models.UserInputOptionDefaultValue, buildMethodOption)
+	buildMethodOption.AddOption(models.UserInputOptionDefaultValue, newPlatformOption(platforms, nil, true))
+
+	return *projectDirOption
+}
+
+// DefaultConfigs generates the static bitrise.yml templates for the manual configuration flow.
+func (s *Scanner) DefaultConfigs() (models.BitriseConfigMap, error) {
+	configs := models.BitriseConfigMap{}
+	for _, platform := range platforms {
+		descriptor := configDescriptor{platform: platform, isDefault: true}
+		config, err := generateConfigBasedOn(descriptor, models.SSHKeyActivationConditional)
+		if err != nil {
+			return nil, err
+		}
+		configs[configName(descriptor)] = config
+	}
+	return configs, nil
+}
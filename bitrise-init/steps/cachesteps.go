@@ -0,0 +1,129 @@
+package steps
+
+import (
+	"fmt"
+
+	bitriseModels "github.com/bitrise-io/bitrise/v2/models"
+	envmanModels "github.com/bitrise-io/envman/v2/models"
+)
+
+func RestoreGradleCache() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CacheRestoreGradleID, CacheRestoreGradleVersion)
+	return stepListItem(stepIDComposite, "", "")
+}
+
+func SaveGradleCache() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CacheSaveGradleID, CacheSaveGradleVersion)
+	return stepListItem(stepIDComposite, "", "")
+}
+
+func RestoreCocoapodsCache() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CacheRestoreCocoapodsID, CacheRestoreCocoapodsVersion)
+	return stepListItem(stepIDComposite, "", "")
+}
+
+func SaveCocoapodsCache() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CacheSaveCocoapodsID, CacheSaveCocoapodsVersion)
+	return stepListItem(stepIDComposite, "", "")
+}
+
+func RestoreCarthageCache() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CacheRestoreCarthageID, CacheRestoreCarthageVersion)
+	return stepListItem(stepIDComposite, "", "")
+}
+
+func SaveCarthageCache() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CacheSaveCarthageID, CacheSaveCarthageVersion)
+	return stepListItem(stepIDComposite, "", "")
+}
+
+func RestoreNPMCache() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CacheRestoreNPMID, CacheRestoreNPMVersion)
+	return stepListItem(stepIDComposite, "", "")
+}
+
+func SaveNPMCache() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CacheSaveNPMID, CacheSaveNPMVersion)
+	return stepListItem(stepIDComposite, "", "")
+}
+
+func RestoreSPMCache() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CacheRestoreSPMID, CacheRestoreSPMVersion)
+	return stepListItem(stepIDComposite, "", "")
+}
+
+func SaveSPMCache() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CacheSaveSPMID, CacheSaveSPMVersion)
+	return stepListItem(stepIDComposite, "", "")
+}
+
+func RestoreDartCache() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CacheRestoreDartID, CacheRestoreDartVersion)
+	return stepListItem(stepIDComposite, "", "")
+}
+
+func SaveDartCache() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CacheSaveDartID, CacheSaveDartVersion)
+	return stepListItem(stepIDComposite, "", "")
+}
+
+func RestoreCache(key string) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CacheRestoreID, CacheRestoreVersion)
+	return stepListItem(stepIDComposite, "", "",
+		envmanModels.EnvironmentItemModel{"key": key},
+	)
+}
+
+func SaveCache(key, paths string) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CacheSaveID, CacheSaveVersion)
+	return stepListItem(stepIDComposite, "", "",
+		envmanModels.EnvironmentItemModel{"key": key},
+		envmanModels.EnvironmentItemModel{"paths": paths},
+	)
+}
+
+func ActivateBuildCacheForGradle() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(ActivateBuildCacheForGradleID, ActivateBuildCacheForGradleVersion)
+	return stepListItem(stepIDComposite, "", "")
+}
+
+func ActivateBuildCacheForBazel() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(ActivateBuildCacheForBazelID, ActivateBuildCacheForBazelVersion)
+	return stepListItem(stepIDComposite, "", "")
+}
+
+// RestoreNodePackageManagerCache restores the dependency cache of the JS package manager:
+// the npm cache step handles npm and yarn, the pnpm store and the Bun install cache are keyed on the lock file.
+func RestoreNodePackageManagerCache(packageManager, lockFile string) bitriseModels.StepListItemModel {
+	key, _, ok := nodePackageManagerCacheKeyAndPaths(packageManager, lockFile)
+	if !ok {
+		return RestoreNPMCache()
+	}
+	return RestoreCache(key)
+}
+
+// SaveNodePackageManagerCache saves the dependency cache of the JS package manager, see RestoreNodePackageManagerCache.
+func SaveNodePackageManagerCache(packageManager, lockFile string) bitriseModels.StepListItemModel {
+	key, paths, ok := nodePackageManagerCacheKeyAndPaths(packageManager, lockFile)
+	if !ok {
+		return SaveNPMCache()
+	}
+	return SaveCache(key, paths)
+}
+
+func nodePackageManagerCacheKeyAndPaths(packageManager, lockFile string) (string, string, bool) {
+	var paths string
+	switch packageManager {
+	case "pnpm":
+		paths = "~/.pnpm-store"
+	case "bun":
+		paths = "~/.bun/install/cache"
+	default:
+		return "", "", false
+	}
+
+	if lockFile == "" {
+		return packageManager, paths, true
+	}
+	return fmt.Sprintf(`%s-{{ checksum "**/%s" }}`, packageManager, lockFile), paths, true
+}
@@ -0,0 +1,388 @@
+package steps
+
+import (
+	"github.com/bitrise-io/bitrise-init/models"
+	bitriseModels "github.com/bitrise-io/bitrise/v2/models"
+	envmanModels "github.com/bitrise-io/envman/v2/models"
+	"github.com/bitrise-io/go-utils/pointers"
+	stepmanModels "github.com/bitrise-io/stepman/models"
+)
+
+// PrepareListParams describes the default prepare Step options.
+type PrepareListParams struct {
+	SSHKeyActivation models.SSHKeyActivation
+}
+
+func stepIDComposite(ID, version string) string {
+	if version != "" {
+		return ID + "@" + version
+	}
+	return ID
+}
+
+func stepListItem(stepIDComposite, title, runIf string, inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	step := stepmanModels.StepModel{}
+	if title != "" {
+		step.Title = pointers.NewStringPtr(title)
+	}
+	if runIf != "" {
+		step.RunIf = pointers.NewStringPtr(runIf)
+	}
+	if len(inputs) > 0 {
+		step.Inputs = inputs
+	}
+
+	return bitriseModels.StepListItemModel{
+		stepIDComposite: step,
+	}
+}
+
+func DefaultPrepareStepList(params PrepareListParams) []bitriseModels.StepListItemModel {
+	stepList := []bitriseModels.StepListItemModel{}
+
+	switch params.SSHKeyActivation {
+	case models.SSHKeyActivationNone:
+		{
+			// No SSH key setup needed
+		}
+	case models.SSHKeyActivationMandatory:
+		{
+			// This needs the `SSH_RSA_PRIVATE_KEY` env to be defined, which depends on the selected path in the website
+			// add-new-app flow.
+			stepList = append(stepList, ActivateSSHKeyStepListItem(""))
+		}
+	case models.SSHKeyActivationConditional:
+		{
+			// Add the SSH key step just in case, but only run it if the required env var is available.
+			runCondition := `{{getenv "SSH_RSA_PRIVATE_KEY" | ne ""}}`
+			stepList = append(stepList, ActivateSSHKeyStepListItem(runCondition))
+		}
+	}
+
+	stepList = append(stepList, GitCloneStepListItem())
+
+	return stepList
+}
+
+func DefaultDeployStepList() []bitriseModels.StepListItemModel {
+	stepList := []bitriseModels.StepListItemModel{}
+
+	stepList = append(stepList, DeployToBitriseIoStepListItem())
+
+	return stepList
+}
+
+func ActivateSSHKeyStepListItem(runIfCondition string) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(ActivateSSHKeyID, ActivateSSHKeyVersion)
+	return stepListItem(stepIDComposite, "", runIfCondition)
+}
+
+func AndroidLintStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(AndroidLintID, AndroidLintVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func AndroidUnitTestStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(AndroidUnitTestID, AndroidUnitTestVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func AndroidBuildStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(AndroidBuildID, AndroidBuildVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func GradleRunnerStepListItem(buildDir, gradleTask string, additionalInputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(GradleRunnerID, GradleRunnerVersion)
+	return stepListItem(stepIDComposite, "", "",
+		append([]envmanModels.EnvironmentItemModel{
+			{"build_root_directory": buildDir},
+			{"gradle_task": gradleTask}},
+			additionalInputs...)...)
+}
+
+func GradleUnitTestStepListItem(projectRootDir string, additionalInputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(GradleUnitTestID, GradleUnitTestVersion)
+	return stepListItem(stepIDComposite, "", "",
+		append([]envmanModels.EnvironmentItemModel{
+			{"project_root_dir": projectRootDir},
+		}, additionalInputs...)...)
+}
+
+func GitCloneStepListItem() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(GitCloneID, GitCloneVersion)
+	return stepListItem(stepIDComposite, "", "")
+}
+
+func CertificateAndProfileInstallerStepListItem() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CertificateAndProfileInstallerID, CertificateAndProfileInstallerVersion)
+	return stepListItem(stepIDComposite, "", "")
+}
+
+func ChangeAndroidVersionCodeAndVersionNameStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(ChangeAndroidVersionCodeAndVersionNameID, ChangeAndroidVersionCodeAndVersionNameVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func DeployToBitriseIoStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(DeployToBitriseIoID, DeployToBitriseIoVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func SignAPKStepListItem() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(SignAPKID, SignAPKVersion)
+	return stepListItem(stepIDComposite, "", `{{getenv "BITRISEIO_ANDROID_KEYSTORE_URL" | ne ""}}`)
+}
+
+func InstallMissingAndroidToolsStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(InstallMissingAndroidToolsID, InstallMissingAndroidToolsVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+// SetJavaVersionStepListItem activates the JDK of the major version, like 17 or 21.
+func SetJavaVersionStepListItem(version string) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(SetJavaVersionID, SetJavaVersionVersion)
+	return stepListItem(stepIDComposite, "", "", envmanModels.EnvironmentItemModel{"set_java_version": version})
+}
+
+func FastlaneStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(FastlaneID, FastlaneVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func CocoapodsInstallStepListItem() bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CocoapodsInstallID, CocoapodsInstallVersion)
+	return stepListItem(stepIDComposite, "", "", envmanModels.EnvironmentItemModel{
+		"is_cache_disabled": "true", // Disable legacy caching when used in workflows with KV caching
+	})
+}
+
+func CarthageStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CarthageID, CarthageVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func XcodeArchiveStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(XcodeArchiveID, XcodeArchiveVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func XcodeBuildForTestStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(XcodeBuildForTestID, XcodeBuildForTestVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func XcodeTestStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(XcodeTestID, XcodeTestVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func XcodeArchiveMacStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(XcodeArchiveMacID, XcodeArchiveMacVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func ExportXCArchiveStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(ExportXCArchiveID, ExportXCArchiveVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func XcodeTestMacStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(XcodeTestMacID, XcodeTestMacVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func CordovaArchiveStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CordovaArchiveID, CordovaArchiveVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func IonicArchiveStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(IonicArchiveID, IonicArchiveVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func GenerateCordovaBuildConfigStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(GenerateCordovaBuildConfigID, GenerateCordovaBuildConfigVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func JasmineTestRunnerStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(JasmineTestRunnerID, JasmineTestRunnerVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func KarmaJasmineTestRunnerStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(KarmaJasmineTestRunnerID, KarmaJasmineTestRunnerVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func ScriptStepListItem(title string, content string, additionalInputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(ScriptID, ScriptVersion)
+	return stepListItem(stepIDComposite, title, "", append([]envmanModels.EnvironmentItemModel{
+		{"content": content},
+	}, additionalInputs...)...)
+}
+
+// WithServiceContainers attaches the service containers to the step, the containers run while the step runs.
+func WithServiceContainers(item bitriseModels.StepListItemModel, serviceContainerRefs []stepmanModels.ContainerReference) bitriseModels.StepListItemModel {
+	if len(serviceContainerRefs) == 0 {
+		return item
+	}
+	for id, value := range item {
+		if step, ok := value.(stepmanModels.StepModel); ok {
+			step.ServiceContainers = serviceContainerRefs
+			item[id] = step
+		}
+	}
+	return item
+}
+
+func NpmStepListItem(command, workdir string) bitriseModels.StepListItemModel {
+	var inputs []envmanModels.EnvironmentItemModel
+	if workdir != "" {
+		inputs = append(inputs, envmanModels.EnvironmentItemModel{"workdir": workdir})
+	}
+	if command != "" {
+		inputs = append(inputs, envmanModels.EnvironmentItemModel{"command": command})
+	}
+
+	stepIDComposite := stepIDComposite(NpmID, NpmVersion)
+	return stepListItem(stepIDComposite, "npm "+command, "", inputs...)
+}
+
+// NodePackageManagerStepListItem runs the JS package manager command, with the npm and yarn steps, or in a Script step for pnpm and Bun.
+func NodePackageManagerStepListItem(packageManager, command, workdir string) bitriseModels.StepListItemModel {
+	switch packageManager {
+	case "yarn":
+		return YarnStepListItem(command, workdir)
+	case "pnpm", "bun":
+		var inputs []envmanModels.EnvironmentItemModel
+		if workdir != "" {
+			inputs = append(inputs, envmanModels.EnvironmentItemModel{"working_dir": workdir})
+		}
+		content := "#!/usr/bin/env bash\nset -euxo pipefail\n\n" + packageManager + " " + command + "\n"
+		return ScriptStepListItem(packageManager+" "+command, content, inputs...)
+	default:
+		return NpmStepListItem(command, workdir)
+	}
+}
+
+// SetupNodePackageManagerStepListItem installs the JS package manager if the stacks do not provide it:
+// pnpm and the yarn versions pinned by the packageManager field of package.json are activated by Corepack,
+// Bun is installed by its install script. Returns false if the preinstalled package manager is used.
+func SetupNodePackageManagerStepListItem(packageManager, version, workdir string) (bitriseModels.StepListItemModel, bool) {
+	var inputs []envmanModels.EnvironmentItemModel
+	if workdir != "" {
+		inputs = append(inputs, envmanModels.EnvironmentItemModel{"working_dir": workdir})
+	}
+
+	content := "#!/usr/bin/env bash\nset -euxo pipefail\n\n"
+	switch {
+	case packageManager == "pnpm":
+		// Corepack runs the pnpm version of the packageManager field, or its default version;
+		// the packages are stored in a dir outside of the pnpm installation, so that it can be cached
+		content += `corepack enable
+pnpm config set store-dir ~/.pnpm-store
+`
+	case packageManager == "yarn" && version != "":
+		content += "corepack enable\n"
+	case packageManager == "bun" && version != "":
+		content += `curl -fsSL https://bun.sh/install | bash -s "bun-v` + version + `"
+envman add --key PATH --value "$HOME/.bun/bin:$PATH"
+`
+	case packageManager == "bun":
+		content += `if ! command -v bun >/dev/null; then
+  curl -fsSL https://bun.sh/install | bash
+  envman add --key PATH --value "$HOME/.bun/bin:$PATH"
+fi
+`
+	default:
+		return nil, false
+	}
+
+	return ScriptStepListItem("Set up "+packageManager, content, inputs...), true
+}
+
+func RunEASBuildStepListItem(workdir, platform string) bitriseModels.StepListItemModel {
+	var inputs []envmanModels.EnvironmentItemModel
+	if platform != "" {
+		inputs = append(inputs, envmanModels.EnvironmentItemModel{"platform": platform})
+	}
+	if workdir != "" {
+		inputs = append(inputs, envmanModels.EnvironmentItemModel{"work_dir": workdir})
+	}
+	stepIDComposite := stepIDComposite(RunEASBuildID, RunEASBuildVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func YarnStepListItem(command, workdir string) bitriseModels.StepListItemModel {
+	var inputs []envmanModels.EnvironmentItemModel
+	if workdir != "" {
+		inputs = append(inputs, envmanModels.EnvironmentItemModel{"workdir": workdir})
+	}
+	if command != "" {
+		inputs = append(inputs, envmanModels.EnvironmentItemModel{"command": command})
+	}
+
+	stepIDComposite := stepIDComposite(YarnID, YarnVersion)
+	return stepListItem(stepIDComposite, "yarn "+command, "", inputs...)
+}
+
+func FlutterInstallStepListItem(version string) bitriseModels.StepListItemModel {
+	const versionInputKey = "version"
+
+	var inputs []envmanModels.EnvironmentItemModel
+
+	if version != "" {
+		inputs = append(inputs, envmanModels.EnvironmentItemModel{versionInputKey: version})
+	}
+
+	stepIDComposite := stepIDComposite(FlutterInstallID, FlutterInstallVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func FlutterTestStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(FlutterTestID, FlutterTestVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func FlutterAnalyzeStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(FlutterAnalyzeID, FlutterAnalyzeVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func FlutterBuildStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(FlutterBuildID, FlutterBuildVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func XcodeTestShardCalculationStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(XcodeTestShardCalculationID, XcodeTestShardCalculationVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func PullIntermediateFilesStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(PullIntermediateFilesID, PullIntermediateFilesVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func XcodeTestWithoutBuildingStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(XcodeTestWithoutBuildingID, XcodeTestWithoutBuildingVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func AvdManagerStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(AvdManagerID, AvdManagerVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func WaitForAndroidEmulatorStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(WaitForAndroidEmulatorID, WaitForAndroidEmulatorVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
+
+func CustomTestResultsExportStepListItem(inputs ...envmanModels.EnvironmentItemModel) bitriseModels.StepListItemModel {
+	stepIDComposite := stepIDComposite(CustomTestResultsExportID, CustomTestResultsExportVersion)
+	return stepListItem(stepIDComposite, "", "", inputs...)
+}
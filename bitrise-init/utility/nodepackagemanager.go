@@ -0,0 +1,78 @@
+package utility
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// JS package managers.
+const (
+	NodePackageManagerNpm  = "npm"
+	NodePackageManagerYarn = "yarn"
+	NodePackageManagerPnpm = "pnpm"
+	NodePackageManagerBun  = "bun"
+)
+
+// NodePackageManagerLockFiles are the lock files of the JS package managers, in the order of the lock file based detection:
+// yarn wins if there are multiple lock files, as the React Native scanner detected it before the other package managers.
+var NodePackageManagerLockFiles = []struct {
+	PackageManager string
+	LockFile       string
+}{
+	{NodePackageManagerYarn, "yarn.lock"},
+	{NodePackageManagerNpm, "package-lock.json"},
+	{NodePackageManagerPnpm, "pnpm-lock.yaml"},
+	{NodePackageManagerBun, "bun.lock"},
+	{NodePackageManagerBun, "bun.lockb"},
+}
+
+// NodePackageManager is the package manager of a JS project.
+type NodePackageManager struct {
+	Name string
+	// Version is set if the packageManager field of package.json pins the package manager
+	Version string
+	// LockFile is the name of the lock file next to package.json, empty if there is no lock file
+	LockFile string
+}
+
+// DetectNodePackageManager returns the package manager of the JS project in dir.
+// The packageManager field of package.json takes precedence over the lock files, as Corepack runs the pinned package manager.
+// Returns an empty Name if neither of them is found.
+func DetectNodePackageManager(dir string) NodePackageManager {
+	var packageManager NodePackageManager
+
+	packageJSONPth := filepath.Join(dir, "package.json")
+	if FileExists(packageJSONPth) {
+		if packages, err := ParsePackagesJSON(packageJSONPth); err == nil {
+			packageManager.Name, packageManager.Version = parsePackageManagerField(packages.PackageManager)
+		}
+	}
+
+	for _, lockFile := range NodePackageManagerLockFiles {
+		if packageManager.Name != "" && lockFile.PackageManager != packageManager.Name {
+			continue
+		}
+		if FileExists(filepath.Join(dir, lockFile.LockFile)) {
+			packageManager.Name = lockFile.PackageManager
+			packageManager.LockFile = lockFile.LockFile
+			break
+		}
+	}
+
+	return packageManager
+}
+
+// parsePackageManagerField splits the packageManager field (like pnpm@9.1.0+sha512.abc) to the package manager name and version.
+func parsePackageManagerField(field string) (string, string) {
+	name, version, found := strings.Cut(field, "@")
+	if !found {
+		return "", ""
+	}
+	version, _, _ = strings.Cut(version, "+")
+
+	switch name {
+	case NodePackageManagerNpm, NodePackageManagerYarn, NodePackageManagerPnpm, NodePackageManagerBun:
+		return name, version
+	}
+	return "", ""
+}
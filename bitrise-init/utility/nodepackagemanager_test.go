@@ -0,0 +1,44 @@
+package utility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectNodePackageManager(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  NodePackageManager
+	}{
+		{
+			name:  "yarn wins over npm",
+			files: map[string]string{"package.json": `{}`, "yarn.lock": "", "package-lock.json": ""},
+			want:  NodePackageManager{Name: NodePackageManagerYarn, LockFile: "yarn.lock"},
+		},
+		{
+			name:  "packageManager field pins the version and selects the lock file",
+			files: map[string]string{"package.json": `{"packageManager": "pnpm@9.1.0+sha512.abc"}`, "yarn.lock": "", "pnpm-lock.yaml": ""},
+			want:  NodePackageManager{Name: NodePackageManagerPnpm, Version: "9.1.0", LockFile: "pnpm-lock.yaml"},
+		},
+		{
+			name:  "text lock file of Bun",
+			files: map[string]string{"package.json": `{}`, "bun.lock": ""},
+			want:  NodePackageManager{Name: NodePackageManagerBun, LockFile: "bun.lock"},
+		},
+		{
+			name:  "unknown package manager in the packageManager field",
+			files: map[string]string{"package.json": `{"packageManager": "deno@2.0.0"}`},
+			want:  NodePackageManager{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			require.Equal(t, tt.want, DetectNodePackageManager(dir))
+		})
+	}
+}
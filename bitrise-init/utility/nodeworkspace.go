@@ -0,0 +1,301 @@
+package utility
+
+import (
+	"encoding/json"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/bitrise-io/bitrise-init/metrics"
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+// Task runners of JS monorepos, running the tasks of the affected workspace packages.
+const (
+	NodeTaskRunnerNx    = "nx"
+	NodeTaskRunnerTurbo = "turbo"
+)
+
+const (
+	pnpmWorkspaceFile = "pnpm-workspace.yaml"
+	nxConfigFile      = "nx.json"
+	turboConfigFile   = "turbo.json"
+)
+
+// NodeWorkspace is the root of a JS monorepo (npm, yarn or pnpm workspaces, Nx or Turborepo),
+// dependencies are installed once in its root dir for all of its packages.
+type NodeWorkspace struct {
+	RootDir    string   // dir of the root package.json
+	Packages   []string // package.json paths of the workspace packages
+	TaskRunner string   // NodeTaskRunnerNx, NodeTaskRunnerTurbo or empty
+	// TaskRunnerTasks are the task names of the Turborepo pipeline, like lint for the lint and web#lint tasks
+	TaskRunnerTasks []string
+	Sources         []string // the files defining the workspace
+}
+
+// Contains reports whether the package.json is the root or one of the packages of the workspace.
+func (w NodeWorkspace) Contains(packageJSONPth string) bool {
+	if filepath.Dir(packageJSONPth) == w.RootDir {
+		return true
+	}
+	for _, pkg := range w.Packages {
+		if pkg == packageJSONPth {
+			return true
+		}
+	}
+	return false
+}
+
+// FindNodeWorkspace returns the workspace containing the package.json, if any.
+func FindNodeWorkspace(workspaces []NodeWorkspace, packageJSONPth string) *NodeWorkspace {
+	for i, workspace := range workspaces {
+		if workspace.Contains(packageJSONPth) {
+			return &workspaces[i]
+		}
+	}
+	return nil
+}
+
+type packageJSONWorkspaces struct {
+	Workspaces json.RawMessage `json:"workspaces"`
+}
+
+type pnpmWorkspace struct {
+	Packages []string `yaml:"packages"`
+}
+
+// turboConfig is the task pipeline of turbo.json, the tasks field of Turborepo 2 was called pipeline before.
+type turboConfig struct {
+	Tasks    map[string]json.RawMessage `json:"tasks"`
+	Pipeline map[string]json.RawMessage `json:"pipeline"`
+}
+
+// DetectNodeWorkspaces returns the JS workspaces among the package.json files (as returned by CollectPackageJSONFiles).
+// A workspace root is a package.json with a workspaces field, or next to a pnpm-workspace.yaml, nx.json or turbo.json file.
+// The packages are matched by the workspace globs; Nx and Turborepo roots without globs have no packages,
+// like the Nx repositories defining their projects in project.json files.
+// Workspaces nested in another workspace are not supported, they are part of the outer workspace.
+func DetectNodeWorkspaces(packageJSONPths []string) ([]NodeWorkspace, error) {
+	var workspaces []NodeWorkspace
+	for _, packageJSONPth := range packageJSONPths {
+		if FindNodeWorkspace(workspaces, packageJSONPth) != nil {
+			continue
+		}
+
+		rootDir := filepath.Dir(packageJSONPth)
+		var (
+			patterns []string
+			sources  []string
+		)
+
+		packagePatterns, err := parseWorkspacesField(packageJSONPth)
+		if err != nil {
+			return nil, err
+		}
+		if len(packagePatterns) > 0 {
+			patterns = append(patterns, packagePatterns...)
+			sources = append(sources, packageJSONPth)
+		}
+
+		pnpmWorkspacePth := filepath.Join(rootDir, pnpmWorkspaceFile)
+		if FileExists(pnpmWorkspacePth) {
+			pnpmPatterns, err := parsePnpmWorkspace(pnpmWorkspacePth)
+			if err != nil {
+				return nil, err
+			}
+			patterns = append(patterns, pnpmPatterns...)
+			sources = append(sources, pnpmWorkspacePth)
+		}
+
+		var (
+			taskRunner      string
+			taskRunnerTasks []string
+		)
+		for _, runner := range []struct{ name, configFile string }{
+			{NodeTaskRunnerNx, nxConfigFile},
+			{NodeTaskRunnerTurbo, turboConfigFile},
+		} {
+			if configPth := filepath.Join(rootDir, runner.configFile); FileExists(configPth) {
+				taskRunner = runner.name
+				sources = append(sources, configPth)
+				if runner.name == NodeTaskRunnerTurbo {
+					taskRunnerTasks, err = parseTurboTasks(configPth)
+					if err != nil {
+						log.TWarnf("Failed to parse %s: %s", configPth, err)
+					}
+				}
+				break
+			}
+		}
+
+		if len(sources) == 0 {
+			continue
+		}
+
+		workspace := NodeWorkspace{RootDir: rootDir, TaskRunner: taskRunner, TaskRunnerTasks: taskRunnerTasks, Sources: sources}
+		for _, pth := range packageJSONPths {
+			relDir, err := filepath.Rel(rootDir, filepath.Dir(pth))
+			if err != nil || relDir == "." || strings.HasPrefix(relDir, "..") {
+				continue
+			}
+			if matchWorkspacePatterns(patterns, filepath.ToSlash(relDir)) {
+				workspace.Packages = append(workspace.Packages, pth)
+			}
+		}
+
+		workspaces = append(workspaces, workspace)
+	}
+	return workspaces, nil
+}
+
+// parseWorkspacesField returns the package globs of the workspaces field,
+// either a list of globs, or an object with a packages list (yarn's nohoist format).
+func parseWorkspacesField(packageJSONPth string) ([]string, error) {
+	content, err := metrics.ReadStringFromFile(packageJSONPth)
+	if err != nil {
+		return nil, err
+	}
+
+	var packageJSON packageJSONWorkspaces
+	if err := json.Unmarshal([]byte(content), &packageJSON); err != nil || len(packageJSON.Workspaces) == 0 {
+		return nil, nil
+	}
+
+	var patterns []string
+	if err := json.Unmarshal(packageJSON.Workspaces, &patterns); err == nil {
+		return patterns, nil
+	}
+	var object struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(packageJSON.Workspaces, &object); err == nil {
+		return object.Packages, nil
+	}
+	return nil, nil
+}
+
+func parsePnpmWorkspace(pth string) ([]string, error) {
+	content, err := fileutil.ReadBytesFromFile(pth)
+	if err != nil {
+		return nil, err
+	}
+	metrics.FileRead()
+
+	var workspace pnpmWorkspace
+	if err := yaml.Unmarshal(content, &workspace); err != nil {
+		return nil, err
+	}
+	return workspace.Packages, nil
+}
+
+// parseTurboTasks returns the task names of the turbo.json pipeline, without the package prefix of the package tasks (web#lint).
+func parseTurboTasks(pth string) ([]string, error) {
+	content, err := metrics.ReadStringFromFile(pth)
+	if err != nil {
+		return nil, err
+	}
+
+	var config turboConfig
+	// turbo.json allows comments
+	if err := json.Unmarshal([]byte(stripJSONComments(content)), &config); err != nil {
+		return nil, err
+	}
+
+	pipeline := config.Tasks
+	if pipeline == nil {
+		pipeline = config.Pipeline
+	}
+	var tasks []string
+	for key := range pipeline {
+		task := key
+		if i := strings.LastIndex(key, "#"); i != -1 {
+			task = key[i+1:]
+		}
+		if !slices.Contains(tasks, task) {
+			tasks = append(tasks, task)
+		}
+	}
+	sort.Strings(tasks)
+	return tasks, nil
+}
+
+// stripJSONComments removes the line and block comments outside of the JSON strings.
+func stripJSONComments(content string) string {
+	var stripped strings.Builder
+	inString := false
+	for i := 0; i < len(content); i++ {
+		switch {
+		case inString:
+			stripped.WriteByte(content[i])
+			if content[i] == '\\' && i+1 < len(content) {
+				i++
+				stripped.WriteByte(content[i])
+			} else if content[i] == '"' {
+				inString = false
+			}
+		case content[i] == '"':
+			inString = true
+			stripped.WriteByte(content[i])
+		case strings.HasPrefix(content[i:], "//"):
+			end := strings.IndexByte(content[i:], '\n')
+			if end == -1 {
+				return stripped.String()
+			}
+			i += end - 1
+		case strings.HasPrefix(content[i:], "/*"):
+			end := strings.Index(content[i+2:], "*/")
+			if end == -1 {
+				return stripped.String()
+			}
+			i += end + 3
+		default:
+			stripped.WriteByte(content[i])
+		}
+	}
+	return stripped.String()
+}
+
+// matchWorkspacePatterns reports whether the package dir (relative to the workspace root) is matched by the globs,
+// and not excluded by a negated (!) glob.
+func matchWorkspacePatterns(patterns []string, relDir string) bool {
+	matched := false
+	for _, pattern := range patterns {
+		negated := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(pattern, "!"), "./"), "/")
+		if !matchGlobSegments(strings.Split(pattern, "/"), strings.Split(relDir, "/")) {
+			continue
+		}
+		if negated {
+			return false
+		}
+		matched = true
+	}
+	return matched
+}
+
+// matchGlobSegments matches the path segments to the glob segments, where ** matches any number of segments.
+func matchGlobSegments(patternSegments, pathSegments []string) bool {
+	if len(patternSegments) == 0 {
+		return len(pathSegments) == 0
+	}
+	if patternSegments[0] == "**" {
+		for i := 0; i <= len(pathSegments); i++ {
+			if matchGlobSegments(patternSegments[1:], pathSegments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(pathSegments) == 0 {
+		return false
+	}
+	if ok, err := path.Match(patternSegments[0], pathSegments[0]); err != nil || !ok {
+		return false
+	}
+	return matchGlobSegments(patternSegments[1:], pathSegments[1:])
+}
@@ -0,0 +1,137 @@
+package utility
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for pth, content := range files {
+		pth = filepath.Join(dir, pth)
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+		require.NoError(t, os.WriteFile(pth, []byte(content), 0644))
+	}
+}
+
+func TestDetectNodeWorkspaces(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []NodeWorkspace
+	}{
+		{
+			name: "npm workspaces with a negated glob",
+			files: map[string]string{
+				"package.json":                 `{"workspaces": ["packages/*", "!packages/legacy"]}`,
+				"packages/a/package.json":      `{}`,
+				"packages/legacy/package.json": `{}`,
+				"tools/package.json":           `{}`,
+			},
+			want: []NodeWorkspace{{
+				RootDir:  ".",
+				Packages: []string{"packages/a/package.json"},
+				Sources:  []string{"package.json"},
+			}},
+		},
+		{
+			name: "pnpm workspace with Turborepo tasks",
+			files: map[string]string{
+				"package.json":        `{}`,
+				"pnpm-workspace.yaml": "packages:\n  - apps/**\n",
+				"turbo.json": `{
+  // the pipeline of the monorepo
+  "$schema": "https://turbo.build/schema.json",
+  "tasks": {"build": {}, "web#lint": {}, "//#test": {}, "test": {"dependsOn": ["build"]}}
+}`,
+				"apps/web/package.json": `{}`,
+			},
+			want: []NodeWorkspace{{
+				RootDir:         ".",
+				Packages:        []string{"apps/web/package.json"},
+				TaskRunner:      NodeTaskRunnerTurbo,
+				TaskRunnerTasks: []string{"build", "lint", "test"},
+				Sources:         []string{"pnpm-workspace.yaml", "turbo.json"},
+			}},
+		},
+		{
+			name: "Nx root without globs claims no packages",
+			files: map[string]string{
+				"package.json":     `{}`,
+				"nx.json":          `{}`,
+				"app/package.json": `{}`,
+			},
+			want: []NodeWorkspace{{
+				RootDir:    ".",
+				TaskRunner: NodeTaskRunnerNx,
+				Sources:    []string{"nx.json"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			writeFiles(t, ".", tt.files)
+
+			var packageJSONPths []string
+			for pth := range tt.files {
+				if filepath.Base(pth) == "package.json" {
+					packageJSONPths = append(packageJSONPths, pth)
+				}
+			}
+			// the root package.json first, as listed by CollectPackageJSONFiles
+			slices.SortFunc(packageJSONPths, func(a, b string) int {
+				return strings.Count(a, "/") - strings.Count(b, "/")
+			})
+
+			got, err := DetectNodeWorkspaces(packageJSONPths)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseTurboTasks(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{name: "Turborepo 1 pipeline", content: `{"pipeline": {"lint": {}, "test": {}}}`, want: []string{"lint", "test"}},
+		{name: "block comment", content: "{/* \"lint\": {} */ \"tasks\": {\"build\": {}}}", want: []string{"build"}},
+		{name: "no tasks", content: `{"globalEnv": ["CI"]}`, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, map[string]string{"turbo.json": tt.content})
+
+			got, err := parseTurboTasks(filepath.Join(dir, "turbo.json"))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatchWorkspacePatterns(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		relDir   string
+		want     bool
+	}{
+		{patterns: []string{"packages/*"}, relDir: "packages/a", want: true},
+		{patterns: []string{"packages/*"}, relDir: "packages/a/nested", want: false},
+		{patterns: []string{"./apps/**"}, relDir: "apps/web/nested", want: true},
+		{patterns: []string{"packages/*", "!packages/b"}, relDir: "packages/b", want: false},
+		{patterns: nil, relDir: "packages/a", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.relDir, func(t *testing.T) {
+			require.Equal(t, tt.want, matchWorkspacePatterns(tt.patterns, tt.relDir))
+		})
+	}
+}
@@ -0,0 +1,490 @@
+package utility
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/bitrise-io/bitrise-init/metrics"
+	bitriseModels "github.com/bitrise-io/bitrise/v2/models"
+	envmanModels "github.com/bitrise-io/envman/v2/models"
+	stepmanModels "github.com/bitrise-io/stepman/models"
+)
+
+// Databases run in service containers by the test workflows.
+const (
+	ServiceDatabasePostgres = "postgres"
+	ServiceDatabaseMySQL    = "mysql"
+	ServiceDatabaseRedis    = "redis"
+	ServiceDatabaseMongoDB  = "mongodb"
+)
+
+// DetectedDatabase is a database used by a project through the driver package, it runs in a service container.
+type DetectedDatabase struct {
+	Database      string // one of the ServiceDatabase constants
+	DriverPackage string
+	DriverSource  string // the file declaring the driver package
+	// AptPackages are the system packages building the driver, like the client library headers of source distributed drivers
+	AptPackages []string
+}
+
+// DatabaseNames returns the databases of the detected drivers.
+func DatabaseNames(databases []DetectedDatabase) []string {
+	var names []string
+	for _, db := range databases {
+		names = append(names, db.Database)
+	}
+	return names
+}
+
+// ServiceContainer is the service container of a database, and the connection URL env var of the app.
+type ServiceContainer struct {
+	Name         string // the container ID, one of the ServiceDatabase constants
+	Image        string
+	Ports        []string
+	HealthCheck  string
+	IsRelational bool
+
+	// credentials of the database, set in the container envs and in the connection URL
+	username string
+	password string
+	database string
+	// passwordEnvKey is the app env var holding the password, set if the app creates its database
+	passwordEnvKey string
+
+	URLEnvKey string // app env var of the connection URL, like DATABASE_URL
+	urlScheme string
+}
+
+// URL returns the connection URL of the service container. Script steps run on the host machine,
+// and connect to the service containers via the mapped ports.
+func (c ServiceContainer) URL() string {
+	port := strings.SplitN(c.Ports[0], ":", 2)[0]
+
+	u := url.URL{Scheme: c.urlScheme, Host: c.Host() + ":" + port, Path: "/" + c.database}
+	if c.username != "" {
+		u.User = url.UserPassword(c.username, c.password)
+	}
+	return u.String()
+}
+
+// JDBCURL returns the JDBC URL of a relational database service container, JVM apps take the credentials separately.
+func (c ServiceContainer) JDBCURL() string {
+	scheme := "postgresql"
+	if c.Name == ServiceDatabaseMySQL {
+		scheme = "mysql"
+	}
+	port := strings.SplitN(c.Ports[0], ":", 2)[0]
+	return "jdbc:" + scheme + "://" + c.Host() + ":" + port + "/" + c.database
+}
+
+// Host returns the host of the service container for the Script steps, which run on the host machine.
+func (c ServiceContainer) Host() string {
+	// MySQL treats "localhost" as a Unix socket path, 127.0.0.1 forces TCP
+	if c.Name == ServiceDatabaseMySQL {
+		return "127.0.0.1"
+	}
+	return "localhost"
+}
+
+// WithPasswordFromEnv returns the container taking the password of the database's default user from the app env var.
+// The container creates no database and user, it suits the apps creating their database, like Rails apps with db:create.
+func (c ServiceContainer) WithPasswordFromEnv(envKey string) ServiceContainer {
+	if c.IsRelational {
+		c.passwordEnvKey = envKey
+	}
+	return c
+}
+
+// Credentials returns the username and password of the database.
+func (c ServiceContainer) Credentials() (string, string) {
+	return c.username, c.password
+}
+
+// Envs returns the container envs, creating the database and its user as the connection URL expects.
+func (c ServiceContainer) Envs() []envmanModels.EnvironmentItemModel {
+	if c.passwordEnvKey != "" {
+		passwordKey := "POSTGRES_PASSWORD"
+		if c.Name == ServiceDatabaseMySQL {
+			passwordKey = "MYSQL_ROOT_PASSWORD"
+		}
+		return []envmanModels.EnvironmentItemModel{{passwordKey: "$" + c.passwordEnvKey}}
+	}
+
+	switch c.Name {
+	case ServiceDatabasePostgres:
+		return []envmanModels.EnvironmentItemModel{{"POSTGRES_USER": c.username}, {"POSTGRES_PASSWORD": c.password}, {"POSTGRES_DB": c.database}}
+	case ServiceDatabaseMySQL:
+		if c.username == "root" {
+			return []envmanModels.EnvironmentItemModel{{"MYSQL_ROOT_PASSWORD": c.password}, {"MYSQL_DATABASE": c.database}}
+		}
+		return []envmanModels.EnvironmentItemModel{{"MYSQL_ROOT_PASSWORD": c.password}, {"MYSQL_USER": c.username}, {"MYSQL_PASSWORD": c.password}, {"MYSQL_DATABASE": c.database}}
+	}
+	return nil
+}
+
+var defaultServiceContainers = map[string]ServiceContainer{
+	ServiceDatabasePostgres: {
+		Name:         ServiceDatabasePostgres,
+		Image:        "postgres:18",
+		Ports:        []string{"5432:5432"},
+		HealthCheck:  healthCheck(`pg_isready`),
+		IsRelational: true,
+		username:     "postgres",
+		password:     "postgres",
+		database:     "app",
+		URLEnvKey:    "DATABASE_URL",
+		urlScheme:    "postgres",
+	},
+	ServiceDatabaseMySQL: {
+		Name:         ServiceDatabaseMySQL,
+		Image:        "mysql:9",
+		Ports:        []string{"3306:3306"},
+		HealthCheck:  healthCheck(`mysqladmin ping -h 127.0.0.1 -u root --password=$$MYSQL_ROOT_PASSWORD`),
+		IsRelational: true,
+		username:     "root",
+		password:     "mysql",
+		database:     "app",
+		URLEnvKey:    "DATABASE_URL",
+		urlScheme:    "mysql",
+	},
+	ServiceDatabaseRedis: {
+		Name:        ServiceDatabaseRedis,
+		Image:       "redis:8",
+		Ports:       []string{"6379:6379"},
+		HealthCheck: healthCheck(`redis-cli ping`),
+		database:    "0",
+		URLEnvKey:   "REDIS_URL",
+		urlScheme:   "redis",
+	},
+	ServiceDatabaseMongoDB: {
+		Name:        ServiceDatabaseMongoDB,
+		Image:       "mongo:8",
+		Ports:       []string{"27017:27017"},
+		HealthCheck: healthCheck(`mongosh --eval 'db.runCommand({ping:1})'`),
+		database:    "test",
+		URLEnvKey:   "MONGODB_URI",
+		urlScheme:   "mongodb",
+	},
+}
+
+// healthCheck returns the container options running the health check command, the service is ready when the command succeeds.
+func healthCheck(command string) string {
+	return fmt.Sprintf(`--health-cmd "%s" --health-interval 10s --health-timeout 5s --health-retries 5`, command)
+}
+
+// imageHealthCheck returns the health check of the container's image. The compatible images don't all ship the client of the official image:
+// MariaDB images have healthcheck.sh instead of mysqladmin, Valkey images valkey-cli and MongoDB images older than 5 the legacy mongo shell.
+func imageHealthCheck(container ServiceContainer) string {
+	switch imageName(container.Image) {
+	case "mariadb":
+		return healthCheck(`healthcheck.sh --connect --innodb_initialized`)
+	case "valkey":
+		return healthCheck(`valkey-cli ping`)
+	case "mongo":
+		if major, err := strconv.Atoi(strings.SplitN(imageTag(container.Image), ".", 2)[0]); err == nil && major < 5 {
+			return healthCheck(`mongo --eval 'db.runCommand({ping:1})'`)
+		}
+	}
+	return container.HealthCheck
+}
+
+// DefaultServiceContainer returns the service container of the database with the official image and the default credentials.
+func DefaultServiceContainer(database string) (ServiceContainer, bool) {
+	container, ok := defaultServiceContainers[database]
+	return container, ok
+}
+
+// serviceImageNames map the Docker image names of docker-compose services to the databases.
+var serviceImageNames = map[string]string{
+	"postgres": ServiceDatabasePostgres,
+	"postgis":  ServiceDatabasePostgres,
+	"mysql":    ServiceDatabaseMySQL,
+	"mariadb":  ServiceDatabaseMySQL,
+	"redis":    ServiceDatabaseRedis,
+	"valkey":   ServiceDatabaseRedis,
+	"mongo":    ServiceDatabaseMongoDB,
+}
+
+// urlSchemes map the connection URL schemes of the .env example files to the databases.
+var urlSchemes = map[string]string{
+	"postgres":    ServiceDatabasePostgres,
+	"postgresql":  ServiceDatabasePostgres,
+	"mysql":       ServiceDatabaseMySQL,
+	"mysql2":      ServiceDatabaseMySQL,
+	"mariadb":     ServiceDatabaseMySQL,
+	"redis":       ServiceDatabaseRedis,
+	"rediss":      ServiceDatabaseRedis,
+	"mongodb":     ServiceDatabaseMongoDB,
+	"mongodb+srv": ServiceDatabaseMongoDB,
+}
+
+var (
+	composeFiles    = []string{"docker-compose.yml", "docker-compose.yaml", "compose.yml", "compose.yaml"}
+	envExampleFiles = []string{".env.example", ".env.sample", ".env.template"}
+)
+
+// ServiceContainers returns the service containers of the databases used by the project in projectDir, and the files configuring them.
+// The image and the credentials are taken from the docker-compose file of the project, the name of the connection URL env var and
+// the database name from its .env example file, falling back to the official images with default credentials.
+func ServiceContainers(projectDir string, databases []string) ([]ServiceContainer, []string) {
+	var (
+		containers []ServiceContainer
+		sources    []string
+	)
+
+	composeServices, composeFile := readComposeServices(projectDir)
+	envExample, envExampleFile := readEnvExample(projectDir)
+
+	for _, database := range databases {
+		container, ok := defaultServiceContainers[database]
+		if !ok {
+			continue
+		}
+
+		if service, ok := composeServices[database]; ok {
+			applyComposeService(&container, service)
+			sources = appendIfMissing(sources, composeFile)
+		}
+		if key, u, ok := findDatabaseURL(envExample, database); ok {
+			applyEnvExampleURL(&container, key, u)
+			sources = appendIfMissing(sources, envExampleFile)
+		}
+
+		containers = append(containers, container)
+	}
+
+	return containers, sources
+}
+
+// ServiceContainerDefinitions returns the container definitions of the bitrise.yml.
+func ServiceContainerDefinitions(containers []ServiceContainer) map[string]bitriseModels.Container {
+	definitions := map[string]bitriseModels.Container{}
+	for _, container := range containers {
+		definitions[container.Name] = bitriseModels.Container{
+			Type:    bitriseModels.ContainerTypeService,
+			Image:   container.Image,
+			Ports:   container.Ports,
+			Envs:    container.Envs(),
+			Options: container.HealthCheck,
+		}
+	}
+	return definitions
+}
+
+// ServiceContainerReferences returns the service containers to attach to the test steps.
+func ServiceContainerReferences(containers []ServiceContainer) []stepmanModels.ContainerReference {
+	var refs []stepmanModels.ContainerReference
+	for _, container := range containers {
+		refs = append(refs, container.Name)
+	}
+	return refs
+}
+
+// ServiceContainerAppEnvs returns the connection URL env vars of the app.
+func ServiceContainerAppEnvs(containers []ServiceContainer) []envmanModels.EnvironmentItemModel {
+	var envs []envmanModels.EnvironmentItemModel
+	seen := map[string]bool{}
+	for _, container := range containers {
+		if seen[container.URLEnvKey] {
+			continue
+		}
+		seen[container.URLEnvKey] = true
+		envs = append(envs, envmanModels.EnvironmentItemModel{container.URLEnvKey: container.URL()})
+	}
+	return envs
+}
+
+type composeService struct {
+	Image       string      `yaml:"image"`
+	Environment interface{} `yaml:"environment"`
+}
+
+// readComposeServices returns the database services of the docker-compose file, keyed by the database.
+func readComposeServices(projectDir string) (map[string]composeService, string) {
+	for _, file := range composeFiles {
+		pth := filepath.Join(projectDir, file)
+		if !FileExists(pth) {
+			continue
+		}
+
+		content, err := metrics.ReadStringFromFile(pth)
+		if err != nil {
+			return nil, ""
+		}
+		var compose struct {
+			Services map[string]composeService `yaml:"services"`
+		}
+		if err := yaml.Unmarshal([]byte(content), &compose); err != nil {
+			return nil, ""
+		}
+
+		names := make([]string, 0, len(compose.Services))
+		for name := range compose.Services {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		services := map[string]composeService{}
+		for _, name := range names {
+			service := compose.Services[name]
+			if database, ok := serviceImageNames[imageName(service.Image)]; ok {
+				if _, ok := services[database]; !ok {
+					services[database] = service
+				}
+			}
+		}
+		return services, file
+	}
+	return nil, ""
+}
+
+// imageTag returns the tag of a Docker image reference, like 16-alpine of postgres:16-alpine, empty if the reference has no tag.
+func imageTag(image string) string {
+	if i := strings.Index(image, "@"); i != -1 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i != -1 && !strings.Contains(image[i:], "/") {
+		return image[i+1:]
+	}
+	return ""
+}
+
+// imageName returns the name of a Docker image reference, like postgres of docker.io/library/postgres:16-alpine.
+func imageName(image string) string {
+	name := image
+	if i := strings.LastIndex(name, "/"); i != -1 {
+		name = name[i+1:]
+	}
+	if i := strings.IndexAny(name, ":@"); i != -1 {
+		name = name[:i]
+	}
+	return name
+}
+
+func applyComposeService(container *ServiceContainer, service composeService) {
+	// Images with variables (like postgres:${POSTGRES_VERSION}) can not be resolved
+	if service.Image != "" && !strings.Contains(service.Image, "$") {
+		container.Image = service.Image
+		container.HealthCheck = imageHealthCheck(*container)
+	}
+
+	envs := composeEnvironment(service.Environment)
+	switch container.Name {
+	case ServiceDatabasePostgres:
+		setIfNotEmpty(&container.username, envs["POSTGRES_USER"])
+		setIfNotEmpty(&container.password, envs["POSTGRES_PASSWORD"])
+		setIfNotEmpty(&container.database, envs["POSTGRES_DB"])
+	case ServiceDatabaseMySQL:
+		if envs["MYSQL_USER"] != "" && envs["MYSQL_PASSWORD"] != "" {
+			container.username = envs["MYSQL_USER"]
+			container.password = envs["MYSQL_PASSWORD"]
+		} else {
+			setIfNotEmpty(&container.password, envs["MYSQL_ROOT_PASSWORD"])
+		}
+		setIfNotEmpty(&container.database, envs["MYSQL_DATABASE"])
+	}
+}
+
+// composeEnvironment returns the environment of a compose service, given either as a map or as a list of KEY=VALUE items.
+func composeEnvironment(environment interface{}) map[string]string {
+	envs := map[string]string{}
+	switch environment := environment.(type) {
+	case map[interface{}]interface{}:
+		for key, value := range environment {
+			envs[fmt.Sprint(key)] = fmt.Sprint(value)
+		}
+	case []interface{}:
+		for _, item := range environment {
+			if key, value, found := strings.Cut(fmt.Sprint(item), "="); found {
+				envs[key] = value
+			}
+		}
+	}
+	for key, value := range envs {
+		// Values referring to variables (like ${POSTGRES_PASSWORD:-secret}) can not be resolved
+		if strings.Contains(value, "$") {
+			delete(envs, key)
+		}
+	}
+	return envs
+}
+
+// readEnvExample returns the KEY=VALUE pairs of the .env example file, in the order of the file.
+func readEnvExample(projectDir string) ([][2]string, string) {
+	for _, file := range envExampleFiles {
+		pth := filepath.Join(projectDir, file)
+		if !FileExists(pth) {
+			continue
+		}
+
+		content, err := metrics.ReadStringFromFile(pth)
+		if err != nil {
+			return nil, ""
+		}
+
+		var pairs [][2]string
+		for _, line := range strings.Split(content, "\n") {
+			line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "export "))
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			if key, value, found := strings.Cut(line, "="); found {
+				pairs = append(pairs, [2]string{strings.TrimSpace(key), strings.Trim(strings.TrimSpace(value), `"'`)})
+			}
+		}
+		return pairs, file
+	}
+	return nil, ""
+}
+
+// findDatabaseURL returns the first env var of the .env example file holding a connection URL of the database.
+func findDatabaseURL(envExample [][2]string, database string) (string, *url.URL, bool) {
+	for _, pair := range envExample {
+		u, err := url.Parse(pair[1])
+		if err != nil || urlSchemes[u.Scheme] != database {
+			continue
+		}
+		return pair[0], u, true
+	}
+	return "", nil, false
+}
+
+// applyEnvExampleURL uses the env var name, the scheme and the database name of the example connection URL.
+// The credentials of the URL are used if the docker-compose file did not set them.
+func applyEnvExampleURL(container *ServiceContainer, key string, u *url.URL) {
+	container.URLEnvKey = key
+	if !strings.HasSuffix(u.Scheme, "+srv") {
+		container.urlScheme = u.Scheme
+	}
+	setIfNotEmpty(&container.database, strings.Trim(u.Path, "/"))
+
+	defaults := defaultServiceContainers[container.Name]
+	if u.User != nil && container.username == defaults.username && container.password == defaults.password && container.IsRelational {
+		password, _ := u.User.Password()
+		if u.User.Username() != "" && password != "" && !strings.Contains(password, "$") {
+			container.username = u.User.Username()
+			container.password = password
+		}
+	}
+}
+
+func setIfNotEmpty(field *string, value string) {
+	if value != "" {
+		*field = value
+	}
+}
+
+func appendIfMissing(items []string, item string) []string {
+	for _, existing := range items {
+		if existing == item {
+			return items
+		}
+	}
+	return append(items, item)
+}
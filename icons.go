@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"image/png"
@@ -13,6 +14,7 @@ import (
 	"github.com/bitrise-io/bitrise-init/models"
 	"github.com/bitrise-io/go-utils/log"
 	"github.com/bitrise-io/go-utils/retry"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type appIconCandidateURL struct {
@@ -26,11 +28,18 @@ type iconCandidateQuery struct {
 	buildTriggerToken string
 }
 
-func uploadIcons(icons []models.Icon, query iconCandidateQuery) error {
+func uploadIcons(ctx context.Context, icons []models.Icon, query iconCandidateQuery) (err error) {
 	log.TInfof("Validating app icons.")
+	_, validateSpan := tracer.Start(ctx, "validate icons")
+	validateSpan.SetAttributes(attribute.Int("icons.count", len(icons)))
 	icons = filterValidIcons(icons)
+	validateSpan.SetAttributes(attribute.Int("icons.valid_count", len(icons)))
+	validateSpan.End()
 
 	log.TInfof("Submitting app icons...")
+	_, uploadSpan := tracer.Start(ctx, "upload icons")
+	defer func() { endSpan(uploadSpan, err) }()
+
 	nameToPath := map[string]string{}
 	for _, icon := range icons {
 		nameToPath[icon.Filename] = icon.Path
@@ -57,6 +66,7 @@ func uploadIcons(icons []models.Icon, query iconCandidateQuery) error {
 		return nil
 	}
 
+	uploadSpan.SetAttributes(attribute.Int("icons.candidate_count", len(candidates)))
 	candidateURLs, err := getUploadURLs(query, candidates)
 	if err != nil {
 		return fmt.Errorf("failed to get candidate target URLs, error: %s", err)
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"image"
@@ -143,7 +144,7 @@ func Test_uploadIcons(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := uploadIcons(tt.args.icons, tt.args.query); (err != nil) != tt.wantErr {
+			if err := uploadIcons(context.Background(), tt.args.icons, tt.args.query); (err != nil) != tt.wantErr {
 				t.Errorf("uploadIcons() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/bitrise-io/bitrise-init/scanner"
+	"github.com/bitrise-io/bitrise-init/scanners"
 	"github.com/bitrise-io/go-steputils/step"
 	"github.com/bitrise-io/go-steputils/stepconf"
 	"github.com/bitrise-io/go-utils/command"
@@ -24,6 +26,7 @@ import (
 	"github.com/bitrise-steplib/steps-git-clone/gitclone/bitriseapi"
 	"github.com/bitrise-steplib/steps-git-clone/gitclone/tracker"
 	"github.com/bitrise-steplib/steps-git-clone/transport"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type config struct {
@@ -33,6 +36,9 @@ type config struct {
 	IconCandidatesURL    string          `env:"icon_candidates_url"`
 	DebugLog             bool            `env:"verbose_log,opt[false,true]"`
 
+	// Generate Xcode projects of Tuist and XcodeGen manifests before scanning
+	GenerateXcodeProjects bool `env:"generate_xcode_projects"`
+
 	// Enable activate SSH key and git clone
 	EnableRepoClone bool `env:"enable_repo_clone"`
 
@@ -46,10 +52,15 @@ type config struct {
 	// Git clone step
 	RepositoryURL string `env:"repository_url"`
 	Branch        string `env:"branch"`
+
+	// Tracing
+	OTLPTracesEndpoint string `env:"otlp_traces_endpoint"`
+	TraceOutputPath    string `env:"trace_output_path"`
 }
 
 func failf(format string, args ...interface{}) {
 	log.TErrorf(format, args...)
+	stopTracing()
 	os.Exit(1)
 }
 
@@ -77,7 +88,10 @@ type repoConfig struct {
 	Branch           string
 }
 
-func cloneRepo(cfg repoConfig) error {
+func cloneRepo(ctx context.Context, cfg repoConfig) (err error) {
+	ctx, span := tracer.Start(ctx, "clone")
+	defer func() { endSpan(span, err) }()
+
 	cfg.RepositoryURL = strings.TrimSpace(cfg.RepositoryURL)
 	cfg.Branch = strings.TrimSpace(cfg.Branch)
 	if cfg.RepositoryURL == "" {
@@ -99,11 +113,14 @@ func cloneRepo(cfg repoConfig) error {
 
 	// Activate SSH key is optional
 	if cfg.SSHRsaPrivateKey != "" {
-		if err := activatesshkey.Execute(activatesshkey.Config{
+		_, sshSpan := tracer.Start(ctx, "activate SSH key")
+		err := activatesshkey.Execute(activatesshkey.Config{
 			SSHRsaPrivateKey:        cfg.SSHRsaPrivateKey,
 			SSHKeySavePath:          path.Join(pathutil.UserHomeDir(), ".ssh", "steplib_ssh_step_id_rsa"),
 			IsRemoveOtherIdentities: false,
-		}); err != nil {
+		})
+		endSpan(sshSpan, err)
+		if err != nil {
 			return newStepError(
 				"activate_ssh_key_failed",
 				err,
@@ -113,11 +130,14 @@ func cloneRepo(cfg repoConfig) error {
 	}
 
 	// Activate Git HTTP credentials
-	if err := transport.Setup(transport.Config{
+	_, transportSpan := tracer.Start(ctx, "setup git transport")
+	err = transport.Setup(transport.Config{
 		URL:          cfg.RepositoryURL,
 		HTTPUsername: string(cfg.GitHTTPUsername),
 		HTTPPassword: string(cfg.GitHTTPPassword),
-	}); err != nil {
+	})
+	endSpan(transportSpan, err)
+	if err != nil {
 		return newStepError(
 			"activate_git_http_credentials_failed",
 			err,
@@ -143,7 +163,11 @@ func cloneRepo(cfg repoConfig) error {
 
 		UpdateSubmodules: true,
 	}
-	if _, err := gitcloner.CheckoutState(config); err != nil {
+	_, checkoutSpan := tracer.Start(ctx, "checkout")
+	checkoutSpan.SetAttributes(attribute.String("git.branch", cfg.Branch))
+	_, err = gitcloner.CheckoutState(config)
+	endSpan(checkoutSpan, err)
+	if err != nil {
 		if _, ok := err.(*step.Error); ok {
 			return err
 		}
@@ -169,6 +193,14 @@ func main() {
 	stepconf.Print(cfg)
 	log.SetEnableDebugLog(cfg.DebugLog)
 
+	ctx, err := startTracing(context.Background(), tracingConfig{
+		OTLPEndpoint: cfg.OTLPTracesEndpoint,
+		OutputPath:   cfg.TraceOutputPath,
+	})
+	if err != nil {
+		log.TWarnf("Tracing disabled: %s", err)
+	}
+
 	var resultClient *resultClient
 	// Local file path can be specified with the 'path::' prefix. This can be used for debugging scan results locally.
 	isLocalResultSubmitURL := strings.HasPrefix(cfg.ResultSubmitURL, "path::")
@@ -177,7 +209,6 @@ func main() {
 			log.TWarnf("Build trigger token is empty.")
 		}
 
-		var err error
 		if resultClient, err = newResultClient(cfg.ResultSubmitURL, cfg.ResultSubmitAPIToken); err != nil {
 			failf(fmt.Sprintf("%v", err))
 		}
@@ -191,13 +222,13 @@ func main() {
 		handleStepError := func(stepID, tag string, err error, shortMsg string) {
 			LogError(stepID, tag, err, "%s", shortMsg)
 			if resultClient != nil {
-				if err := resultClient.uploadErrorResult(stepID, err); err != nil {
+				if err := resultClient.uploadErrorResult(ctx, stepID, err); err != nil {
 					log.TWarnf("Failed to submit result: %s", err)
 				}
 			}
 		}
 
-		if err := cloneRepo(repoConfig{
+		if err := cloneRepo(ctx, repoConfig{
 			CloneIntoDir:     cfg.ScanDirectory,
 			RepositoryURL:    cfg.RepositoryURL,
 			SSHRsaPrivateKey: cfg.SSHRsaPrivateKey,
@@ -222,13 +253,18 @@ func main() {
 	}
 
 	hasSSHKey := cfg.SSHRsaPrivateKey != ""
-	result, platformsDetected := scanner.GenerateScanResult(searchDir, hasSSHKey)
+	result, platformsDetected := scanner.GenerateScanResultWithContext(ctx, searchDir, hasSSHKey, scanners.Options{
+		GenerateXcodeProjects: cfg.GenerateXcodeProjects,
+	})
 
 	// Store results
 	shouldSaveToFile := isLocalResultSubmitURL
 	shouldStoreResult := resultClient != nil || shouldSaveToFile
 	if shouldStoreResult {
+		_, marshalSpan := tracer.Start(ctx, "marshal result")
 		resultBytes, err := json.MarshalIndent(result, "", "\t")
+		marshalSpan.SetAttributes(attribute.Int("result.size_bytes", len(resultBytes)))
+		endSpan(marshalSpan, err)
 		if err != nil {
 			failf("failed to marshal results: %v", err)
 		}
@@ -243,7 +279,7 @@ func main() {
 			log.TDonef("Results file created.")
 		} else {
 			log.TInfof("Submitting results...")
-			if err := resultClient.uploadResults(resultBytes); err != nil {
+			if err := resultClient.uploadResults(ctx, resultBytes); err != nil {
 				failf("Could not send results: %s", err)
 			}
 
@@ -253,7 +289,7 @@ func main() {
 
 	// Upload icons
 	if strings.TrimSpace(cfg.IconCandidatesURL) != "" {
-		if err := uploadIcons(result.Icons,
+		if err := uploadIcons(ctx, result.Icons,
 			iconCandidateQuery{
 				URL:               cfg.IconCandidatesURL,
 				buildTriggerToken: string(cfg.ResultSubmitAPIToken),
@@ -267,4 +303,5 @@ func main() {
 		failf("No known platform detected")
 	}
 	log.TDonef("Scan finished.")
+	stopTracing()
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -15,6 +16,9 @@ import (
 	"github.com/bitrise-io/go-steputils/stepconf"
 	"github.com/bitrise-io/go-utils/log"
 	"github.com/bitrise-io/go-utils/retry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 type resultClient struct {
@@ -82,26 +86,32 @@ func buildErrorScanResultModel(stepID string, err error) models.ScanResultModel
 	}
 }
 
-func (c *resultClient) uploadErrorResult(stepID string, err error) error {
+func (c *resultClient) uploadErrorResult(ctx context.Context, stepID string, err error) error {
 	result := buildErrorScanResultModel(stepID, err)
 	resultBytes, err := json.MarshalIndent(result, "", "\t")
 	if err != nil {
 		failf("failed to marshal results: %v", err)
 	}
-	return c.uploadResults(resultBytes)
+	return c.uploadResults(ctx, resultBytes)
 }
 
-func (c *resultClient) uploadResults(bytes []byte) error {
+func (c *resultClient) uploadResults(ctx context.Context, bytes []byte) (err error) {
+	ctx, span := tracer.Start(ctx, "submit result")
+	defer func() { endSpan(span, err) }()
+
 	if err := retry.Times(1).Wait(5 * time.Second).Try(func(attempt uint) error {
 		if attempt != 0 {
 			log.TWarnf("%d query attempt failed", attempt)
 		}
+		span.SetAttributes(attribute.Int("http.request.resend_count", int(attempt)))
 
-		req, err := http.NewRequest(http.MethodPost, c.URL.String(), strings.NewReader(string(bytes)))
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL.String(), strings.NewReader(string(bytes)))
 		if err != nil {
 			return fmt.Errorf("failed to create request: %v", err)
 		}
 		req.Header.Add("Content-Type", "application/json")
+		// Propagating the trace context, so that the result processing can be linked to the scan
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
 
 		reqDump, err := httputil.DumpRequestOut(req, true)
 		if err != nil {
@@ -132,6 +142,7 @@ func (c *resultClient) uploadResults(bytes []byte) error {
 			}
 		}
 
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
 		if resp.StatusCode != http.StatusOK {
 			log.TErrorf("Submit failed, url: %s request: %s, response: %s", c.URL.String(), reqDump, respDump)
 
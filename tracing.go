@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName         = "github.com/bitrise-steplib/steps-project-scanner"
+	tracingServiceName = "steps-project-scanner"
+	tracingStopTimeout = 10 * time.Second
+)
+
+var tracer = otel.Tracer(tracerName)
+
+type tracingConfig struct {
+	OTLPEndpoint string
+	OutputPath   string
+}
+
+// tracing holds the trace exporters of the step run and the span covering the whole run.
+type tracing struct {
+	provider *sdktrace.TracerProvider
+	stepSpan trace.Span
+	closers  []func() error
+}
+
+// activeTracing is set while the step run is traced, so that failf can flush the spans before exiting.
+var activeTracing *tracing
+
+// startTracing sets up the global tracer provider and propagator and starts the span of the whole step run.
+// Without an OTLP endpoint or output path tracing stays disabled and the returned context is the given one.
+func startTracing(ctx context.Context, cfg tracingConfig) (context.Context, error) {
+	cfg.OTLPEndpoint = strings.TrimSpace(cfg.OTLPEndpoint)
+	cfg.OutputPath = strings.TrimSpace(cfg.OutputPath)
+	if cfg.OTLPEndpoint == "" && cfg.OutputPath == "" {
+		return ctx, nil
+	}
+
+	t := &tracing{}
+	var opts []sdktrace.TracerProviderOption
+	var exporters []sdktrace.SpanExporter
+	if cfg.OTLPEndpoint != "" {
+		exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+		if err != nil {
+			return ctx, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+		}
+		exporters = append(exporters, exporter)
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	if cfg.OutputPath != "" {
+		file, err := os.Create(cfg.OutputPath)
+		if err != nil {
+			t.discard(ctx, exporters)
+			return ctx, fmt.Errorf("failed to create trace output file: %w", err)
+		}
+		t.closers = append(t.closers, file.Close)
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			t.discard(ctx, exporters)
+			return ctx, fmt.Errorf("failed to create file trace exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithSyncer(exporter))
+	}
+	opts = append(opts, sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(tracingServiceName))))
+
+	t.provider = sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(t.provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	ctx, t.stepSpan = tracer.Start(ctx, "step run")
+	activeTracing = t
+	return ctx, nil
+}
+
+// stopTracing ends the span of the step run and flushes the exported spans.
+func stopTracing() {
+	t := activeTracing
+	if t == nil {
+		return
+	}
+	activeTracing = nil
+
+	t.stepSpan.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), tracingStopTimeout)
+	defer cancel()
+	if err := t.provider.Shutdown(ctx); err != nil {
+		log.TWarnf("Failed to flush traces: %s", err)
+	}
+	for _, closer := range t.closers {
+		if err := closer(); err != nil {
+			log.TWarnf("Failed to close trace output: %s", err)
+		}
+	}
+}
+
+// discard shuts down the exporters and closes the trace outputs created before setting up tracing failed.
+func (t *tracing) discard(ctx context.Context, exporters []sdktrace.SpanExporter) {
+	for _, exporter := range exporters {
+		if err := exporter.Shutdown(ctx); err != nil {
+			log.TWarnf("Failed to shut down trace exporter: %s", err)
+		}
+	}
+	for _, closer := range t.closers {
+		if err := closer(); err != nil {
+			log.TWarnf("Failed to close trace output: %s", err)
+		}
+	}
+}
+
+// endSpan records the error (if any) on the span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
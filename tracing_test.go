@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bitrise-io/go-steputils/stepconf"
+)
+
+func Test_tracing(t *testing.T) {
+	// Stand-in for an OTLP collector, recording the paths of the export requests
+	var collectorMu sync.Mutex
+	var collectorPaths []string
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.Copy(io.Discard, r.Body); err != nil {
+			t.Errorf("collector: failed to read body: %s", err)
+		}
+		collectorMu.Lock()
+		collectorPaths = append(collectorPaths, r.URL.Path)
+		collectorMu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	var traceParent string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+
+	outputPath := filepath.Join(t.TempDir(), "traces.json")
+	ctx, err := startTracing(context.Background(), tracingConfig{
+		OTLPEndpoint: collector.URL + "/v1/traces",
+		OutputPath:   outputPath,
+	})
+	if err != nil {
+		t.Fatalf("startTracing() error = %v", err)
+	}
+
+	client, err := newResultClient(api.URL, stepconf.Secret("token"))
+	if err != nil {
+		t.Fatalf("newResultClient() error = %v", err)
+	}
+	if err := client.uploadResults(ctx, []byte("{}")); err != nil {
+		t.Fatalf("uploadResults() error = %v", err)
+	}
+
+	stopTracing()
+
+	traceParentParts := strings.Split(traceParent, "-")
+	if len(traceParentParts) != 4 {
+		t.Fatalf("result submission request has invalid traceparent header: %s", traceParent)
+	}
+
+	traces, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read trace output: %s", err)
+	}
+	if !strings.Contains(string(traces), `"TraceID":"`+traceParentParts[1]+`"`) {
+		t.Errorf("traceparent header (%s) does not belong to the exported trace", traceParent)
+	}
+	for _, spanName := range []string{"step run", "submit result"} {
+		if !strings.Contains(string(traces), `"Name":"`+spanName+`"`) {
+			t.Errorf("trace output does not contain span: %s", spanName)
+		}
+	}
+
+	collectorMu.Lock()
+	defer collectorMu.Unlock()
+	if len(collectorPaths) == 0 || collectorPaths[0] != "/v1/traces" {
+		t.Errorf("collector export requests = %v, want requests to /v1/traces", collectorPaths)
+	}
+}
+
+func Test_startTracing_disabled(t *testing.T) {
+	ctx := context.Background()
+	got, err := startTracing(ctx, tracingConfig{})
+	if err != nil {
+		t.Fatalf("startTracing() error = %v", err)
+	}
+	if got != ctx {
+		t.Errorf("startTracing() returned a new context with tracing disabled")
+	}
+	if activeTracing != nil {
+		t.Errorf("startTracing() activated tracing without exporters")
+	}
+}
+
+func Test_startTracing_outputFileError(t *testing.T) {
+	ctx := context.Background()
+	got, err := startTracing(ctx, tracingConfig{
+		OTLPEndpoint: "http://localhost:4318/v1/traces",
+		OutputPath:   filepath.Join(t.TempDir(), "missing", "traces.json"),
+	})
+	if err == nil {
+		t.Fatalf("startTracing() expected an error for an output path in a missing directory")
+	}
+	if got != ctx {
+		t.Errorf("startTracing() returned a new context after failing")
+	}
+	if activeTracing != nil {
+		t.Errorf("startTracing() activated tracing after failing")
+	}
+}